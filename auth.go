@@ -2,28 +2,50 @@ package platform
 
 import (
 	"context"
+	"fmt"
+	"time"
 )
 
 // Authorization is a authorization. 🎉
+//
+// The token of an authorization is only known when it is created; the
+// services store a hash of it and leave Token empty when finding
+// authorizations.
 type Authorization struct {
 	ID          ID           `json:"id,omitempty"`
-	Token       string       `json:"token"`
+	Token       string       `json:"token,omitempty"`
 	Status      Status       `json:"status"`
+	Description string       `json:"description,omitempty"`
 	User        string       `json:"user,omitempty"`
 	UserID      ID           `json:"userID,omitempty"`
+	OrgID       ID           `json:"orgID,omitempty"`
 	Permissions []Permission `json:"permissions,omitempty"`
+
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
 }
 
-// Allowed returns true if the authorization is active and request permission
-// exists in the authorization's list of permissions.
+// Allowed returns true if the authorization is active and unexpired and
+// request permission exists in the authorization's list of permissions.
 func (a *Authorization) Allowed(p Permission) bool {
-	if !a.IsActive() {
+	if !a.IsActive() || a.Expired() != nil {
 		return false
 	}
 
 	return allowed(p, a.Permissions)
 }
 
+// Expired returns an error if the authorization has expired. Authorizations
+// without an expiry never expire.
+func (a *Authorization) Expired() error {
+	if a.ExpiresAt != nil && time.Now().After(*a.ExpiresAt) {
+		return fmt.Errorf("authorization has expired")
+	}
+
+	return nil
+}
+
 // IsActive is a stub for idpe.
 func IsActive(a *Authorization) bool {
 	return a.IsActive()
@@ -78,6 +100,13 @@ type AuthorizationService interface {
 	DeleteAuthorization(ctx context.Context, id ID) error
 }
 
+// AuthorizationUsageRecorder records when authorizations are used.
+type AuthorizationUsageRecorder interface {
+	// RecordAuthorizationUsage records that the authorization id was used at t.
+	// The usage may be batched and only stored some time later.
+	RecordAuthorizationUsage(ctx context.Context, id ID, t time.Time)
+}
+
 // AuthorizationFilter represents a set of filter that restrict the returned results.
 type AuthorizationFilter struct {
 	Token *string
@@ -0,0 +1,63 @@
+package platform_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+func TestAuthorization_Allowed(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	p := platform.ReadBucketPermission(1)
+
+	tests := []struct {
+		name          string
+		authorization platform.Authorization
+		want          bool
+	}{
+		{
+			name: "never expires",
+			authorization: platform.Authorization{
+				Status:      platform.Active,
+				Permissions: []platform.Permission{p},
+			},
+			want: true,
+		},
+		{
+			name: "not yet expired",
+			authorization: platform.Authorization{
+				Status:      platform.Active,
+				ExpiresAt:   &future,
+				Permissions: []platform.Permission{p},
+			},
+			want: true,
+		},
+		{
+			name: "expired",
+			authorization: platform.Authorization{
+				Status:      platform.Active,
+				ExpiresAt:   &past,
+				Permissions: []platform.Permission{p},
+			},
+			want: false,
+		},
+		{
+			name: "inactive",
+			authorization: platform.Authorization{
+				Status:      platform.Inactive,
+				Permissions: []platform.Permission{p},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.authorization.Allowed(p); got != tt.want {
+				t.Errorf("expected allowed to be %v got %v", tt.want, got)
+			}
+		})
+	}
+}
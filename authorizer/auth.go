@@ -0,0 +1,129 @@
+package authorizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	platcontext "github.com/influxdata/platform/context"
+)
+
+var _ platform.AuthorizationService = (*AuthorizationService)(nil)
+
+// AuthorizationService wraps a platform.AuthorizationService and authorizes
+// actions against it appropriately. Users may always manage their own
+// authorizations, but may only grant the permissions they are allowed.
+type AuthorizationService struct {
+	s platform.AuthorizationService
+}
+
+// NewAuthorizationService constructs an instance of an authorizing authorization service.
+func NewAuthorizationService(s platform.AuthorizationService) *AuthorizationService {
+	return &AuthorizationService{
+		s: s,
+	}
+}
+
+// authorizeAuthorization returns an error unless the authorization a belongs
+// to the user of the authorizer on ctx, or the authorizer is allowed p.
+func authorizeAuthorization(ctx context.Context, p platform.Permission, a *platform.Authorization) error {
+	if isUser(ctx, a.UserID) {
+		return nil
+	}
+	return authorize(ctx, p)
+}
+
+// FindAuthorizationByID checks to see if the authorizer on context has read access to the id provided.
+func (s *AuthorizationService) FindAuthorizationByID(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+	a, err := s.s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	p := platform.NewPermission(platform.ReadAction, platform.AuthorizationResourceType, a.OrgID, a.ID)
+	if err := authorizeAuthorization(ctx, p, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// FindAuthorizationByToken returns the authorization of the token. Knowing
+// the token is enough to read its authorization, so it is not authorized.
+func (s *AuthorizationService) FindAuthorizationByToken(ctx context.Context, t string) (*platform.Authorization, error) {
+	return s.s.FindAuthorizationByToken(ctx, t)
+}
+
+// FindAuthorizations retrieves all authorizations that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *AuthorizationService) FindAuthorizations(ctx context.Context, filter platform.AuthorizationFilter, opt ...platform.FindOptions) ([]*platform.Authorization, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	as, _, err := s.s.FindAuthorizations(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	auths := as[:0]
+	for _, a := range as {
+		p := platform.NewPermission(platform.ReadAction, platform.AuthorizationResourceType, a.OrgID, a.ID)
+		if authorizeAuthorization(ctx, p, a) == nil {
+			auths = append(auths, a)
+		}
+	}
+
+	return auths, len(auths), nil
+}
+
+// CreateAuthorization checks to see if the authorizer on context may create
+// the authorization, and is allowed every permission it grants.
+func (s *AuthorizationService) CreateAuthorization(ctx context.Context, a *platform.Authorization) error {
+	if !isUser(ctx, a.UserID) {
+		if err := authorizeCreate(ctx, platform.AuthorizationResourceType, a.OrgID); err != nil {
+			return err
+		}
+	}
+
+	auth, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range a.Permissions {
+		if !auth.Allowed(p) {
+			return &platform.Error{
+				Code: platform.EForbidden,
+				Msg:  fmt.Sprintf("cannot grant %s without being allowed it", p),
+			}
+		}
+	}
+
+	return s.s.CreateAuthorization(ctx, a)
+}
+
+// SetAuthorizationStatus checks to see if the authorizer on context has write access to the authorization provided.
+func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
+	a, err := s.s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	p := platform.NewPermission(platform.WriteAction, platform.AuthorizationResourceType, a.OrgID, a.ID)
+	if err := authorizeAuthorization(ctx, p, a); err != nil {
+		return err
+	}
+
+	return s.s.SetAuthorizationStatus(ctx, id, status)
+}
+
+// DeleteAuthorization checks to see if the authorizer on context has delete access to the authorization provided.
+func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platform.ID) error {
+	a, err := s.s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	p := platform.NewPermission(platform.DeleteAction, platform.AuthorizationResourceType, a.OrgID, a.ID)
+	if err := authorizeAuthorization(ctx, p, a); err != nil {
+		return err
+	}
+
+	return s.s.DeleteAuthorization(ctx, id)
+}
@@ -0,0 +1,96 @@
+// Package authorizer provides decorators of the platform services that check
+// the permissions of the authorizer found on the context before calling the
+// decorated service.
+//
+// A resource may be accessed through a permission on the resource itself, on
+// the resources of its organization, or on every resource of its type. Lists
+// are filtered to the resources the authorizer may read.
+package authorizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	platcontext "github.com/influxdata/platform/context"
+)
+
+// authorize returns an error unless the authorizer on ctx is allowed any of
+// the permissions ps.
+func authorize(ctx context.Context, ps ...platform.Permission) error {
+	a, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EForbidden,
+			Msg:  "unauthorized",
+			Err:  err,
+		}
+	}
+
+	if allowed(a, ps...) {
+		return nil
+	}
+	return &platform.Error{
+		Code: platform.EForbidden,
+		Msg:  fmt.Sprintf("%s is unauthorized", ps[0]),
+	}
+}
+
+// authorized returns true if the authorizer on ctx is allowed any of the
+// permissions ps.
+func authorized(ctx context.Context, ps ...platform.Permission) bool {
+	a, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return false
+	}
+	return allowed(a, ps...)
+}
+
+func allowed(a platform.Authorizer, ps ...platform.Permission) bool {
+	for _, p := range ps {
+		if a.Allowed(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUser returns true if the authorizer on ctx belongs to user id.
+func isUser(ctx context.Context, id platform.ID) bool {
+	a, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return false
+	}
+	return id.Valid() && a.GetUserID() == id
+}
+
+func authorizeRead(ctx context.Context, t platform.ResourceType, orgID, id platform.ID) error {
+	return authorize(ctx, platform.NewPermission(platform.ReadAction, t, orgID, id))
+}
+
+func authorizeWrite(ctx context.Context, t platform.ResourceType, orgID, id platform.ID) error {
+	return authorize(ctx, platform.NewPermission(platform.WriteAction, t, orgID, id))
+}
+
+func authorizeDelete(ctx context.Context, t platform.ResourceType, orgID, id platform.ID) error {
+	return authorize(ctx, platform.NewPermission(platform.DeleteAction, t, orgID, id))
+}
+
+// authorizeCreate returns an error unless the authorizer on ctx may create
+// resources of type t in the organization orgID, or may write to the
+// organization. Resources that do not belong to an organization are created
+// with an invalid orgID, and require the permission to create every resource
+// of type t.
+func authorizeCreate(ctx context.Context, t platform.ResourceType, orgID platform.ID) error {
+	ps := []platform.Permission{
+		platform.NewPermission(platform.CreateAction, t, orgID, platform.InvalidID()),
+	}
+	if orgID.Valid() {
+		ps = append(ps, platform.Permission{Action: platform.WriteAction, Resource: platform.OrgResource(orgID)})
+	}
+	return authorize(ctx, ps...)
+}
+
+func canRead(ctx context.Context, t platform.ResourceType, orgID, id platform.ID) bool {
+	return authorized(ctx, platform.NewPermission(platform.ReadAction, t, orgID, id))
+}
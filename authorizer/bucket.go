@@ -0,0 +1,107 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.BucketService = (*BucketService)(nil)
+
+// BucketService wraps a platform.BucketService and authorizes actions
+// against it appropriately.
+type BucketService struct {
+	s platform.BucketService
+}
+
+// NewBucketService constructs an instance of an authorizing bucket service.
+func NewBucketService(s platform.BucketService) *BucketService {
+	return &BucketService{
+		s: s,
+	}
+}
+
+// bucketPermission returns the permission for action a on the bucket b.
+func bucketPermission(a platform.Action, b *platform.Bucket) platform.Permission {
+	return platform.NewPermission(a, platform.BucketResourceType, b.OrganizationID, b.ID)
+}
+
+// authorizeBucket looks up the bucket id and checks to see if the authorizer
+// on context is allowed action a on it.
+func (s *BucketService) authorizeBucket(ctx context.Context, a platform.Action, id platform.ID) (*platform.Bucket, error) {
+	b, err := s.s.FindBucketByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, bucketPermission(a, b)); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// FindBucketByID checks to see if the authorizer on context has read access to the id provided.
+func (s *BucketService) FindBucketByID(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+	return s.authorizeBucket(ctx, platform.ReadAction, id)
+}
+
+// FindBucket retrieves the bucket and checks to see if the authorizer on context has read access to the bucket.
+func (s *BucketService) FindBucket(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+	b, err := s.s.FindBucket(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, bucketPermission(platform.ReadAction, b)); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// FindBuckets retrieves all buckets that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *BucketService) FindBuckets(ctx context.Context, filter platform.BucketFilter, opt ...platform.FindOptions) ([]*platform.Bucket, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	bs, _, err := s.s.FindBuckets(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	buckets := bs[:0]
+	for _, b := range bs {
+		if authorized(ctx, bucketPermission(platform.ReadAction, b)) {
+			buckets = append(buckets, b)
+		}
+	}
+
+	return buckets, len(buckets), nil
+}
+
+// CreateBucket checks to see if the authorizer on context may create buckets
+// in, or write to, the organization of the bucket.
+func (s *BucketService) CreateBucket(ctx context.Context, b *platform.Bucket) error {
+	if err := authorizeCreate(ctx, platform.BucketResourceType, b.OrganizationID); err != nil {
+		return err
+	}
+
+	return s.s.CreateBucket(ctx, b)
+}
+
+// UpdateBucket checks to see if the authorizer on context has write access to the bucket provided.
+func (s *BucketService) UpdateBucket(ctx context.Context, id platform.ID, upd platform.BucketUpdate) (*platform.Bucket, error) {
+	if _, err := s.authorizeBucket(ctx, platform.WriteAction, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateBucket(ctx, id, upd)
+}
+
+// DeleteBucket checks to see if the authorizer on context has delete access to the bucket provided.
+func (s *BucketService) DeleteBucket(ctx context.Context, id platform.ID) error {
+	if _, err := s.authorizeBucket(ctx, platform.DeleteAction, id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteBucket(ctx, id)
+}
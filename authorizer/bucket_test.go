@@ -0,0 +1,151 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
+	platcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+)
+
+func newAuthorizedContext(ps ...platform.Permission) context.Context {
+	return platcontext.SetAuthorizer(context.Background(), &platform.Authorization{
+		ID:          1,
+		UserID:      2,
+		Status:      platform.Active,
+		Permissions: ps,
+	})
+}
+
+func TestBucketService_FindBucketByID(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		wantErr     bool
+	}{
+		{
+			name:        "authorized to read the bucket",
+			permissions: []platform.Permission{platform.ReadBucketPermission(10)},
+		},
+		{
+			name: "authorized to read every bucket",
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.ResourceOfType(platform.BucketResourceType)},
+			},
+		},
+		{
+			name: "authorized to read the buckets of the organization",
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 20)},
+			},
+		},
+		{
+			name: "unauthorized to read the buckets of another organization",
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 21)},
+			},
+			wantErr: true,
+		},
+		{
+			name:        "unauthorized to read another bucket",
+			permissions: []platform.Permission{platform.ReadBucketPermission(11)},
+			wantErr:     true,
+		},
+		{
+			name:        "unauthorized to read with write access",
+			permissions: []platform.Permission{platform.WriteBucketPermission(10)},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mock.NewBucketService()
+			m.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+				return &platform.Bucket{ID: id, OrganizationID: 20}, nil
+			}
+			s := authorizer.NewBucketService(m)
+
+			_, err := s.FindBucketByID(newAuthorizedContext(tt.permissions...), 10)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EForbidden {
+				t.Errorf("expected error code %q, got %q", platform.EForbidden, platform.ErrorCode(err))
+			}
+		})
+	}
+}
+
+func TestBucketService_FindBuckets(t *testing.T) {
+	m := mock.NewBucketService()
+	m.FindBucketsFn = func(context.Context, platform.BucketFilter, ...platform.FindOptions) ([]*platform.Bucket, int, error) {
+		return []*platform.Bucket{{ID: 10}, {ID: 11}, {ID: 12}}, 3, nil
+	}
+	s := authorizer.NewBucketService(m)
+
+	ctx := newAuthorizedContext(platform.ReadBucketPermission(10), platform.ReadBucketPermission(12))
+	bs, n, err := s.FindBuckets(ctx, platform.BucketFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 || len(bs) != 2 || bs[0].ID != 10 || bs[1].ID != 12 {
+		t.Errorf("expected buckets 10 and 12, got %d buckets: %v", n, bs)
+	}
+}
+
+func TestBucketService_CreateBucket(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		wantErr     bool
+	}{
+		{
+			name: "authorized to write to the organization",
+			permissions: []platform.Permission{
+				{Action: platform.WriteAction, Resource: platform.OrgResource(20)},
+			},
+		},
+		{
+			name: "authorized to create buckets in the organization",
+			permissions: []platform.Permission{
+				{Action: platform.CreateAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 20)},
+			},
+		},
+		{
+			name: "authorized to create buckets",
+			permissions: []platform.Permission{
+				{Action: platform.CreateAction, Resource: platform.ResourceOfType(platform.BucketResourceType)},
+			},
+		},
+		{
+			name: "unauthorized to write to another organization",
+			permissions: []platform.Permission{
+				{Action: platform.WriteAction, Resource: platform.OrgResource(21)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			m := mock.NewBucketService()
+			m.CreateBucketFn = func(context.Context, *platform.Bucket) error {
+				created = true
+				return nil
+			}
+			s := authorizer.NewBucketService(m)
+
+			err := s.CreateBucket(newAuthorizedContext(tt.permissions...), &platform.Bucket{OrganizationID: 20})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if created == tt.wantErr {
+				t.Errorf("expected bucket to be created %v, got %v", !tt.wantErr, created)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.DashboardService = (*DashboardService)(nil)
+
+// DashboardService wraps a platform.DashboardService and authorizes actions
+// against it appropriately.
+type DashboardService struct {
+	s platform.DashboardService
+}
+
+// NewDashboardService constructs an instance of an authorizing dashboard service.
+func NewDashboardService(s platform.DashboardService) *DashboardService {
+	return &DashboardService{
+		s: s,
+	}
+}
+
+// authorizeDashboard looks up the dashboard id and checks to see if the
+// authorizer on context is allowed action a on it.
+func (s *DashboardService) authorizeDashboard(ctx context.Context, a platform.Action, id platform.ID) (*platform.Dashboard, error) {
+	d, err := s.s.FindDashboardByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, platform.NewPermission(a, platform.DashboardResourceType, d.OrganizationID, d.ID)); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// FindDashboardByID checks to see if the authorizer on context has read access to the id provided.
+func (s *DashboardService) FindDashboardByID(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+	return s.authorizeDashboard(ctx, platform.ReadAction, id)
+}
+
+// FindDashboards retrieves all dashboards that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *DashboardService) FindDashboards(ctx context.Context, filter platform.DashboardFilter, opts platform.FindOptions) ([]*platform.Dashboard, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	ds, _, err := s.s.FindDashboards(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dashboards := ds[:0]
+	for _, d := range ds {
+		if canRead(ctx, platform.DashboardResourceType, d.OrganizationID, d.ID) {
+			dashboards = append(dashboards, d)
+		}
+	}
+
+	return dashboards, len(dashboards), nil
+}
+
+// CreateDashboard checks to see if the authorizer on context may create
+// dashboards in the organization of the dashboard.
+func (s *DashboardService) CreateDashboard(ctx context.Context, d *platform.Dashboard) error {
+	if err := authorizeCreate(ctx, platform.DashboardResourceType, d.OrganizationID); err != nil {
+		return err
+	}
+
+	return s.s.CreateDashboard(ctx, d)
+}
+
+// UpdateDashboard checks to see if the authorizer on context has write access to the dashboard provided.
+func (s *DashboardService) UpdateDashboard(ctx context.Context, id platform.ID, upd platform.DashboardUpdate) (*platform.Dashboard, error) {
+	if _, err := s.authorizeDashboard(ctx, platform.WriteAction, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateDashboard(ctx, id, upd)
+}
+
+// AddDashboardCell checks to see if the authorizer on context has write access to the dashboard provided.
+func (s *DashboardService) AddDashboardCell(ctx context.Context, id platform.ID, c *platform.Cell, opts platform.AddDashboardCellOptions) error {
+	if _, err := s.authorizeDashboard(ctx, platform.WriteAction, id); err != nil {
+		return err
+	}
+
+	return s.s.AddDashboardCell(ctx, id, c, opts)
+}
+
+// RemoveDashboardCell checks to see if the authorizer on context has write access to the dashboard provided.
+func (s *DashboardService) RemoveDashboardCell(ctx context.Context, dashboardID, cellID platform.ID) error {
+	if _, err := s.authorizeDashboard(ctx, platform.WriteAction, dashboardID); err != nil {
+		return err
+	}
+
+	return s.s.RemoveDashboardCell(ctx, dashboardID, cellID)
+}
+
+// UpdateDashboardCell checks to see if the authorizer on context has write access to the dashboard provided.
+func (s *DashboardService) UpdateDashboardCell(ctx context.Context, dashboardID, cellID platform.ID, upd platform.CellUpdate) (*platform.Cell, error) {
+	if _, err := s.authorizeDashboard(ctx, platform.WriteAction, dashboardID); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateDashboardCell(ctx, dashboardID, cellID, upd)
+}
+
+// ReplaceDashboardCells checks to see if the authorizer on context has write access to the dashboard provided.
+func (s *DashboardService) ReplaceDashboardCells(ctx context.Context, id platform.ID, cs []*platform.Cell) error {
+	if _, err := s.authorizeDashboard(ctx, platform.WriteAction, id); err != nil {
+		return err
+	}
+
+	return s.s.ReplaceDashboardCells(ctx, id, cs)
+}
+
+// DeleteDashboard checks to see if the authorizer on context has delete access to the dashboard provided.
+func (s *DashboardService) DeleteDashboard(ctx context.Context, id platform.ID) error {
+	if _, err := s.authorizeDashboard(ctx, platform.DeleteAction, id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteDashboard(ctx, id)
+}
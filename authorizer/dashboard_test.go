@@ -0,0 +1,137 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
+	"github.com/influxdata/platform/mock"
+)
+
+func newDashboardService() *authorizer.DashboardService {
+	return authorizer.NewDashboardService(&mock.DashboardService{
+		FindDashboardByIDF: func(ctx context.Context, id platform.ID) (*platform.Dashboard, error) {
+			return &platform.Dashboard{ID: id, OrganizationID: 20}, nil
+		},
+		FindDashboardsF: func(context.Context, platform.DashboardFilter, platform.FindOptions) ([]*platform.Dashboard, int, error) {
+			return []*platform.Dashboard{{ID: 10, OrganizationID: 20}, {ID: 11, OrganizationID: 21}}, 2, nil
+		},
+		CreateDashboardF: func(context.Context, *platform.Dashboard) error {
+			return nil
+		},
+		DeleteDashboardF: func(context.Context, platform.ID) error {
+			return nil
+		},
+	})
+}
+
+func TestDashboardService_FindDashboardByID(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		wantErr     bool
+	}{
+		{
+			name: "authorized to read the dashboard",
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.ResourceWithID(platform.DashboardResourceType, 10)},
+			},
+		},
+		{
+			name: "authorized to read the dashboards of the organization",
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.DashboardResourceType, 20)},
+			},
+		},
+		{
+			name:        "authorized as a member of the organization",
+			permissions: platform.OrgPermissions(20),
+		},
+		{
+			name:        "unauthorized as a member of another organization",
+			permissions: platform.OrgPermissions(21),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newDashboardService()
+
+			_, err := s.FindDashboardByID(newAuthorizedContext(tt.permissions...), 10)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EForbidden {
+				t.Errorf("expected error code %q, got %q", platform.EForbidden, platform.ErrorCode(err))
+			}
+		})
+	}
+}
+
+func TestDashboardService_FindDashboards(t *testing.T) {
+	s := newDashboardService()
+
+	ds, n, err := s.FindDashboards(newAuthorizedContext(platform.OrgPermissions(20)...), platform.DashboardFilter{}, platform.FindOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 || len(ds) != 1 || ds[0].ID != 10 {
+		t.Errorf("expected dashboard 10 of the organization, got %d dashboards: %v", n, ds)
+	}
+}
+
+func TestDashboardService_DeleteDashboard(t *testing.T) {
+	s := newDashboardService()
+
+	readOnly := newAuthorizedContext(platform.Permission{
+		Action:   platform.ReadAction,
+		Resource: platform.ResourceInOrg(platform.DashboardResourceType, 20),
+	})
+	if err := s.DeleteDashboard(readOnly, 10); platform.ErrorCode(err) != platform.EForbidden {
+		t.Fatalf("expected dashboard not to be deleted with read access, got %v", err)
+	}
+
+	if err := s.DeleteDashboard(newAuthorizedContext(platform.OrgPermissions(20)...), 10); err != nil {
+		t.Fatalf("expected dashboard to be deleted by an owner of the organization: %v", err)
+	}
+}
+
+func TestDashboardService_CreateDashboard(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		wantErr     bool
+	}{
+		{
+			name: "authorized to create dashboards in the organization",
+			permissions: []platform.Permission{
+				{Action: platform.CreateAction, Resource: platform.ResourceInOrg(platform.DashboardResourceType, 20)},
+			},
+		},
+		{
+			name:        "authorized as an owner of the organization",
+			permissions: platform.OrgPermissions(20),
+		},
+		{
+			name:        "unauthorized as an owner of another organization",
+			permissions: platform.OrgPermissions(21),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newDashboardService()
+
+			err := s.CreateDashboard(newAuthorizedContext(tt.permissions...), &platform.Dashboard{OrganizationID: 20})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EForbidden {
+				t.Errorf("expected error code %q, got %q", platform.EForbidden, platform.ErrorCode(err))
+			}
+		})
+	}
+}
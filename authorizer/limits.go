@@ -0,0 +1,44 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.LimitService = (*LimitService)(nil)
+
+// LimitService wraps a platform.LimitService and authorizes actions
+// against it appropriately.
+type LimitService struct {
+	s platform.LimitService
+}
+
+// NewLimitService constructs an instance of an authorizing limit service.
+func NewLimitService(s platform.LimitService) *LimitService {
+	return &LimitService{
+		s: s,
+	}
+}
+
+// FindLimits checks to see if the authorizer on context has read access to the organization provided.
+func (s *LimitService) FindLimits(ctx context.Context, orgID platform.ID) (*platform.Limits, error) {
+	if err := authorizeRead(ctx, platform.OrgResourceType, platform.InvalidID(), orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindLimits(ctx, orgID)
+}
+
+// UpdateLimits checks to see if the authorizer on context has write access to all organizations.
+// The members of an organization may not raise its limits.
+func (s *LimitService) UpdateLimits(ctx context.Context, orgID platform.ID, upd platform.LimitsUpdate) (*platform.Limits, error) {
+	if err := authorize(ctx, platform.Permission{
+		Action:   platform.WriteAction,
+		Resource: platform.ResourceOfType(platform.OrgResourceType),
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateLimits(ctx, orgID, upd)
+}
@@ -0,0 +1,121 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+	kerrors "github.com/influxdata/platform/kit/errors"
+)
+
+var _ platform.MacroService = (*MacroService)(nil)
+
+// MacroService wraps a platform.MacroService and authorizes actions
+// against it appropriately.
+type MacroService struct {
+	s platform.MacroService
+}
+
+// NewMacroService constructs an instance of an authorizing macro service.
+func NewMacroService(s platform.MacroService) *MacroService {
+	return &MacroService{
+		s: s,
+	}
+}
+
+// authorizeMacro looks up the macro id and checks to see if the authorizer
+// on context is allowed action a on it.
+func (s *MacroService) authorizeMacro(ctx context.Context, a platform.Action, id platform.ID) (*platform.Macro, error) {
+	m, err := s.s.FindMacroByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, platform.NewPermission(a, platform.MacroResourceType, m.OrganizationID, m.ID)); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// FindMacroByID checks to see if the authorizer on context has read access to the id provided.
+func (s *MacroService) FindMacroByID(ctx context.Context, id platform.ID) (*platform.Macro, error) {
+	return s.authorizeMacro(ctx, platform.ReadAction, id)
+}
+
+// FindMacros retrieves all macros and then filters the list down to only the resources that are authorized.
+func (s *MacroService) FindMacros(ctx context.Context) ([]*platform.Macro, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	ms, err := s.s.FindMacros(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	macros := ms[:0]
+	for _, m := range ms {
+		if canRead(ctx, platform.MacroResourceType, m.OrganizationID, m.ID) {
+			macros = append(macros, m)
+		}
+	}
+
+	return macros, nil
+}
+
+// CreateMacro checks to see if the authorizer on context may create macros in
+// the organization of the macro.
+func (s *MacroService) CreateMacro(ctx context.Context, m *platform.Macro) error {
+	if err := authorizeCreate(ctx, platform.MacroResourceType, m.OrganizationID); err != nil {
+		return err
+	}
+
+	return s.s.CreateMacro(ctx, m)
+}
+
+// UpdateMacro checks to see if the authorizer on context has write access to the macro provided.
+func (s *MacroService) UpdateMacro(ctx context.Context, id platform.ID, upd *platform.MacroUpdate) (*platform.Macro, error) {
+	if _, err := s.authorizeMacro(ctx, platform.WriteAction, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateMacro(ctx, id, upd)
+}
+
+// ReplaceMacro checks to see if the authorizer on context has write access to
+// the macro provided, and to the macro it replaces. A macro that does not
+// exist yet is created, so the authorizer must then be allowed to create it.
+func (s *MacroService) ReplaceMacro(ctx context.Context, m *platform.Macro) error {
+	prev, err := s.s.FindMacroByID(ctx, m.ID)
+	switch {
+	case isNotFound(err):
+		if err := authorizeCreate(ctx, platform.MacroResourceType, m.OrganizationID); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if err := authorizeWrite(ctx, platform.MacroResourceType, prev.OrganizationID, prev.ID); err != nil {
+			return err
+		}
+		if err := authorizeWrite(ctx, platform.MacroResourceType, m.OrganizationID, m.ID); err != nil {
+			return err
+		}
+	}
+
+	return s.s.ReplaceMacro(ctx, m)
+}
+
+// isNotFound returns true if err reports a macro that was not found.
+func isNotFound(err error) bool {
+	if e, ok := err.(kerrors.Error); ok {
+		return e.Reference == kerrors.NotFound
+	}
+	return err != nil && platform.ErrorCode(err) == platform.ENotFound
+}
+
+// DeleteMacro checks to see if the authorizer on context has delete access to the macro provided.
+func (s *MacroService) DeleteMacro(ctx context.Context, id platform.ID) error {
+	if _, err := s.authorizeMacro(ctx, platform.DeleteAction, id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteMacro(ctx, id)
+}
@@ -0,0 +1,49 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.OAuth2IdentityService = (*OAuth2IdentityService)(nil)
+
+// OAuth2IdentityService wraps a platform.OAuth2IdentityService and authorizes
+// actions against it appropriately. Linking an identity lets whoever holds it
+// sign in as the user, so users may not link identities to themselves; it
+// requires a permission on the user itself, or on every user.
+type OAuth2IdentityService struct {
+	s platform.OAuth2IdentityService
+}
+
+// NewOAuth2IdentityService constructs an instance of an authorizing OAuth2 identity service.
+func NewOAuth2IdentityService(s platform.OAuth2IdentityService) *OAuth2IdentityService {
+	return &OAuth2IdentityService{
+		s: s,
+	}
+}
+
+// FindOAuth2Identity checks to see if the authorizer on context is the user of the identity, or has read access to it.
+func (s *OAuth2IdentityService) FindOAuth2Identity(ctx context.Context, issuer, subject string) (*platform.OAuth2Identity, error) {
+	i, err := s.s.FindOAuth2Identity(ctx, issuer, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isUser(ctx, i.UserID) {
+		if err := authorizeRead(ctx, platform.UserResourceType, platform.InvalidID(), i.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	return i, nil
+}
+
+// CreateOAuth2Identity checks to see if the authorizer on context has write access to the user of the identity.
+func (s *OAuth2IdentityService) CreateOAuth2Identity(ctx context.Context, i *platform.OAuth2Identity) error {
+	if err := authorizeWrite(ctx, platform.UserResourceType, platform.InvalidID(), i.UserID); err != nil {
+		return err
+	}
+
+	return s.s.CreateOAuth2Identity(ctx, i)
+}
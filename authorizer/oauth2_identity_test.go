@@ -0,0 +1,55 @@
+package authorizer_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
+	"github.com/influxdata/platform/inmem"
+)
+
+func TestOAuth2IdentityService_CreateOAuth2Identity(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		wantErr     bool
+	}{
+		{
+			name: "authorized to write every user",
+			permissions: []platform.Permission{
+				{Action: platform.WriteAction, Resource: platform.UserResource},
+			},
+		},
+		{
+			name: "unauthorized to write another user",
+			permissions: []platform.Permission{
+				{Action: platform.WriteAction, Resource: platform.ResourceWithID(platform.UserResourceType, 3)},
+			},
+			wantErr: true,
+		},
+		{
+			// The authorizer of the context is user 2.
+			name:    "unauthorized to link an identity to oneself",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := inmem.NewService()
+			ctx := newAuthorizedContext(tt.permissions...)
+			if err := svc.PutUser(ctx, &platform.User{ID: 2, Name: "user"}); err != nil {
+				t.Fatalf("failed to create user: %v", err)
+			}
+			s := authorizer.NewOAuth2IdentityService(svc)
+
+			err := s.CreateOAuth2Identity(ctx, &platform.OAuth2Identity{Issuer: "github", Subject: "user", UserID: 2})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EForbidden {
+				t.Errorf("expected error code %q, got %q", platform.EForbidden, platform.ErrorCode(err))
+			}
+		})
+	}
+}
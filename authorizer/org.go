@@ -0,0 +1,91 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.OrganizationService = (*OrgService)(nil)
+
+// OrgService wraps a platform.OrganizationService and authorizes actions
+// against it appropriately. Organizations do not belong to an organization,
+// so the permissions on them are not scoped to one.
+type OrgService struct {
+	s platform.OrganizationService
+}
+
+// NewOrgService constructs an instance of an authorizing org service.
+func NewOrgService(s platform.OrganizationService) *OrgService {
+	return &OrgService{
+		s: s,
+	}
+}
+
+// FindOrganizationByID checks to see if the authorizer on context has read access to the id provided.
+func (s *OrgService) FindOrganizationByID(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+	if err := authorizeRead(ctx, platform.OrgResourceType, platform.InvalidID(), id); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindOrganizationByID(ctx, id)
+}
+
+// FindOrganization retrieves the organization and checks to see if the authorizer on context has read access to the organization.
+func (s *OrgService) FindOrganization(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+	o, err := s.s.FindOrganization(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeRead(ctx, platform.OrgResourceType, platform.InvalidID(), o.ID); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// FindOrganizations retrieves all organizations that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *OrgService) FindOrganizations(ctx context.Context, filter platform.OrganizationFilter, opt ...platform.FindOptions) ([]*platform.Organization, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	os, _, err := s.s.FindOrganizations(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	orgs := os[:0]
+	for _, o := range os {
+		if canRead(ctx, platform.OrgResourceType, platform.InvalidID(), o.ID) {
+			orgs = append(orgs, o)
+		}
+	}
+
+	return orgs, len(orgs), nil
+}
+
+// CreateOrganization checks to see if the authorizer on context may create organizations.
+func (s *OrgService) CreateOrganization(ctx context.Context, o *platform.Organization) error {
+	if err := authorizeCreate(ctx, platform.OrgResourceType, platform.InvalidID()); err != nil {
+		return err
+	}
+
+	return s.s.CreateOrganization(ctx, o)
+}
+
+// UpdateOrganization checks to see if the authorizer on context has write access to the organization provided.
+func (s *OrgService) UpdateOrganization(ctx context.Context, id platform.ID, upd platform.OrganizationUpdate) (*platform.Organization, error) {
+	if err := authorizeWrite(ctx, platform.OrgResourceType, platform.InvalidID(), id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateOrganization(ctx, id, upd)
+}
+
+// DeleteOrganization checks to see if the authorizer on context has delete access to the organization provided.
+func (s *OrgService) DeleteOrganization(ctx context.Context, id platform.ID) error {
+	if err := authorizeDelete(ctx, platform.OrgResourceType, platform.InvalidID(), id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteOrganization(ctx, id)
+}
@@ -0,0 +1,144 @@
+package authorizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.ScraperTargetStoreService = (*ScraperTargetStoreService)(nil)
+
+// ScraperTargetStoreService wraps a platform.ScraperTargetStoreService and
+// authorizes actions against it appropriately. Targets write their metrics
+// to a bucket, so adding or updating a target also requires write access to
+// its bucket.
+type ScraperTargetStoreService struct {
+	s platform.ScraperTargetStoreService
+	b platform.BucketService
+}
+
+// NewScraperTargetStoreService constructs an instance of an authorizing
+// scraper target store service. The buckets of targets are found with b.
+func NewScraperTargetStoreService(s platform.ScraperTargetStoreService, b platform.BucketService) *ScraperTargetStoreService {
+	return &ScraperTargetStoreService{
+		s: s,
+		b: b,
+	}
+}
+
+func scraperPermission(a platform.Action, t *platform.ScraperTarget) platform.Permission {
+	return platform.NewPermission(a, platform.ScraperResourceType, t.OrgID, t.ID)
+}
+
+// authorizeTarget looks up the target id and checks to see if the authorizer
+// on context is allowed action a on it.
+func (s *ScraperTargetStoreService) authorizeTarget(ctx context.Context, a platform.Action, id platform.ID) (*platform.ScraperTarget, error) {
+	t, err := s.s.GetTargetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, scraperPermission(a, t)); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// authorizeTargetBucket checks to see if the bucket of the target belongs to
+// its organization, and if the authorizer on context has write access to it.
+func (s *ScraperTargetStoreService) authorizeTargetBucket(ctx context.Context, t *platform.ScraperTarget) error {
+	b, err := s.b.FindBucketByID(ctx, t.BucketID)
+	if err != nil {
+		return err
+	}
+	if b.OrganizationID != t.OrgID {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("bucket %s does not belong to organization %s", t.BucketID, t.OrgID),
+		}
+	}
+
+	return authorize(ctx, bucketPermission(platform.WriteAction, b))
+}
+
+// authorizeTargetSecrets checks to see if the authorizer on context may read
+// the secrets of the organization of the target, if the target loads its
+// credentials from them.
+func authorizeTargetSecrets(ctx context.Context, t *platform.ScraperTarget) error {
+	if t.BearerTokenSecret == "" && (t.BasicAuth == nil || t.BasicAuth.PasswordSecret == "") {
+		return nil
+	}
+
+	return authorize(ctx, platform.NewPermission(platform.ReadAction, platform.SecretResourceType, t.OrgID, platform.InvalidID()))
+}
+
+// ListTargets retrieves all scraper targets and then filters the list down to only the resources that are authorized.
+func (s *ScraperTargetStoreService) ListTargets(ctx context.Context) ([]platform.ScraperTarget, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	ts, err := s.s.ListTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := ts[:0]
+	for _, t := range ts {
+		if authorized(ctx, scraperPermission(platform.ReadAction, &t)) {
+			targets = append(targets, t)
+		}
+	}
+
+	return targets, nil
+}
+
+// GetTargetByID checks to see if the authorizer on context has read access to the id provided.
+func (s *ScraperTargetStoreService) GetTargetByID(ctx context.Context, id platform.ID) (*platform.ScraperTarget, error) {
+	return s.authorizeTarget(ctx, platform.ReadAction, id)
+}
+
+// AddTarget checks to see if the authorizer on context may create scraper
+// targets in, or write to, the organization of the target, has write access
+// to its bucket and may read the secrets it uses.
+func (s *ScraperTargetStoreService) AddTarget(ctx context.Context, t *platform.ScraperTarget) error {
+	if err := authorizeCreate(ctx, platform.ScraperResourceType, t.OrgID); err != nil {
+		return err
+	}
+	if err := s.authorizeTargetBucket(ctx, t); err != nil {
+		return err
+	}
+	if err := authorizeTargetSecrets(ctx, t); err != nil {
+		return err
+	}
+
+	return s.s.AddTarget(ctx, t)
+}
+
+// UpdateTarget checks to see if the authorizer on context has write access to
+// the target provided, and to the target as updated and its bucket, and may
+// read the secrets the updated target uses.
+func (s *ScraperTargetStoreService) UpdateTarget(ctx context.Context, t *platform.ScraperTarget) (*platform.ScraperTarget, error) {
+	if _, err := s.authorizeTarget(ctx, platform.WriteAction, t.ID); err != nil {
+		return nil, err
+	}
+	if err := authorize(ctx, scraperPermission(platform.WriteAction, t)); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeTargetBucket(ctx, t); err != nil {
+		return nil, err
+	}
+	if err := authorizeTargetSecrets(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateTarget(ctx, t)
+}
+
+// RemoveTarget checks to see if the authorizer on context has delete access to the target provided.
+func (s *ScraperTargetStoreService) RemoveTarget(ctx context.Context, id platform.ID) error {
+	if _, err := s.authorizeTarget(ctx, platform.DeleteAction, id); err != nil {
+		return err
+	}
+
+	return s.s.RemoveTarget(ctx, id)
+}
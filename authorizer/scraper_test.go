@@ -0,0 +1,114 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
+)
+
+func newScraperTargetStoreService() *authorizer.ScraperTargetStoreService {
+	buckets := mock.NewBucketService()
+	buckets.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		// Bucket 30 belongs to organization 20, and bucket 31 to organization 21.
+		return &platform.Bucket{ID: id, OrganizationID: id - 10}, nil
+	}
+	return authorizer.NewScraperTargetStoreService(inmem.NewService(), buckets)
+}
+
+func TestScraperTargetStoreService_AddTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		bucketID    platform.ID
+		secret      string
+		wantCode    string
+	}{
+		{
+			name:        "authorized as an owner of the organization",
+			permissions: platform.OrgPermissions(20),
+			bucketID:    30,
+		},
+		{
+			name: "unauthorized to write to the bucket",
+			permissions: []platform.Permission{
+				{Action: platform.CreateAction, Resource: platform.ResourceInOrg(platform.ScraperResourceType, 20)},
+			},
+			bucketID: 30,
+			wantCode: platform.EForbidden,
+		},
+		{
+			name: "authorized to read the secrets of the organization",
+			permissions: []platform.Permission{
+				{Action: platform.CreateAction, Resource: platform.ResourceInOrg(platform.ScraperResourceType, 20)},
+				{Action: platform.WriteAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 20)},
+				{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.SecretResourceType, 20)},
+			},
+			bucketID: 30,
+			secret:   "token",
+		},
+		{
+			name: "unauthorized to read the secrets of the organization",
+			permissions: []platform.Permission{
+				{Action: platform.CreateAction, Resource: platform.ResourceInOrg(platform.ScraperResourceType, 20)},
+				{Action: platform.WriteAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 20)},
+			},
+			bucketID: 30,
+			secret:   "token",
+			wantCode: platform.EForbidden,
+		},
+		{
+			name:        "unauthorized as an owner of another organization",
+			permissions: platform.OrgPermissions(21),
+			bucketID:    30,
+			wantCode:    platform.EForbidden,
+		},
+		{
+			name:        "bucket of another organization",
+			permissions: append(platform.OrgPermissions(20), platform.OrgPermissions(21)...),
+			bucketID:    31,
+			wantCode:    platform.EInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScraperTargetStoreService()
+
+			err := s.AddTarget(newAuthorizedContext(tt.permissions...), &platform.ScraperTarget{
+				Name:              "target",
+				OrgID:             20,
+				BucketID:          tt.bucketID,
+				BearerTokenSecret: tt.secret,
+			})
+			if tt.wantCode == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantCode != "" && platform.ErrorCode(err) != tt.wantCode {
+				t.Fatalf("expected error code %q, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestScraperTargetStoreService_ListTargets(t *testing.T) {
+	s := newScraperTargetStoreService()
+
+	for _, org := range []platform.ID{20, 21} {
+		ctx := newAuthorizedContext(platform.OrgPermissions(org)...)
+		if err := s.AddTarget(ctx, &platform.ScraperTarget{OrgID: org, BucketID: org + 10}); err != nil {
+			t.Fatalf("unexpected error adding target: %v", err)
+		}
+	}
+
+	ts, err := s.ListTargets(newAuthorizedContext(platform.OrgPermissions(20)...))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ts) != 1 || ts[0].OrgID != 20 {
+		t.Errorf("expected the target of the organization, got %v", ts)
+	}
+}
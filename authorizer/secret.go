@@ -0,0 +1,59 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.SecretService = (*SecretService)(nil)
+
+// SecretService wraps a platform.SecretService and authorizes actions
+// against it appropriately. Secrets are authorized as a whole for their
+// organization.
+type SecretService struct {
+	s platform.SecretService
+}
+
+// NewSecretService constructs an instance of an authorizing secret service.
+func NewSecretService(s platform.SecretService) *SecretService {
+	return &SecretService{
+		s: s,
+	}
+}
+
+// LoadSecret checks to see if the authorizer on context has read access to the secrets of the organization provided.
+func (s *SecretService) LoadSecret(ctx context.Context, orgID platform.ID, k string) (string, error) {
+	if err := authorizeRead(ctx, platform.SecretResourceType, orgID, platform.InvalidID()); err != nil {
+		return "", err
+	}
+
+	return s.s.LoadSecret(ctx, orgID, k)
+}
+
+// GetSecretKeys checks to see if the authorizer on context has read access to the secrets of the organization provided.
+func (s *SecretService) GetSecretKeys(ctx context.Context, orgID platform.ID) ([]string, error) {
+	if err := authorizeRead(ctx, platform.SecretResourceType, orgID, platform.InvalidID()); err != nil {
+		return nil, err
+	}
+
+	return s.s.GetSecretKeys(ctx, orgID)
+}
+
+// PutSecret checks to see if the authorizer on context has write access to the secrets of the organization provided.
+func (s *SecretService) PutSecret(ctx context.Context, orgID platform.ID, k string, v string) error {
+	if err := authorizeWrite(ctx, platform.SecretResourceType, orgID, platform.InvalidID()); err != nil {
+		return err
+	}
+
+	return s.s.PutSecret(ctx, orgID, k, v)
+}
+
+// DeleteSecret checks to see if the authorizer on context has delete access to the secrets of the organization provided.
+func (s *SecretService) DeleteSecret(ctx context.Context, orgID platform.ID, ks ...string) error {
+	if err := authorizeDelete(ctx, platform.SecretResourceType, orgID, platform.InvalidID()); err != nil {
+		return err
+	}
+
+	return s.s.DeleteSecret(ctx, orgID, ks...)
+}
@@ -0,0 +1,43 @@
+package authorizer_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
+	"github.com/influxdata/platform/mock"
+)
+
+func TestSecretService(t *testing.T) {
+	s := authorizer.NewSecretService(mock.NewSecretService())
+
+	// Members of an organization may read its secret keys, but not change them.
+	var member []platform.Permission
+	for _, p := range platform.OrgPermissions(20) {
+		if p.Action == platform.ReadAction {
+			member = append(member, p)
+		}
+	}
+	ctx := newAuthorizedContext(member...)
+	if _, err := s.GetSecretKeys(ctx, 20); err != nil {
+		t.Fatalf("expected member to read the secret keys of the organization: %v", err)
+	}
+	if err := s.PutSecret(ctx, 20, "k", "v"); platform.ErrorCode(err) != platform.EForbidden {
+		t.Fatalf("expected member not to write secrets, got %v", err)
+	}
+
+	// Owners of an organization may manage its secrets only.
+	ctx = newAuthorizedContext(platform.OrgPermissions(20)...)
+	if err := s.PutSecret(ctx, 20, "k", "v"); err != nil {
+		t.Fatalf("expected owner to write the secrets of the organization: %v", err)
+	}
+	if err := s.DeleteSecret(ctx, 20, "k"); err != nil {
+		t.Fatalf("expected owner to delete the secrets of the organization: %v", err)
+	}
+	if _, err := s.GetSecretKeys(ctx, 21); platform.ErrorCode(err) != platform.EForbidden {
+		t.Fatalf("expected owner not to read the secret keys of another organization, got %v", err)
+	}
+	if err := s.PutSecret(ctx, 21, "k", "v"); platform.ErrorCode(err) != platform.EForbidden {
+		t.Fatalf("expected owner not to write the secrets of another organization, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package authorizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.TelegrafConfigStore = (*TelegrafConfigStore)(nil)
+
+// TelegrafConfigStore wraps a platform.TelegrafConfigStore and authorizes
+// actions against it appropriately. The user resource mappings of the store
+// are served by an authorizing user resource mapping service.
+type TelegrafConfigStore struct {
+	platform.UserResourceMappingService
+	s platform.TelegrafConfigStore
+}
+
+// NewTelegrafConfigStore constructs an instance of an authorizing telegraf
+// config store. The user resource mappings of the store are served by m.
+func NewTelegrafConfigStore(s platform.TelegrafConfigStore, m *UserResourceMappingService) *TelegrafConfigStore {
+	return &TelegrafConfigStore{
+		UserResourceMappingService: m,
+		s:                          s,
+	}
+}
+
+// authorizeTelegrafConfig looks up the telegraf config id and checks to see
+// if the authorizer on context is allowed action a on it.
+func (s *TelegrafConfigStore) authorizeTelegrafConfig(ctx context.Context, a platform.Action, id platform.ID) (*platform.TelegrafConfig, error) {
+	tc, err := s.s.FindTelegrafConfigByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, platform.NewPermission(a, platform.TelegrafResourceType, tc.OrganizationID, tc.ID)); err != nil {
+		return nil, err
+	}
+
+	return tc, nil
+}
+
+// FindTelegrafConfigByID checks to see if the authorizer on context has read access to the id provided.
+func (s *TelegrafConfigStore) FindTelegrafConfigByID(ctx context.Context, id platform.ID) (*platform.TelegrafConfig, error) {
+	return s.authorizeTelegrafConfig(ctx, platform.ReadAction, id)
+}
+
+// FindTelegrafConfig retrieves the telegraf config and checks to see if the authorizer on context has read access to the telegraf config.
+func (s *TelegrafConfigStore) FindTelegrafConfig(ctx context.Context, filter platform.UserResourceMappingFilter) (*platform.TelegrafConfig, error) {
+	tc, err := s.s.FindTelegrafConfig(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeRead(ctx, platform.TelegrafResourceType, tc.OrganizationID, tc.ID); err != nil {
+		return nil, err
+	}
+
+	return tc, nil
+}
+
+// FindTelegrafConfigs retrieves all telegraf configs that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *TelegrafConfigStore) FindTelegrafConfigs(ctx context.Context, filter platform.UserResourceMappingFilter, opt ...platform.FindOptions) ([]*platform.TelegrafConfig, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	ts, _, err := s.s.FindTelegrafConfigs(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tcs := ts[:0]
+	for _, tc := range ts {
+		if canRead(ctx, platform.TelegrafResourceType, tc.OrganizationID, tc.ID) {
+			tcs = append(tcs, tc)
+		}
+	}
+
+	return tcs, len(tcs), nil
+}
+
+// CreateTelegrafConfig checks to see if the authorizer on context may create
+// telegraf configs in the organization of the config.
+func (s *TelegrafConfigStore) CreateTelegrafConfig(ctx context.Context, tc *platform.TelegrafConfig, userID platform.ID, now time.Time) error {
+	if err := authorizeCreate(ctx, platform.TelegrafResourceType, tc.OrganizationID); err != nil {
+		return err
+	}
+
+	return s.s.CreateTelegrafConfig(ctx, tc, userID, now)
+}
+
+// UpdateTelegrafConfig checks to see if the authorizer on context has write access to the telegraf config provided.
+func (s *TelegrafConfigStore) UpdateTelegrafConfig(ctx context.Context, id platform.ID, tc *platform.TelegrafConfig, userID platform.ID, now time.Time) (*platform.TelegrafConfig, error) {
+	if _, err := s.authorizeTelegrafConfig(ctx, platform.WriteAction, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateTelegrafConfig(ctx, id, tc, userID, now)
+}
+
+// DeleteTelegrafConfig checks to see if the authorizer on context has delete access to the telegraf config provided.
+func (s *TelegrafConfigStore) DeleteTelegrafConfig(ctx context.Context, id platform.ID) error {
+	if _, err := s.authorizeTelegrafConfig(ctx, platform.DeleteAction, id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteTelegrafConfig(ctx, id)
+}
@@ -0,0 +1,36 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.UsageService = (*UsageService)(nil)
+
+// UsageService wraps a platform.UsageService and authorizes actions
+// against it appropriately.
+type UsageService struct {
+	s platform.UsageService
+}
+
+// NewUsageService constructs an instance of an authorizing usage service.
+func NewUsageService(s platform.UsageService) *UsageService {
+	return &UsageService{
+		s: s,
+	}
+}
+
+// GetUsage checks to see if the authorizer on context has read access to the organization of the filter,
+// or to all organizations when the filter has none.
+func (s *UsageService) GetUsage(ctx context.Context, filter platform.UsageFilter) (map[platform.UsageMetric]*platform.Usage, error) {
+	orgID := platform.InvalidID()
+	if filter.OrgID != nil {
+		orgID = *filter.OrgID
+	}
+	if err := authorizeRead(ctx, platform.OrgResourceType, platform.InvalidID(), orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.GetUsage(ctx, filter)
+}
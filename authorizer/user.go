@@ -0,0 +1,120 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.UserService = (*UserService)(nil)
+
+// UserService wraps a platform.UserService and authorizes actions
+// against it appropriately. Users may always read and update themselves.
+// Users belong to the organizations they are members or owners of, and may
+// be read with a permission on the users of any of them. Only a permission
+// on the user itself, or on every user, allows writing and deleting a user.
+type UserService struct {
+	s platform.UserService
+	m platform.UserResourceMappingService
+}
+
+// NewUserService constructs an instance of an authorizing user service. The
+// organizations of users are found with m.
+func NewUserService(s platform.UserService, m platform.UserResourceMappingService) *UserService {
+	return &UserService{
+		s: s,
+		m: m,
+	}
+}
+
+// authorizeReadUser checks to see if the authorizer on context is the user
+// id, or has read access to it in any of its organizations.
+func (s *UserService) authorizeReadUser(ctx context.Context, id platform.ID) error {
+	if isUser(ctx, id) {
+		return nil
+	}
+
+	ps := []platform.Permission{platform.NewPermission(platform.ReadAction, platform.UserResourceType, platform.InvalidID(), id)}
+	ms, _, err := s.m.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+		UserID:       id,
+		ResourceType: platform.OrgResourceType,
+	})
+	if err != nil {
+		return err
+	}
+	for _, m := range ms {
+		ps = append(ps, platform.NewPermission(platform.ReadAction, platform.UserResourceType, m.ResourceID, id))
+	}
+
+	return authorize(ctx, ps...)
+}
+
+// FindUserByID checks to see if the authorizer on context has read access to the id provided.
+func (s *UserService) FindUserByID(ctx context.Context, id platform.ID) (*platform.User, error) {
+	if err := s.authorizeReadUser(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindUserByID(ctx, id)
+}
+
+// FindUser retrieves the user and checks to see if the authorizer on context has read access to the user.
+func (s *UserService) FindUser(ctx context.Context, filter platform.UserFilter) (*platform.User, error) {
+	u, err := s.s.FindUser(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeReadUser(ctx, u.ID); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// FindUsers retrieves all users that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *UserService) FindUsers(ctx context.Context, filter platform.UserFilter, opt ...platform.FindOptions) ([]*platform.User, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	us, _, err := s.s.FindUsers(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := us[:0]
+	for _, u := range us {
+		if s.authorizeReadUser(ctx, u.ID) == nil {
+			users = append(users, u)
+		}
+	}
+
+	return users, len(users), nil
+}
+
+// CreateUser checks to see if the authorizer on context may create users.
+func (s *UserService) CreateUser(ctx context.Context, u *platform.User) error {
+	if err := authorize(ctx, platform.CreateUserPermission); err != nil {
+		return err
+	}
+
+	return s.s.CreateUser(ctx, u)
+}
+
+// UpdateUser checks to see if the authorizer on context has write access to the user provided.
+func (s *UserService) UpdateUser(ctx context.Context, id platform.ID, upd platform.UserUpdate) (*platform.User, error) {
+	if !isUser(ctx, id) {
+		if err := authorizeWrite(ctx, platform.UserResourceType, platform.InvalidID(), id); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.s.UpdateUser(ctx, id, upd)
+}
+
+// DeleteUser checks to see if the authorizer on context has delete access to the user provided.
+func (s *UserService) DeleteUser(ctx context.Context, id platform.ID) error {
+	if err := authorizeDelete(ctx, platform.UserResourceType, platform.InvalidID(), id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteUser(ctx, id)
+}
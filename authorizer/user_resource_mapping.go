@@ -0,0 +1,171 @@
+package authorizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.UserResourceMappingService = (*UserResourceMappingService)(nil)
+
+// ResourceOrgFinder finds the organization of the resources users are mapped
+// to with the services of each resource type.
+type ResourceOrgFinder struct {
+	BucketService             platform.BucketService
+	DashboardService          platform.DashboardService
+	ViewService               platform.ViewService
+	TaskService               platform.TaskService
+	TelegrafConfigStore       platform.TelegrafConfigStore
+	ScraperTargetStoreService platform.ScraperTargetStoreService
+}
+
+// FindResourceOrgID returns the organization of the resource id of type t.
+// Organizations do not belong to an organization, so an invalid ID is
+// returned for them.
+func (f *ResourceOrgFinder) FindResourceOrgID(ctx context.Context, t platform.ResourceType, id platform.ID) (platform.ID, error) {
+	switch t {
+	case platform.OrgResourceType:
+		return platform.InvalidID(), nil
+	case platform.BucketResourceType:
+		b, err := f.BucketService.FindBucketByID(ctx, id)
+		if err != nil {
+			return platform.InvalidID(), err
+		}
+		return b.OrganizationID, nil
+	case platform.DashboardResourceType:
+		d, err := f.DashboardService.FindDashboardByID(ctx, id)
+		if err != nil {
+			return platform.InvalidID(), err
+		}
+		return d.OrganizationID, nil
+	case platform.ViewResourceType:
+		v, err := f.ViewService.FindViewByID(ctx, id)
+		if err != nil {
+			return platform.InvalidID(), err
+		}
+		return v.OrganizationID, nil
+	case platform.TaskResourceType:
+		tk, err := f.TaskService.FindTaskByID(ctx, id)
+		if err != nil {
+			return platform.InvalidID(), err
+		}
+		return tk.Organization, nil
+	case platform.TelegrafResourceType:
+		tc, err := f.TelegrafConfigStore.FindTelegrafConfigByID(ctx, id)
+		if err != nil {
+			return platform.InvalidID(), err
+		}
+		return tc.OrganizationID, nil
+	case platform.ScraperResourceType:
+		st, err := f.ScraperTargetStoreService.GetTargetByID(ctx, id)
+		if err != nil {
+			return platform.InvalidID(), err
+		}
+		return st.OrgID, nil
+	}
+
+	return platform.InvalidID(), &platform.Error{
+		Code: platform.EInvalid,
+		Msg:  fmt.Sprintf("users cannot be mapped to resources of type %q", t),
+	}
+}
+
+// UserResourceMappingService wraps a platform.UserResourceMappingService and
+// authorizes actions against it appropriately. Mappings may be read by their
+// user, or with read access to their resource. Creating a mapping requires
+// write access to its resource, as does deleting it unless it is the
+// authorizer's own.
+type UserResourceMappingService struct {
+	s platform.UserResourceMappingService
+	f *ResourceOrgFinder
+}
+
+// NewUserResourceMappingService constructs an instance of an authorizing user
+// resource mapping service. The organizations of mapped resources are found
+// with f.
+func NewUserResourceMappingService(s platform.UserResourceMappingService, f *ResourceOrgFinder) *UserResourceMappingService {
+	return &UserResourceMappingService{
+		s: s,
+		f: f,
+	}
+}
+
+// mappingPermission returns the permission for action a on the resource of
+// the mapping m.
+func (s *UserResourceMappingService) mappingPermission(ctx context.Context, a platform.Action, m *platform.UserResourceMapping) (platform.Permission, error) {
+	orgID, err := s.f.FindResourceOrgID(ctx, m.ResourceType, m.ResourceID)
+	if err != nil {
+		return platform.Permission{}, err
+	}
+	return platform.NewPermission(a, m.ResourceType, orgID, m.ResourceID), nil
+}
+
+// FindUserResourceMappings retrieves all mappings that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *UserResourceMappingService) FindUserResourceMappings(ctx context.Context, filter platform.UserResourceMappingFilter, opt ...platform.FindOptions) ([]*platform.UserResourceMapping, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	ms, _, err := s.s.FindUserResourceMappings(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mappings := ms[:0]
+	for _, m := range ms {
+		if isUser(ctx, m.UserID) {
+			mappings = append(mappings, m)
+			continue
+		}
+		p, err := s.mappingPermission(ctx, platform.ReadAction, m)
+		if err != nil {
+			continue
+		}
+		if authorized(ctx, p) {
+			mappings = append(mappings, m)
+		}
+	}
+
+	return mappings, len(mappings), nil
+}
+
+// CreateUserResourceMapping checks to see if the authorizer on context has write access to the resource of the mapping.
+func (s *UserResourceMappingService) CreateUserResourceMapping(ctx context.Context, m *platform.UserResourceMapping) error {
+	p, err := s.mappingPermission(ctx, platform.WriteAction, m)
+	if err != nil {
+		return err
+	}
+	if err := authorize(ctx, p); err != nil {
+		return err
+	}
+
+	return s.s.CreateUserResourceMapping(ctx, m)
+}
+
+// DeleteUserResourceMapping checks to see if the mapping is of the authorizer
+// on context, or if it has write access to the resource of the mapping.
+func (s *UserResourceMappingService) DeleteUserResourceMapping(ctx context.Context, resourceID platform.ID, userID platform.ID) error {
+	if !isUser(ctx, userID) {
+		ms, _, err := s.s.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+			ResourceID: resourceID,
+			UserID:     userID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(ms) == 0 {
+			return &platform.Error{
+				Code: platform.ENotFound,
+				Msg:  "user to resource mapping not found",
+			}
+		}
+
+		p, err := s.mappingPermission(ctx, platform.WriteAction, ms[0])
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, p); err != nil {
+			return err
+		}
+	}
+
+	return s.s.DeleteUserResourceMapping(ctx, resourceID, userID)
+}
@@ -0,0 +1,120 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
+	"github.com/influxdata/platform/mock"
+)
+
+func newUserResourceMappingService() *authorizer.UserResourceMappingService {
+	mappings := []*platform.UserResourceMapping{
+		{ResourceID: 30, ResourceType: platform.BucketResourceType, UserID: 2, UserType: platform.Member},
+		{ResourceID: 30, ResourceType: platform.BucketResourceType, UserID: 3, UserType: platform.Owner},
+		{ResourceID: 31, ResourceType: platform.BucketResourceType, UserID: 3, UserType: platform.Owner},
+	}
+
+	urm := mock.NewUserResourceMappingService()
+	urm.FindMappingsFn = func(ctx context.Context, filter platform.UserResourceMappingFilter) ([]*platform.UserResourceMapping, int, error) {
+		var ms []*platform.UserResourceMapping
+		for _, m := range mappings {
+			if filter.ResourceID.Valid() && filter.ResourceID != m.ResourceID {
+				continue
+			}
+			if filter.UserID.Valid() && filter.UserID != m.UserID {
+				continue
+			}
+			ms = append(ms, m)
+		}
+		return ms, len(ms), nil
+	}
+
+	buckets := mock.NewBucketService()
+	buckets.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		// Bucket 30 belongs to organization 20, and bucket 31 to organization 21.
+		return &platform.Bucket{ID: id, OrganizationID: id - 10}, nil
+	}
+
+	return authorizer.NewUserResourceMappingService(urm, &authorizer.ResourceOrgFinder{BucketService: buckets})
+}
+
+func TestUserResourceMappingService_CreateUserResourceMapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		wantErr     bool
+	}{
+		{
+			name:        "authorized as an owner of the organization",
+			permissions: platform.OrgPermissions(20),
+		},
+		{
+			name: "unauthorized to add members with read access",
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 20)},
+			},
+			wantErr: true,
+		},
+		{
+			name:        "unauthorized as an owner of another organization",
+			permissions: platform.OrgPermissions(21),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newUserResourceMappingService()
+
+			err := s.CreateUserResourceMapping(newAuthorizedContext(tt.permissions...), &platform.UserResourceMapping{
+				ResourceID:   30,
+				ResourceType: platform.BucketResourceType,
+				UserID:       4,
+				UserType:     platform.Owner,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && platform.ErrorCode(err) != platform.EForbidden {
+				t.Errorf("expected error code %q, got %q", platform.EForbidden, platform.ErrorCode(err))
+			}
+		})
+	}
+}
+
+func TestUserResourceMappingService_FindUserResourceMappings(t *testing.T) {
+	s := newUserResourceMappingService()
+
+	// The authorizer of the context is user 2.
+	ms, _, err := s.FindUserResourceMappings(newAuthorizedContext(), platform.UserResourceMappingFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ms) != 1 || ms[0].UserID != 2 {
+		t.Errorf("expected only the mapping of the user, got %v", ms)
+	}
+
+	ms, _, err = s.FindUserResourceMappings(newAuthorizedContext(platform.OrgPermissions(20)...), platform.UserResourceMappingFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ms) != 2 {
+		t.Errorf("expected the mappings of the bucket of the organization, got %v", ms)
+	}
+}
+
+func TestUserResourceMappingService_DeleteUserResourceMapping(t *testing.T) {
+	s := newUserResourceMappingService()
+
+	if err := s.DeleteUserResourceMapping(newAuthorizedContext(), 30, 2); err != nil {
+		t.Errorf("expected users to be able to remove their own mappings: %v", err)
+	}
+	if err := s.DeleteUserResourceMapping(newAuthorizedContext(), 30, 3); platform.ErrorCode(err) != platform.EForbidden {
+		t.Errorf("expected the mapping of another user not to be removed without write access, got %v", err)
+	}
+	if err := s.DeleteUserResourceMapping(newAuthorizedContext(platform.OrgPermissions(21)...), 31, 3); err != nil {
+		t.Errorf("expected an owner of the organization to remove the mapping: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.ViewService = (*ViewService)(nil)
+
+// ViewService wraps a platform.ViewService and authorizes actions
+// against it appropriately.
+type ViewService struct {
+	s platform.ViewService
+}
+
+// NewViewService constructs an instance of an authorizing view service.
+func NewViewService(s platform.ViewService) *ViewService {
+	return &ViewService{
+		s: s,
+	}
+}
+
+// authorizeView looks up the view id and checks to see if the authorizer on
+// context is allowed action a on it.
+func (s *ViewService) authorizeView(ctx context.Context, a platform.Action, id platform.ID) (*platform.View, error) {
+	v, err := s.s.FindViewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, platform.NewPermission(a, platform.ViewResourceType, v.OrganizationID, v.ID)); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// FindViewByID checks to see if the authorizer on context has read access to the id provided.
+func (s *ViewService) FindViewByID(ctx context.Context, id platform.ID) (*platform.View, error) {
+	return s.authorizeView(ctx, platform.ReadAction, id)
+}
+
+// FindViews retrieves all views that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *ViewService) FindViews(ctx context.Context, filter platform.ViewFilter) ([]*platform.View, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	vs, _, err := s.s.FindViews(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	views := vs[:0]
+	for _, v := range vs {
+		if canRead(ctx, platform.ViewResourceType, v.OrganizationID, v.ID) {
+			views = append(views, v)
+		}
+	}
+
+	return views, len(views), nil
+}
+
+// CreateView checks to see if the authorizer on context may create views in
+// the organization of the view.
+func (s *ViewService) CreateView(ctx context.Context, v *platform.View) error {
+	if err := authorizeCreate(ctx, platform.ViewResourceType, v.OrganizationID); err != nil {
+		return err
+	}
+
+	return s.s.CreateView(ctx, v)
+}
+
+// UpdateView checks to see if the authorizer on context has write access to the view provided.
+func (s *ViewService) UpdateView(ctx context.Context, id platform.ID, upd platform.ViewUpdate) (*platform.View, error) {
+	if _, err := s.authorizeView(ctx, platform.WriteAction, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateView(ctx, id, upd)
+}
+
+// DeleteView checks to see if the authorizer on context has delete access to the view provided.
+func (s *ViewService) DeleteView(ctx context.Context, id platform.ID) error {
+	if _, err := s.authorizeView(ctx, platform.DeleteAction, id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteView(ctx, id)
+}
@@ -1,6 +1,10 @@
 package platform
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Authorizer will authorize a permission.
 type Authorizer interface {
@@ -19,55 +23,227 @@ type Authorizer interface {
 
 func allowed(p Permission, ps []Permission) bool {
 	for _, perm := range ps {
-		if perm.Action == p.Action && perm.Resource == p.Resource {
+		if perm.Matches(p) {
 			return true
 		}
 	}
 	return false
 }
 
-type action string
+// Action is an action a permission allows on a resource.
+type Action string
 
 const (
 	// ReadAction is the action for reading.
-	ReadAction action = "read"
+	ReadAction Action = "read"
 	// WriteAction is the action for writing.
-	WriteAction action = "write"
+	WriteAction Action = "write"
 	// CreateAction is the action for creating new resources.
-	CreateAction action = "create"
+	CreateAction Action = "create"
 	// DeleteAction is the action for deleting an existing resource.
-	DeleteAction action = "delete"
+	DeleteAction Action = "delete"
 )
 
-type resource string
+// actions are all the actions of a permission.
+var actions = []Action{ReadAction, WriteAction, CreateAction, DeleteAction}
 
-const (
+// AllResourceTypes are the types of every resource permissions may be
+// granted on.
+var AllResourceTypes = []ResourceType{
+	AuthorizationResourceType,
+	BucketResourceType,
+	DashboardResourceType,
+	MacroResourceType,
+	OrgResourceType,
+	ScraperResourceType,
+	SecretResourceType,
+	TaskResourceType,
+	TelegrafResourceType,
+	UserResourceType,
+	ViewResourceType,
+}
+
+// Valid returns an error if t is not a type of resource permissions may be
+// granted on.
+func (t ResourceType) Valid() error {
+	for _, rt := range AllResourceTypes {
+		if t == rt {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown resource type %q", t)
+}
+
+// Resource is a resource permissions are granted on. A resource without an ID
+// is every resource of its type, and a resource with an OrgID is limited to
+// the resources of that organization.
+type Resource struct {
+	Type  ResourceType `json:"type"`
+	ID    *ID          `json:"id,omitempty"`
+	OrgID *ID          `json:"orgID,omitempty"`
+}
+
+var (
 	// UserResource represents the user resource actions can apply to.
-	UserResource = resource("user")
+	UserResource = ResourceOfType(UserResourceType)
 	// OrganizationResource represents the org resource actions can apply to.
-	OrganizationResource = resource("org")
+	OrganizationResource = ResourceOfType(OrgResourceType)
 )
 
+// NewResource constructs the resource id of type t in the organization
+// orgID. An invalid orgID or id is left out of the resource.
+func NewResource(t ResourceType, orgID, id ID) Resource {
+	r := Resource{Type: t}
+	if orgID.Valid() {
+		r.OrgID = &orgID
+	}
+	if id.Valid() {
+		r.ID = &id
+	}
+	return r
+}
+
+// ResourceOfType constructs the resource of every resource of type t.
+func ResourceOfType(t ResourceType) Resource {
+	return Resource{Type: t}
+}
+
+// ResourceWithID constructs the resource of the single resource id of type t.
+func ResourceWithID(t ResourceType, id ID) Resource {
+	return NewResource(t, InvalidID(), id)
+}
+
+// ResourceInOrg constructs the resource of every resource of type t in the
+// organization orgID.
+func ResourceInOrg(t ResourceType, orgID ID) Resource {
+	return NewResource(t, orgID, InvalidID())
+}
+
+// OrgResource constructs the resource of a single organization.
+func OrgResource(id ID) Resource {
+	return ResourceWithID(OrgResourceType, id)
+}
+
 // TaskResource represents the task resource scoped to an organization.
-func TaskResource(orgID ID) resource {
-	return resource(fmt.Sprintf("org/%s/task", orgID))
+func TaskResource(orgID ID) Resource {
+	return ResourceInOrg(TaskResourceType, orgID)
 }
 
 // BucketResource constructs a bucket resource.
-func BucketResource(id ID) resource {
-	return resource(fmt.Sprintf("bucket/%s", id))
+func BucketResource(id ID) Resource {
+	return ResourceWithID(BucketResourceType, id)
+}
+
+// String returns the resource as a path of the form
+// [org/<orgID>/]<type>[/<id>].
+func (r Resource) String() string {
+	s := string(r.Type)
+	if r.OrgID != nil {
+		s = fmt.Sprintf("%s/%s/%s", OrgResourceType, r.OrgID, s)
+	}
+	if r.ID != nil {
+		s = fmt.Sprintf("%s/%s", s, r.ID)
+	}
+	return s
+}
+
+// ParseResource parses a resource from the path returned by Resource.String.
+func ParseResource(s string) (Resource, error) {
+	parts := strings.Split(s, "/")
+	var r Resource
+	if len(parts) >= 3 && parts[0] == string(OrgResourceType) {
+		var orgID ID
+		if err := orgID.DecodeFromString(parts[1]); err != nil {
+			return r, fmt.Errorf("invalid organization id in resource %q: %v", s, err)
+		}
+		r.OrgID = &orgID
+		parts = parts[2:]
+	}
+
+	switch len(parts) {
+	case 2:
+		var id ID
+		if err := id.DecodeFromString(parts[1]); err != nil {
+			return r, fmt.Errorf("invalid id in resource %q: %v", s, err)
+		}
+		r.ID = &id
+	case 1:
+	default:
+		return r, fmt.Errorf("invalid resource %q", s)
+	}
+	r.Type = ResourceType(parts[0])
+
+	if err := r.Valid(); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Valid returns an error if the resource is of an unknown type or has an
+// invalid id.
+func (r Resource) Valid() error {
+	if err := r.Type.Valid(); err != nil {
+		return err
+	}
+	if r.ID != nil && !r.ID.Valid() {
+		return errors.New("resource id is invalid")
+	}
+	if r.OrgID != nil && !r.OrgID.Valid() {
+		return errors.New("resource organization id is invalid")
+	}
+	return nil
 }
 
 // Permission defines an action and a resource.
 type Permission struct {
-	Action   action   `json:"action"`
-	Resource resource `json:"resource"`
+	Action   Action   `json:"action"`
+	Resource Resource `json:"resource"`
+}
+
+// NewPermission constructs the permission for action a on the resource id of
+// type t in the organization orgID. An invalid orgID or id is left out of
+// the resource.
+func NewPermission(a Action, t ResourceType, orgID, id ID) Permission {
+	return Permission{
+		Action:   a,
+		Resource: NewResource(t, orgID, id),
+	}
 }
 
 func (p Permission) String() string {
 	return fmt.Sprintf("%s:%s", p.Action, p.Resource)
 }
 
+// Valid returns an error if the permission has an unknown action or an
+// invalid resource.
+func (p Permission) Valid() error {
+	switch p.Action {
+	case ReadAction, WriteAction, CreateAction, DeleteAction:
+	default:
+		return fmt.Errorf("unknown action %q", p.Action)
+	}
+	return p.Resource.Valid()
+}
+
+// Matches returns true if p grants the permission requested. The resource of
+// p matches the requested resource if they are of the same type, and the
+// organization and id of p, when set, are those of the requested resource.
+// A permission on every resource of a type therefore grants the permission
+// on each of them, and a permission on the resources of an organization
+// grants the permission on each resource of the organization.
+func (p Permission) Matches(requested Permission) bool {
+	if p.Action != requested.Action || p.Resource.Type != requested.Resource.Type {
+		return false
+	}
+	if p.Resource.OrgID != nil && (requested.Resource.OrgID == nil || *p.Resource.OrgID != *requested.Resource.OrgID) {
+		return false
+	}
+	if p.Resource.ID != nil && (requested.Resource.ID == nil || *p.Resource.ID != *requested.Resource.ID) {
+		return false
+	}
+	return true
+}
+
 var (
 	// CreateUserPermission is a permission for creating users.
 	CreateUserPermission = Permission{
@@ -96,3 +272,31 @@ func WriteBucketPermission(id ID) Permission {
 		Resource: BucketResource(id),
 	}
 }
+
+// OrgPermissions returns the permissions of every action on every resource
+// of the organization orgID, and on the organization itself.
+func OrgPermissions(orgID ID) []Permission {
+	var ps []Permission
+	for _, a := range actions {
+		ps = append(ps, Permission{Action: a, Resource: OrgResource(orgID)})
+		for _, t := range AllResourceTypes {
+			if t == OrgResourceType {
+				continue
+			}
+			ps = append(ps, Permission{Action: a, Resource: ResourceInOrg(t, orgID)})
+		}
+	}
+	return ps
+}
+
+// OperPermissions returns the permissions of an operator, who may perform
+// every action on every resource.
+func OperPermissions() []Permission {
+	var ps []Permission
+	for _, t := range AllResourceTypes {
+		for _, a := range actions {
+			ps = append(ps, Permission{Action: a, Resource: ResourceOfType(t)})
+		}
+	}
+	return ps
+}
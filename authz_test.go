@@ -0,0 +1,116 @@
+package platform_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestPermission_Matches(t *testing.T) {
+	bucket := platform.NewPermission(platform.ReadAction, platform.BucketResourceType, 1, 2)
+
+	tests := []struct {
+		name       string
+		permission platform.Permission
+		requested  platform.Permission
+		want       bool
+	}{
+		{
+			name:       "every resource of the type",
+			permission: platform.Permission{Action: platform.ReadAction, Resource: platform.ResourceOfType(platform.BucketResourceType)},
+			requested:  bucket,
+			want:       true,
+		},
+		{
+			name:       "resources of the organization",
+			permission: platform.Permission{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 1)},
+			requested:  bucket,
+			want:       true,
+		},
+		{
+			name:       "the resource itself",
+			permission: platform.ReadBucketPermission(2),
+			requested:  bucket,
+			want:       true,
+		},
+		{
+			name:       "the resource in its organization",
+			permission: bucket,
+			requested:  bucket,
+			want:       true,
+		},
+		{
+			name:       "resources of another organization",
+			permission: platform.Permission{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 3)},
+			requested:  bucket,
+		},
+		{
+			name:       "another resource",
+			permission: platform.ReadBucketPermission(3),
+			requested:  bucket,
+		},
+		{
+			name:       "another action",
+			permission: platform.WriteBucketPermission(2),
+			requested:  bucket,
+		},
+		{
+			name:       "another type",
+			permission: platform.Permission{Action: platform.ReadAction, Resource: platform.ResourceOfType(platform.DashboardResourceType)},
+			requested:  bucket,
+		},
+		{
+			name:       "a single resource does not grant every resource",
+			permission: platform.ReadBucketPermission(2),
+			requested:  platform.Permission{Action: platform.ReadAction, Resource: platform.ResourceOfType(platform.BucketResourceType)},
+		},
+		{
+			name:       "an organization does not grant resources of unknown organization",
+			permission: platform.Permission{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 1)},
+			requested:  platform.ReadBucketPermission(2),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.permission.Matches(tt.requested); got != tt.want {
+				t.Errorf("%s matches %s: got %v, want %v", tt.permission, tt.requested, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseResource(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    platform.Resource
+		wantErr bool
+	}{
+		{path: "user", want: platform.UserResource},
+		{path: "bucket/0000000000000002", want: platform.BucketResource(2)},
+		{path: "org/0000000000000001", want: platform.OrgResource(1)},
+		{path: "org/0000000000000001/task", want: platform.TaskResource(1)},
+		{path: "org/0000000000000001/bucket/0000000000000002", want: platform.NewResource(platform.BucketResourceType, 1, 2)},
+		{path: "source", wantErr: true},
+		{path: "bucket/nope", wantErr: true},
+		{path: "org/0000000000000001/bucket/0000000000000002/x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := platform.ParseResource(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+			if got.String() != tt.path {
+				t.Errorf("expected %s to round trip, got %s", tt.path, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package platform
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BackupService backs up the metadata and time series data of an instance.
+type BackupService interface {
+	// Backup writes a backup of the instance to w as a tar archive holding
+	// the BackupManifest, the metadata database and the storage engine
+	// files. Only the TSM files modified after since are included when
+	// since is not zero, which makes an incremental backup.
+	Backup(ctx context.Context, w io.Writer, since time.Time) error
+}
+
+// BackupManifest describes the contents of a backup.
+type BackupManifest struct {
+	CreatedAt time.Time `json:"createdAt"`
+	// Since is the time of the previous backup an incremental backup
+	// continues from; it is zero for full backups.
+	Since time.Time `json:"since,omitempty"`
+
+	// Buckets are the buckets of the instance when it was backed up.
+	Buckets []*Bucket `json:"buckets"`
+
+	// Files are the names of every TSM and tombstone file of the storage
+	// engine when it was backed up, including those left out of an
+	// incremental backup.
+	Files []string `json:"files"`
+}
+
+// FindBucket returns the backed up bucket named name of the organization
+// named org. Any organization matches when org is empty.
+func (m *BackupManifest) FindBucket(org, name string) (*Bucket, error) {
+	var found *Bucket
+	for _, b := range m.Buckets {
+		if b.Name != name || (org != "" && b.Organization != org) {
+			continue
+		}
+		if found != nil {
+			return nil, &Error{
+				Code: EConflict,
+				Msg:  "bucket name is ambiguous; the organization of the bucket is required",
+				Op:   "platform.BackupManifest.FindBucket",
+			}
+		}
+		found = b
+	}
+
+	if found == nil {
+		return nil, &Error{
+			Code: ENotFound,
+			Msg:  "bucket not found in backup",
+			Op:   "platform.BackupManifest.FindBucket",
+		}
+	}
+	return found, nil
+}
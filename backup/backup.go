@@ -0,0 +1,85 @@
+// Package backup backs up the metadata and time series data of an instance,
+// and restores them.
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+const (
+	// ManifestFile is the name of the manifest in a backup.
+	ManifestFile = "manifest.json"
+	// MetadataFile is the name of the metadata database in a backup.
+	MetadataFile = "influxd.bolt"
+	// EngineDir is the directory of the storage engine files in a backup.
+	EngineDir = "engine"
+)
+
+// MetadataBackuper backs up the metadata database.
+type MetadataBackuper interface {
+	// Backup writes a consistent snapshot of the database to tw as the
+	// file name.
+	Backup(ctx context.Context, tw *tar.Writer, name string) error
+}
+
+// EngineBackuper backs up the files of the storage engine.
+type EngineBackuper interface {
+	// Backup writes the files of the engine to tw under the directory dir,
+	// leaving out the TSM files not modified after since. It returns the
+	// names of every TSM and tombstone file of the engine.
+	Backup(ctx context.Context, tw *tar.Writer, dir string, since time.Time) ([]string, error)
+}
+
+// Service backs up an instance.
+type Service struct {
+	Metadata      MetadataBackuper
+	Engine        EngineBackuper
+	BucketService platform.BucketService
+}
+
+var _ platform.BackupService = (*Service)(nil)
+
+// Backup writes a backup of the instance to w as a tar archive.
+func (s *Service) Backup(ctx context.Context, w io.Writer, since time.Time) error {
+	m := &platform.BackupManifest{
+		CreatedAt: time.Now().UTC(),
+		Since:     since,
+	}
+
+	var err error
+	if m.Buckets, _, err = s.BucketService.FindBuckets(ctx, platform.BucketFilter{}); err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	if err := s.Metadata.Backup(ctx, tw, MetadataFile); err != nil {
+		return err
+	}
+	if m.Files, err = s.Engine.Backup(ctx, tw, EngineDir, since); err != nil {
+		return err
+	}
+
+	// The manifest is written last, as it lists the files of the engine.
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    ManifestFile,
+		Mode:    0644,
+		Size:    int64(len(b)),
+		ModTime: m.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(b); err != nil {
+		return err
+	}
+	return tw.Close()
+}
@@ -0,0 +1,152 @@
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/backup"
+	"github.com/influxdata/platform/mock"
+)
+
+type metadataBackuper func(ctx context.Context, tw *tar.Writer, name string) error
+
+func (fn metadataBackuper) Backup(ctx context.Context, tw *tar.Writer, name string) error {
+	return fn(ctx, tw, name)
+}
+
+type engineBackuper func(ctx context.Context, tw *tar.Writer, dir string, since time.Time) ([]string, error)
+
+func (fn engineBackuper) Backup(ctx context.Context, tw *tar.Writer, dir string, since time.Time) ([]string, error) {
+	return fn(ctx, tw, dir, since)
+}
+
+func writeFile(tw *tar.Writer, name, content string) error {
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))}); err != nil {
+		return err
+	}
+	_, err := tw.Write([]byte(content))
+	return err
+}
+
+func TestService_BackupRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	since := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	buckets := []*platform.Bucket{{ID: 2, OrganizationID: 1, Organization: "org", Name: "bucket"}}
+
+	bs := mock.NewBucketService()
+	bs.FindBucketsFn = func(ctx context.Context, filter platform.BucketFilter, opts ...platform.FindOptions) ([]*platform.Bucket, int, error) {
+		return buckets, len(buckets), nil
+	}
+
+	s := &backup.Service{
+		Metadata: metadataBackuper(func(ctx context.Context, tw *tar.Writer, name string) error {
+			return writeFile(tw, name, "bolt")
+		}),
+		Engine: engineBackuper(func(ctx context.Context, tw *tar.Writer, dir string, gotSince time.Time) ([]string, error) {
+			if !gotSince.Equal(since) {
+				t.Errorf("unexpected since %v", gotSince)
+			}
+			if err := writeFile(tw, dir+"/data/000000002-000000001.tsm", "tsm"); err != nil {
+				return nil, err
+			}
+			if err := writeFile(tw, dir+"/index/0/L0-00000001.tsl", "tsl"); err != nil {
+				return nil, err
+			}
+			return []string{"000000001-000000001.tsm", "000000002-000000001.tsm"}, nil
+		}),
+		BucketService: bs,
+	}
+
+	var buf bytes.Buffer
+	if err := s.Backup(context.Background(), &buf, since); err != nil {
+		t.Fatalf("failed to back up: %v", err)
+	}
+
+	extracted := filepath.Join(dir, "backup")
+	m, err := backup.Extract(&buf, extracted)
+	if err != nil {
+		t.Fatalf("failed to extract backup: %v", err)
+	}
+	if !m.Since.Equal(since) {
+		t.Errorf("unexpected since %v in manifest", m.Since)
+	}
+	if !reflect.DeepEqual(m.Buckets, buckets) {
+		t.Errorf("unexpected buckets %+v in manifest", m.Buckets)
+	}
+
+	// Restore over an instance with files older and newer than the backup.
+	boltPath := filepath.Join(dir, "influxd.bolt")
+	enginePath := filepath.Join(dir, "engine")
+	for p, content := range map[string]string{
+		boltPath: "old bolt",
+		filepath.Join(enginePath, "data", "000000001-000000001.tsm"): "old tsm",
+		filepath.Join(enginePath, "data", "000000003-000000001.tsm"): "newer tsm",
+		filepath.Join(enginePath, "index", "0", "L0-00000002.tsl"):   "newer tsl",
+		filepath.Join(enginePath, "wal", "_00001.wal"):               "wal",
+	} {
+		if err := os.MkdirAll(filepath.Dir(p), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := backup.Restore(extracted, boltPath, enginePath); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+
+	for p, exp := range map[string]string{
+		boltPath: "bolt",
+		filepath.Join(enginePath, "data", "000000001-000000001.tsm"): "old tsm",
+		filepath.Join(enginePath, "data", "000000002-000000001.tsm"): "tsm",
+		filepath.Join(enginePath, "index", "0", "L0-00000001.tsl"):   "tsl",
+	} {
+		if b, err := ioutil.ReadFile(p); err != nil {
+			t.Errorf("failed to read restored file: %v", err)
+		} else if string(b) != exp {
+			t.Errorf("unexpected content %q of %s", b, p)
+		}
+	}
+	for _, p := range []string{
+		filepath.Join(enginePath, "data", "000000003-000000001.tsm"),
+		filepath.Join(enginePath, "index", "0", "L0-00000002.tsl"),
+		filepath.Join(enginePath, "wal"),
+	} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", p)
+		}
+	}
+}
+
+func TestExtract_InvalidName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := writeFile(tw, "../escaped", "x"); err != nil {
+		t.Fatal(err)
+	}
+	tw.Close()
+
+	if _, err := backup.Extract(&buf, dir); err == nil {
+		t.Fatal("expected an error extracting a file outside of the directory")
+	}
+}
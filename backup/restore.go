@@ -0,0 +1,263 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+	"github.com/influxdata/platform/tsdb/tsm1"
+)
+
+// Extract extracts the backup archive read from r into the directory dir,
+// and returns its manifest.
+func Extract(r io.Reader, dir string) (*platform.BackupManifest, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		p := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(p, filepath.Clean(dir)+string(filepath.Separator)) {
+			return nil, fmt.Errorf("invalid file name %q in backup", hdr.Name)
+		}
+		if err := extractFile(tr, p, hdr.ModTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return ReadManifest(dir)
+}
+
+func extractFile(r io.Reader, p string, modTime time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0777); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	// The modification time of TSM files is compared with the time of
+	// incremental backups.
+	if modTime.IsZero() {
+		return nil
+	}
+	return os.Chtimes(p, modTime, modTime)
+}
+
+// ReadManifest reads the manifest of the backup extracted into dir.
+func ReadManifest(dir string) (*platform.BackupManifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &platform.BackupManifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest: %v", err)
+	}
+	return m, nil
+}
+
+// Restore restores the backup extracted into dir over the metadata database
+// at boltPath and the storage engine files in enginePath, which must use the
+// default directories of the engine. influxd must not be running.
+//
+// An incremental backup only holds the TSM files modified since the
+// previous backup, so a full backup has to be restored first, followed by
+// each incremental backup in the order they were made. The TSM files the
+// engine no longer had when a backup was made are removed.
+func Restore(dir, boltPath, enginePath string) error {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := copyFile(filepath.Join(dir, MetadataFile), boltPath); err != nil {
+		return err
+	}
+
+	// The writes in the WAL are newer than the backup.
+	if err := os.RemoveAll(filepath.Join(enginePath, storage.DefaultWALDirectoryName)); err != nil {
+		return err
+	}
+
+	for _, d := range []string{storage.DefaultIndexDirectoryName, storage.DefaultSeriesFileDirectoryName} {
+		dst := filepath.Join(enginePath, d)
+		if err := os.RemoveAll(dst); err != nil {
+			return err
+		}
+		if err := copyDir(filepath.Join(dir, EngineDir, d), dst); err != nil {
+			return err
+		}
+	}
+
+	data := filepath.Join(enginePath, storage.DefaultEngineDirectoryName)
+	if err := copyDir(filepath.Join(dir, EngineDir, storage.DefaultEngineDirectoryName), data); err != nil {
+		return err
+	}
+
+	files := make(map[string]bool, len(m.Files))
+	for _, name := range m.Files {
+		files[name] = true
+	}
+	fis, err := ioutil.ReadDir(data)
+	if err != nil {
+		return err
+	}
+	for _, fi := range fis {
+		if !fi.IsDir() && !files[fi.Name()] {
+			if err := os.Remove(filepath.Join(data, fi.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyDir copies the files of the directory src and its subdirectories into
+// the directory dst. A missing src is an empty directory.
+func copyDir(src, dst string) error {
+	if err := os.MkdirAll(dst, 0777); err != nil {
+		return err
+	}
+
+	return filepath.Walk(src, func(p string, fi os.FileInfo, err error) error {
+		if os.IsNotExist(err) && p == src {
+			return nil
+		} else if err != nil {
+			return err
+		} else if fi.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		return copyFile(p, filepath.Join(dst, rel))
+	})
+}
+
+// copyFile copies the file src to dst, replacing dst once it's completely
+// written.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmp := dst + ".tmp"
+	if err := extractFile(in, tmp, fi.ModTime()); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// ReadBucket calls fn with each point of the bucket bucketID of the
+// organization orgID stored in the TSM files of the backup extracted into
+// dir, so that they can be written to another bucket. Points have their
+// measurement and fields as they were written. Points of later TSM files
+// are read last, as they replace those of earlier files.
+func ReadBucket(dir string, orgID, bucketID platform.ID, fn func(models.Point) error) error {
+	paths, err := filepath.Glob(filepath.Join(dir, EngineDir, storage.DefaultEngineDirectoryName, "*."+tsm1.TSMFileExtension))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	for _, p := range paths {
+		if err := readTSMBucket(p, name[:], fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readTSMBucket(p string, name []byte, fn func(models.Point) error) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	defer r.Close()
+
+	for i := 0; i < r.KeyCount(); i++ {
+		key, _ := r.KeyAt(i)
+		if !bytes.HasPrefix(key, name) {
+			continue
+		}
+
+		seriesKey, field := tsm1.SeriesAndFieldFromCompositeKey(key)
+		_, tags := models.ParseKeyBytes(seriesKey)
+		measurement := tags.Get(tsdb.MeasurementTagKeyBytes)
+
+		pointTags := make(models.Tags, 0, len(tags))
+		for _, t := range tags {
+			if bytes.Equal(t.Key, tsdb.MeasurementTagKeyBytes) || bytes.Equal(t.Key, tsdb.FieldKeyTagKeyBytes) {
+				continue
+			}
+			pointTags = append(pointTags, t)
+		}
+
+		values, err := r.ReadAll(key)
+		if err != nil {
+			return err
+		}
+		deleted := r.TombstoneRange(key)
+
+	Values:
+		for _, v := range values {
+			for _, tr := range deleted {
+				if v.UnixNano() >= tr.Min && v.UnixNano() <= tr.Max {
+					continue Values
+				}
+			}
+
+			pt, err := models.NewPoint(string(measurement), pointTags, models.Fields{string(field): v.Value()}, time.Unix(0, v.UnixNano()))
+			if err != nil {
+				return err
+			}
+			if err := fn(pt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
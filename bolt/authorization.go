@@ -2,11 +2,13 @@ package bolt
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 
 	"github.com/influxdata/platform"
 	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
 )
 
 var (
@@ -23,6 +25,118 @@ func (c *Client) initializeAuthorizations(ctx context.Context, tx *bolt.Tx) erro
 	if _, err := tx.CreateBucketIfNotExists([]byte(authorizationIndex)); err != nil {
 		return err
 	}
+	if err := c.migrateAuthorizationPermissions(ctx, tx); err != nil {
+		return err
+	}
+	return c.migrateAuthorizationTokens(ctx, tx)
+}
+
+// migrateAuthorizationTokens replaces the raw tokens of authorizations stored
+// before tokens were hashed, both in the authorizations and as keys of the
+// token index, with their hashes.
+func (c *Client) migrateAuthorizationTokens(ctx context.Context, tx *bolt.Tx) error {
+	var rs []*authorizationRecord
+
+	cur := tx.Bucket(authorizationBucket).Cursor()
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		r, err := decodeAuthorizationRecord(v)
+		if err != nil {
+			return err
+		}
+		if r.Token != "" {
+			rs = append(rs, r)
+		}
+	}
+
+	idx := tx.Bucket(authorizationIndex)
+	for _, r := range rs {
+		if err := idx.Delete([]byte(r.Token)); err != nil {
+			return err
+		}
+		r.TokenHash = authorizationIndexKey(r.Token)
+		if err := c.putAuthorizationRecord(ctx, tx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// legacyPermission is a permission as stored before resources were
+// structured, when the resource was a path such as "bucket/<id>" or
+// "org/<id>/task".
+type legacyPermission struct {
+	Action   platform.Action `json:"action"`
+	Resource json.RawMessage `json:"resource"`
+}
+
+// migrateAuthorizationPermissions rewrites the permissions of authorizations
+// stored with legacy resource paths into structured resources. Other fields
+// of the authorizations are left untouched. Permissions whose resource can
+// not be parsed are dropped, as they could never have been allowed.
+func (c *Client) migrateAuthorizationPermissions(ctx context.Context, tx *bolt.Tx) error {
+	type migrated struct {
+		k, v []byte
+	}
+	var ms []migrated
+
+	b := tx.Bucket(authorizationBucket)
+	cur := b.Cursor()
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		var a map[string]json.RawMessage
+		if err := json.Unmarshal(v, &a); err != nil {
+			return err
+		}
+		var lps []legacyPermission
+		if raw, ok := a["permissions"]; ok {
+			if err := json.Unmarshal(raw, &lps); err != nil {
+				return err
+			}
+		}
+
+		legacy := false
+		ps := make([]platform.Permission, 0, len(lps))
+		for _, lp := range lps {
+			var path string
+			if err := json.Unmarshal(lp.Resource, &path); err != nil {
+				// The resource is already structured.
+				p := platform.Permission{Action: lp.Action}
+				if err := json.Unmarshal(lp.Resource, &p.Resource); err != nil {
+					return err
+				}
+				ps = append(ps, p)
+				continue
+			}
+
+			legacy = true
+			r, err := platform.ParseResource(path)
+			if err != nil {
+				c.Logger.Warn("dropping authorization permission with unknown resource",
+					zap.String("id", string(k)), zap.String("resource", path), zap.Error(err))
+				continue
+			}
+			ps = append(ps, platform.Permission{Action: lp.Action, Resource: r})
+		}
+		if !legacy {
+			continue
+		}
+
+		raw, err := json.Marshal(ps)
+		if err != nil {
+			return err
+		}
+		a["permissions"] = raw
+		nv, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		ms = append(ms, migrated{k: append([]byte(nil), k...), v: nv})
+	}
+
+	for _, m := range ms {
+		if err := b.Put(m.k, m.v); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -56,6 +170,23 @@ func (c *Client) FindAuthorizationByID(ctx context.Context, id platform.ID) (*pl
 }
 
 func (c *Client) findAuthorizationByID(ctx context.Context, tx *bolt.Tx, id platform.ID) (*platform.Authorization, *platform.Error) {
+	r, pe := c.findAuthorizationRecord(ctx, tx, id)
+	if pe != nil {
+		return nil, pe
+	}
+
+	a := &r.Authorization
+	a.Token = ""
+	if err := c.setUserOnAuthorization(ctx, tx, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// findAuthorizationRecord returns the stored authorization with id along with the
+// hash of its token.
+func (c *Client) findAuthorizationRecord(ctx context.Context, tx *bolt.Tx, id platform.ID) (*authorizationRecord, *platform.Error) {
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, &platform.Error{
@@ -64,7 +195,6 @@ func (c *Client) findAuthorizationByID(ctx context.Context, tx *bolt.Tx, id plat
 		}
 	}
 
-	var a platform.Authorization
 	v := tx.Bucket(authorizationBucket).Get(encodedID)
 
 	if len(v) == 0 {
@@ -74,18 +204,15 @@ func (c *Client) findAuthorizationByID(ctx context.Context, tx *bolt.Tx, id plat
 		}
 	}
 
-	if err := decodeAuthorization(v, &a); err != nil {
+	r, err := decodeAuthorizationRecord(v)
+	if err != nil {
 		return nil, &platform.Error{
 			Code: platform.EInvalid,
 			Err:  err,
 		}
 	}
 
-	if err := c.setUserOnAuthorization(ctx, tx, &a); err != nil {
-		return nil, err
-	}
-
-	return &a, nil
+	return r, nil
 }
 
 // FindAuthorizationByToken returns a authorization by token for a particular authorization.
@@ -130,12 +257,6 @@ func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platfor
 		}
 	}
 
-	if filter.Token != nil {
-		return func(a *platform.Authorization) bool {
-			return a.Token == *filter.Token
-		}
-	}
-
 	if filter.UserID != nil {
 		return func(a *platform.Authorization) bool {
 			return a.UserID == *filter.UserID
@@ -185,6 +306,15 @@ func (c *Client) FindAuthorizations(ctx context.Context, filter platform.Authori
 }
 
 func (c *Client) findAuthorizations(ctx context.Context, tx *bolt.Tx, f platform.AuthorizationFilter) ([]*platform.Authorization, error) {
+	// Tokens are only stored hashed, so are looked up through the index.
+	if f.Token != nil {
+		a, pe := c.findAuthorizationByToken(ctx, tx, *f.Token)
+		if pe != nil {
+			return nil, pe
+		}
+		return []*platform.Authorization{a}, nil
+	}
+
 	// If the users name was provided, look up user by ID first
 	if f.User != nil {
 		u, err := c.findUserByName(ctx, tx, *f.User)
@@ -224,6 +354,15 @@ func (c *Client) CreateAuthorization(ctx context.Context, a *platform.Authorizat
 			a.UserID = u.ID
 		}
 
+		token, err := c.TokenGenerator.Token()
+		if err != nil {
+			return &platform.Error{
+				Err: err,
+				Op:  op,
+			}
+		}
+		a.Token = token
+
 		unique := c.uniqueAuthorizationToken(ctx, tx, a)
 
 		if !unique {
@@ -234,16 +373,9 @@ func (c *Client) CreateAuthorization(ctx context.Context, a *platform.Authorizat
 			}
 		}
 
-		token, err := c.TokenGenerator.Token()
-		if err != nil {
-			return &platform.Error{
-				Err: err,
-				Op:  op,
-			}
-		}
-		a.Token = token
-
 		a.ID = c.IDGenerator.ID()
+		now := c.time()
+		a.CreatedAt = &now
 
 		pe := c.putAuthorization(ctx, tx, a)
 		if pe != nil {
@@ -265,20 +397,50 @@ func (c *Client) PutAuthorization(ctx context.Context, a *platform.Authorization
 	})
 }
 
-func encodeAuthorization(a *platform.Authorization) ([]byte, error) {
-	a.User = ""
-	switch a.Status {
+// authorizationRecord is an authorization as stored. Its token is replaced by
+// the SHA-256 hash of the token, which is also its key in the token index, so
+// that the stored authorizations do not hold any usable credentials.
+type authorizationRecord struct {
+	platform.Authorization
+	TokenHash []byte `json:"tokenHash,omitempty"`
+}
+
+func encodeAuthorization(r *authorizationRecord) ([]byte, error) {
+	switch r.Status {
 	case platform.Active, platform.Inactive:
 	case "":
-		a.Status = platform.Active
+		r.Status = platform.Active
 	default:
 		return nil, fmt.Errorf("unknown authorization status")
 	}
-	return json.Marshal(a)
+
+	stored := *r
+	stored.Token = ""
+	stored.User = ""
+	return json.Marshal(stored)
 }
 
 func (c *Client) putAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.Authorization) *platform.Error {
-	v, err := encodeAuthorization(a)
+	if a.Token == "" {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "token is required",
+		}
+	}
+
+	r := &authorizationRecord{
+		Authorization: *a,
+		TokenHash:     authorizationIndexKey(a.Token),
+	}
+	if err := c.putAuthorizationRecord(ctx, tx, r); err != nil {
+		return err
+	}
+	a.Status = r.Status
+	return c.setUserOnAuthorization(ctx, tx, a)
+}
+
+func (c *Client) putAuthorizationRecord(ctx context.Context, tx *bolt.Tx, r *authorizationRecord) *platform.Error {
+	v, err := encodeAuthorization(r)
 	if err != nil {
 		return &platform.Error{
 			Code: platform.EInvalid,
@@ -286,7 +448,7 @@ func (c *Client) putAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.
 		}
 	}
 
-	encodedID, err := a.ID.Encode()
+	encodedID, err := r.ID.Encode()
 	if err != nil {
 		return &platform.Error{
 			Code: platform.ENotFound,
@@ -294,7 +456,7 @@ func (c *Client) putAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.
 		}
 	}
 
-	if err := tx.Bucket(authorizationIndex).Put(authorizationIndexKey(a.Token), encodedID); err != nil {
+	if err := tx.Bucket(authorizationIndex).Put(r.TokenHash, encodedID); err != nil {
 		return &platform.Error{
 			Code: platform.EInternal,
 			Err:  err,
@@ -305,23 +467,39 @@ func (c *Client) putAuthorization(ctx context.Context, tx *bolt.Tx, a *platform.
 			Err: err,
 		}
 	}
-	return c.setUserOnAuthorization(ctx, tx, a)
+	return nil
 }
 
+// authorizationIndexKey returns the key of the token n in the token index,
+// which is the SHA-256 hash of the token.
 func authorizationIndexKey(n string) []byte {
-	return []byte(n)
+	h := sha256.Sum256([]byte(n))
+	return h[:]
 }
 
 func decodeAuthorization(b []byte, a *platform.Authorization) error {
-	if err := json.Unmarshal(b, a); err != nil {
+	r, err := decodeAuthorizationRecord(b)
+	if err != nil {
 		return err
 	}
-	if a.Status == "" {
-		a.Status = platform.Active
-	}
+	*a = r.Authorization
+	a.Token = ""
 	return nil
 }
 
+// decodeAuthorizationRecord decodes a stored authorization. The token of the
+// record is only set for authorizations stored before tokens were hashed.
+func decodeAuthorizationRecord(b []byte) (*authorizationRecord, error) {
+	r := &authorizationRecord{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, err
+	}
+	if r.Status == "" {
+		r.Status = platform.Active
+	}
+	return r, nil
+}
+
 // forEachAuthorization will iterate through all authorizations while fn returns true.
 func (c *Client) forEachAuthorization(ctx context.Context, tx *bolt.Tx, fn func(*platform.Authorization) bool) error {
 	cur := tx.Bucket(authorizationBucket).Cursor()
@@ -361,11 +539,11 @@ func (c *Client) DeleteAuthorization(ctx context.Context, id platform.ID) error
 }
 
 func (c *Client) deleteAuthorization(ctx context.Context, tx *bolt.Tx, id platform.ID) *platform.Error {
-	a, pe := c.findAuthorizationByID(ctx, tx, id)
+	r, pe := c.findAuthorizationRecord(ctx, tx, id)
 	if pe != nil {
 		return pe
 	}
-	if err := tx.Bucket(authorizationIndex).Delete(authorizationIndexKey(a.Token)); err != nil {
+	if err := tx.Bucket(authorizationIndex).Delete(r.TokenHash); err != nil {
 		return &platform.Error{
 			Err: err,
 		}
@@ -388,37 +566,22 @@ func (c *Client) deleteAuthorization(ctx context.Context, tx *bolt.Tx, id platfo
 // SetAuthorizationStatus updates the status of the authorization. Useful
 // for setting an authorization to inactive or active.
 func (c *Client) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
-	return c.db.Update(func(tx *bolt.Tx) error {
-		return c.updateAuthorization(ctx, tx, id, status)
+	return c.db.Update(func(tx *bolt.Tx) (err error) {
+		pe := c.updateAuthorization(ctx, tx, id, status)
+		if pe != nil {
+			pe.Op = getOp(platform.OpSetAuthorizationStatus)
+			err = pe
+		}
+		return err
 	})
 }
 
 func (c *Client) updateAuthorization(ctx context.Context, tx *bolt.Tx, id platform.ID, status platform.Status) *platform.Error {
-	a, pe := c.findAuthorizationByID(ctx, tx, id)
+	r, pe := c.findAuthorizationRecord(ctx, tx, id)
 	if pe != nil {
 		return pe
 	}
 
-	a.Status = status
-	b, err := encodeAuthorization(a)
-	if err != nil {
-		return &platform.Error{
-			Code: platform.EInvalid,
-			Err:  err,
-		}
-	}
-
-	encodedID, err := id.Encode()
-	if err != nil {
-		return &platform.Error{
-			Err: err,
-		}
-	}
-
-	if err = tx.Bucket(authorizationBucket).Put(encodedID, b); err != nil {
-		return &platform.Error{
-			Err: err,
-		}
-	}
-	return nil
+	r.Status = status
+	return c.putAuthorizationRecord(ctx, tx, r)
 }
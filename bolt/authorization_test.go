@@ -1,12 +1,16 @@
 package bolt_test
 
 import (
+	"bytes"
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/bolt"
 	platformtesting "github.com/influxdata/platform/testing"
+	bbolt "go.etcd.io/bbolt"
 )
 
 func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.T) (platform.AuthorizationService, string, func()) {
@@ -45,3 +49,134 @@ func initAuthorizationService(f platformtesting.AuthorizationFields, t *testing.
 func TestAuthorizationService(t *testing.T) {
 	platformtesting.AuthorizationService(initAuthorizationService, t)
 }
+
+func TestAuthorizationService_MigrateLegacyPermissions(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	if err := c.PutUser(ctx, &platform.User{ID: 1, Name: "user1"}); err != nil {
+		t.Fatalf("failed to populate users: %v", err)
+	}
+
+	const legacy = `{"id":"0000000000000002","token":"abc","status":"active","userID":"0000000000000001","permissions":[` +
+		`{"action":"create","resource":"user"},` +
+		`{"action":"write","resource":"bucket/0000000000000003"},` +
+		`{"action":"read","resource":"org/0000000000000004/task"},` +
+		`{"action":"read","resource":"org/0000000000000004/source"}]}`
+	if err := c.DB().Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket([]byte("authorizationindexv1")).Put([]byte("abc"), []byte("0000000000000002")); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte("authorizationsv1")).Put([]byte("0000000000000002"), []byte(legacy))
+	}); err != nil {
+		t.Fatalf("failed to populate legacy authorization: %v", err)
+	}
+
+	// Reopening the client migrates the stored authorizations.
+	if err := c.Close(); err != nil {
+		t.Fatalf("failed to close client: %v", err)
+	}
+	if err := c.Open(ctx); err != nil {
+		t.Fatalf("failed to reopen client: %v", err)
+	}
+
+	a, err := c.FindAuthorizationByToken(ctx, "abc")
+	if err != nil {
+		t.Fatalf("failed to find migrated authorization: %v", err)
+	}
+
+	want := []platform.Permission{
+		platform.CreateUserPermission,
+		platform.WriteBucketPermission(3),
+		{Action: platform.ReadAction, Resource: platform.TaskResource(4)},
+	}
+	if diff := cmp.Diff(a.Permissions, want); diff != "" {
+		t.Errorf("permissions are different -got/+want\ndiff %s", diff)
+	}
+}
+
+func TestAuthorizationService_StoresTokenHashed(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	if err := c.PutUser(ctx, &platform.User{ID: 1, Name: "user1"}); err != nil {
+		t.Fatalf("failed to populate users: %v", err)
+	}
+	if err := c.PutAuthorization(ctx, &platform.Authorization{ID: 2, UserID: 1, Token: "supersecret"}); err != nil {
+		t.Fatalf("failed to populate authorizations: %v", err)
+	}
+
+	if err := c.DB().View(func(tx *bbolt.Tx) error {
+		for _, name := range []string{"authorizationsv1", "authorizationindexv1"} {
+			if err := tx.Bucket([]byte(name)).ForEach(func(k, v []byte) error {
+				if bytes.Contains(k, []byte("supersecret")) || bytes.Contains(v, []byte("supersecret")) {
+					t.Errorf("expected the token not to be stored in %s", name)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to read authorizations: %v", err)
+	}
+
+	a, err := c.FindAuthorizationByToken(ctx, "supersecret")
+	if err != nil {
+		t.Fatalf("failed to find authorization by token: %v", err)
+	}
+	if a.ID != 2 {
+		t.Errorf("expected authorization %s got %s", platform.ID(2), a.ID)
+	}
+	if a.Token != "" {
+		t.Errorf("expected the token not to be returned, got %q", a.Token)
+	}
+}
+
+func TestAuthorizationService_RecordAuthorizationUsage(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	if err := c.PutUser(ctx, &platform.User{ID: 1, Name: "user1"}); err != nil {
+		t.Fatalf("failed to populate users: %v", err)
+	}
+	if err := c.PutAuthorization(ctx, &platform.Authorization{ID: 2, UserID: 1, Token: "abc"}); err != nil {
+		t.Fatalf("failed to populate authorizations: %v", err)
+	}
+
+	first := time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)
+	last := first.Add(time.Minute)
+	c.RecordAuthorizationUsage(ctx, 2, last)
+	c.RecordAuthorizationUsage(ctx, 2, first)
+	// Usage of deleted authorizations is ignored.
+	c.RecordAuthorizationUsage(ctx, 3, last)
+
+	// Closing the client stores the batched usage.
+	if err := c.Close(); err != nil {
+		t.Fatalf("failed to close client: %v", err)
+	}
+	if err := c.Open(ctx); err != nil {
+		t.Fatalf("failed to reopen client: %v", err)
+	}
+
+	a, err := c.FindAuthorizationByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to find authorization: %v", err)
+	}
+	if a.LastUsedAt == nil || !a.LastUsedAt.Equal(last) {
+		t.Errorf("expected last used time to be %v got %v", last, a.LastUsedAt)
+	}
+}
@@ -0,0 +1,109 @@
+package bolt
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+var _ platform.AuthorizationUsageRecorder = (*Client)(nil)
+
+// authorizationUsageFlushInterval is how often the last used times of
+// authorizations are stored.
+const authorizationUsageFlushInterval = 10 * time.Second
+
+// authorizationUsage batches the last used times of authorizations, so that
+// using an authorization does not write to the database.
+type authorizationUsage struct {
+	mu   sync.Mutex
+	used map[platform.ID]time.Time
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// RecordAuthorizationUsage records that the authorization id was used at t.
+// The last used times are stored in batches every few seconds, and when the
+// client is closed.
+func (c *Client) RecordAuthorizationUsage(ctx context.Context, id platform.ID, t time.Time) {
+	c.usage.mu.Lock()
+	defer c.usage.mu.Unlock()
+	if c.usage.used == nil {
+		c.usage.used = make(map[platform.ID]time.Time)
+	}
+	if last, ok := c.usage.used[id]; !ok || t.After(last) {
+		c.usage.used[id] = t
+	}
+}
+
+// startAuthorizationUsage starts storing the batched last used times of
+// authorizations periodically.
+func (c *Client) startAuthorizationUsage() {
+	c.usage.done = make(chan struct{})
+	c.usage.wg.Add(1)
+	go func() {
+		defer c.usage.wg.Done()
+		ticker := time.NewTicker(authorizationUsageFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.flushAuthorizationUsage(context.Background()); err != nil {
+					c.Logger.Info("failed to store authorization usage", zap.Error(err))
+				}
+			case <-c.usage.done:
+				return
+			}
+		}
+	}()
+}
+
+// stopAuthorizationUsage stops the periodic storing of last used times and
+// stores those still batched.
+func (c *Client) stopAuthorizationUsage() error {
+	if c.usage.done == nil {
+		return nil
+	}
+	close(c.usage.done)
+	c.usage.wg.Wait()
+	c.usage.done = nil
+	return c.flushAuthorizationUsage(context.Background())
+}
+
+// flushAuthorizationUsage stores the batched last used times in a single
+// transaction. Authorizations deleted since they were used are skipped.
+func (c *Client) flushAuthorizationUsage(ctx context.Context) error {
+	c.usage.mu.Lock()
+	used := c.usage.used
+	c.usage.used = nil
+	c.usage.mu.Unlock()
+
+	if len(used) == 0 {
+		return nil
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		for id, t := range used {
+			r, pe := c.findAuthorizationRecord(ctx, tx, id)
+			if pe != nil {
+				if platform.ErrorCode(pe) == platform.ENotFound {
+					continue
+				}
+				return pe
+			}
+			if r.LastUsedAt != nil && !t.After(*r.LastUsedAt) {
+				continue
+			}
+			t := t
+			r.LastUsedAt = &t
+			if pe := c.putAuthorizationRecord(ctx, tx, r); pe != nil {
+				return pe
+			}
+		}
+		return nil
+	})
+}
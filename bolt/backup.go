@@ -0,0 +1,52 @@
+package bolt
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Backup writes a consistent snapshot of the database to tw as the file
+// name. The snapshot is copied to a temporary file in a read transaction
+// before it is written, so that the transaction is not held open while tw
+// is written to.
+func (c *Client) Backup(ctx context.Context, tw *tar.Writer, name string) error {
+	f, err := ioutil.TempFile("", "influxd-bolt-backup-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := c.db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(f)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    fi.Size(),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, f)
+	return err
+}
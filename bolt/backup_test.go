@@ -0,0 +1,70 @@
+package bolt_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+)
+
+func TestClient_Backup(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	if err := c.PutOrganization(ctx, &platform.Organization{ID: 1, Name: "org"}); err != nil {
+		t.Fatalf("failed to populate organizations: %v", err)
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := c.Backup(ctx, tw, "influxd.bolt"); err != nil {
+		t.Fatalf("failed to back up: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(&buf)
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hdr.Name != "influxd.bolt" {
+		t.Fatalf("unexpected file name %q", hdr.Name)
+	}
+
+	f, err := ioutil.TempFile("", "influxdata-platform-bolt-backup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := io.Copy(f, tr); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	restored := bolt.NewClient()
+	restored.Path = f.Name()
+	if err := restored.Open(ctx); err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	defer restored.Close()
+
+	o, err := restored.FindOrganizationByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find organization in backup: %v", err)
+	}
+	if o.Name != "org" {
+		t.Errorf("unexpected organization name %q", o.Name)
+	}
+}
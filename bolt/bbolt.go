@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/keyring"
 	"github.com/influxdata/platform/rand"
 	"github.com/influxdata/platform/snowflake"
 	bolt "go.etcd.io/bbolt"
@@ -41,6 +42,17 @@ type Client struct {
 	IDGenerator    platform.IDGenerator
 	TokenGenerator platform.TokenGenerator
 	time           func() time.Time
+
+	// SecretKeyring encrypts secret values. Secrets are only base64 encoded
+	// when it is nil.
+	SecretKeyring *keyring.Keyring
+
+	// StoragePurger, when set, removes the stored data of deleted buckets
+	// and organizations.
+	StoragePurger platform.StoragePurger
+
+	usage    authorizationUsage
+	orgUsage usageRecords
 }
 
 // NewClient returns an instance of a Client.
@@ -92,6 +104,9 @@ func (c *Client) Open(ctx context.Context) error {
 		return err
 	}
 
+	c.startAuthorizationUsage()
+	c.startUsage()
+
 	c.Logger.Info("Resources opened", zap.String("path", c.Path))
 	return nil
 }
@@ -164,6 +179,11 @@ func (c *Client) initialize(ctx context.Context) error {
 			return err
 		}
 
+		// Always create OAuth2 identity bucket.
+		if err := c.initializeOAuth2Identities(ctx, tx); err != nil {
+			return err
+		}
+
 		// Always create KeyValueLog bucket.
 		if err := c.initializeKeyValueLog(ctx, tx); err != nil {
 			return err
@@ -174,6 +194,16 @@ func (c *Client) initialize(ctx context.Context) error {
 			return err
 		}
 
+		// Always create Limits bucket.
+		if err := c.initializeLimits(ctx, tx); err != nil {
+			return err
+		}
+
+		// Always create Usage bucket.
+		if err := c.initializeUsage(ctx, tx); err != nil {
+			return err
+		}
+
 		return nil
 	}); err != nil {
 		return err
@@ -185,6 +215,12 @@ func (c *Client) initialize(ctx context.Context) error {
 // Close the connection to the bolt database
 func (c *Client) Close() error {
 	if c.db != nil {
+		if err := c.stopAuthorizationUsage(); err != nil {
+			c.Logger.Info("failed to store authorization usage", zap.Error(err))
+		}
+		if err := c.stopUsage(); err != nil {
+			c.Logger.Info("failed to store usage", zap.Error(err))
+		}
 		return c.db.Close()
 	}
 	return nil
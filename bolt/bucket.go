@@ -9,6 +9,7 @@ import (
 	"github.com/influxdata/platform"
 	platformcontext "github.com/influxdata/platform/context"
 	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
 )
 
 var (
@@ -276,6 +277,10 @@ func (c *Client) CreateBucket(ctx context.Context, b *platform.Bucket) error {
 			return fmt.Errorf("bucket with name %s already exists", b.Name)
 		}
 
+		if err := c.checkBucketLimit(ctx, tx, b.OrganizationID); err != nil {
+			return err
+		}
+
 		b.ID = c.IDGenerator.ID()
 
 		if err := c.appendBucketEventToLog(ctx, tx, b.ID, bucketCreatedEvent); err != nil {
@@ -411,9 +416,27 @@ func (c *Client) updateBucket(ctx context.Context, tx *bolt.Tx, id platform.ID,
 
 // DeleteBucket deletes a bucket and prunes it from the index.
 func (c *Client) DeleteBucket(ctx context.Context, id platform.ID) error {
-	return c.db.Update(func(tx *bolt.Tx) error {
+	var orgID platform.ID
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b, err := c.findBucketByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		orgID = b.OrganizationID
 		return c.deleteBucket(ctx, tx, id)
 	})
+	if err != nil {
+		return err
+	}
+
+	if c.StoragePurger != nil {
+		// The bucket is deleted even if its data could not be purged.
+		if err := c.StoragePurger.PurgeBucket(ctx, orgID, id); err != nil {
+			c.Logger.Warn("failed to purge the data of the deleted bucket",
+				zap.String("bucket_id", id.String()), zap.Error(err))
+		}
+	}
+	return nil
 }
 
 func (c *Client) deleteBucket(ctx context.Context, tx *bolt.Tx, id platform.ID) error {
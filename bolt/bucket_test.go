@@ -2,9 +2,11 @@ package bolt_test
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
 	platformtesting "github.com/influxdata/platform/testing"
 )
 
@@ -63,3 +65,43 @@ func TestBucketService_FindBucket(t *testing.T) {
 func TestBucketService_UpdateBucket(t *testing.T) {
 	platformtesting.UpdateBucket(initBucketService, t)
 }
+
+func TestBucketService_DeleteBucketPurgesData(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	var purged []platform.ID
+	c.StoragePurger = &mock.StoragePurger{
+		PurgeBucketF: func(ctx context.Context, orgID, bucketID platform.ID) error {
+			purged = append(purged, orgID, bucketID)
+			return nil
+		},
+	}
+
+	ctx := context.Background()
+	if err := c.PutOrganization(ctx, &platform.Organization{ID: 1, Name: "org"}); err != nil {
+		t.Fatalf("failed to populate organizations: %v", err)
+	}
+	if err := c.PutBucket(ctx, &platform.Bucket{ID: 2, OrganizationID: 1, Name: "bucket"}); err != nil {
+		t.Fatalf("failed to populate buckets: %v", err)
+	}
+
+	if err := c.DeleteBucket(ctx, 2); err != nil {
+		t.Fatalf("failed to delete bucket: %v", err)
+	}
+	if want := []platform.ID{1, 2}; !reflect.DeepEqual(purged, want) {
+		t.Errorf("expected the data of org and bucket %v to be purged, got %v", want, purged)
+	}
+
+	// Buckets that do not exist have no data to purge.
+	purged = nil
+	if err := c.DeleteBucket(ctx, 3); err == nil {
+		t.Errorf("expected an error deleting a missing bucket")
+	}
+	if len(purged) != 0 {
+		t.Errorf("expected no data to be purged, got %v", purged)
+	}
+}
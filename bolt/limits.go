@@ -0,0 +1,162 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	limitsBucket = []byte("limitsv1")
+)
+
+var _ platform.LimitService = (*Client)(nil)
+
+func (c *Client) initializeLimits(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(limitsBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindLimits returns the limits of the organization orgID.
+func (c *Client) FindLimits(ctx context.Context, orgID platform.ID) (*platform.Limits, error) {
+	var l *platform.Limits
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var pe *platform.Error
+		l, pe = c.findLimits(ctx, tx, orgID)
+		if pe != nil {
+			pe.Op = getOp(platform.OpFindLimits)
+			return pe
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (c *Client) findLimits(ctx context.Context, tx *bolt.Tx, orgID platform.ID) (*platform.Limits, *platform.Error) {
+	encodedID, err := orgID.Encode()
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+
+	if tx.Bucket(organizationBucket).Get(encodedID) == nil {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  "organization not found",
+		}
+	}
+
+	l := &platform.Limits{OrganizationID: orgID}
+	v := tx.Bucket(limitsBucket).Get(encodedID)
+	if v == nil {
+		return l, nil
+	}
+	if err := json.Unmarshal(v, l); err != nil {
+		return nil, &platform.Error{
+			Err: err,
+		}
+	}
+	return l, nil
+}
+
+// UpdateLimits updates the limits of the organization orgID.
+func (c *Client) UpdateLimits(ctx context.Context, orgID platform.ID, upd platform.LimitsUpdate) (*platform.Limits, error) {
+	if err := upd.Valid(); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   getOp(platform.OpUpdateLimits),
+			Err:  err,
+		}
+	}
+
+	var l *platform.Limits
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		var pe *platform.Error
+		l, pe = c.findLimits(ctx, tx, orgID)
+		if pe != nil {
+			pe.Op = getOp(platform.OpUpdateLimits)
+			return pe
+		}
+
+		upd.Apply(l)
+		if err := c.putLimits(ctx, tx, l); err != nil {
+			return &platform.Error{
+				Op:  getOp(platform.OpUpdateLimits),
+				Err: err,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (c *Client) putLimits(ctx context.Context, tx *bolt.Tx, l *platform.Limits) error {
+	encodedID, err := l.OrganizationID.Encode()
+	if err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(limitsBucket).Put(encodedID, v)
+}
+
+func (c *Client) deleteLimits(ctx context.Context, tx *bolt.Tx, orgID platform.ID) error {
+	encodedID, err := orgID.Encode()
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(limitsBucket).Delete(encodedID)
+}
+
+// checkBucketLimit returns an error if the organization orgID has reached
+// its maximum number of buckets. Buckets are counted in the transaction that
+// creates them, so that concurrent creations cannot exceed the limit.
+func (c *Client) checkBucketLimit(ctx context.Context, tx *bolt.Tx, orgID platform.ID) error {
+	encodedID, err := orgID.Encode()
+	if err != nil {
+		return err
+	}
+
+	v := tx.Bucket(limitsBucket).Get(encodedID)
+	if v == nil {
+		return nil
+	}
+	var l platform.Limits
+	if err := json.Unmarshal(v, &l); err != nil {
+		return err
+	}
+	if l.MaxBuckets <= 0 {
+		return nil
+	}
+
+	// The bucket index is keyed by organization, then bucket name.
+	n := 0
+	cur := tx.Bucket(bucketIndex).Cursor()
+	for k, _ := cur.Seek(encodedID); k != nil && bytes.HasPrefix(k, encodedID); k, _ = cur.Next() {
+		n++
+	}
+	if n >= l.MaxBuckets {
+		return &platform.Error{
+			Code: platform.ETooManyRequests,
+			Msg:  fmt.Sprintf("organization has reached its limit of %d buckets", l.MaxBuckets),
+		}
+	}
+	return nil
+}
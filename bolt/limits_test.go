@@ -0,0 +1,87 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestClient_Limits(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	if err := c.PutOrganization(ctx, &platform.Organization{ID: 1, Name: "org"}); err != nil {
+		t.Fatalf("failed to populate organizations: %v", err)
+	}
+
+	l, err := c.FindLimits(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find limits: %v", err)
+	}
+	if *l != (platform.Limits{OrganizationID: 1}) {
+		t.Errorf("expected no limits, got %+v", l)
+	}
+
+	maxBuckets, maxSeries := 2, 1000
+	if _, err := c.UpdateLimits(ctx, 1, platform.LimitsUpdate{MaxBuckets: &maxBuckets}); err != nil {
+		t.Fatalf("failed to update limits: %v", err)
+	}
+	if _, err := c.UpdateLimits(ctx, 1, platform.LimitsUpdate{MaxSeries: &maxSeries}); err != nil {
+		t.Fatalf("failed to update limits: %v", err)
+	}
+
+	l, err = c.FindLimits(ctx, 1)
+	if err != nil {
+		t.Fatalf("failed to find limits: %v", err)
+	}
+	if exp := (platform.Limits{OrganizationID: 1, MaxBuckets: 2, MaxSeries: 1000}); *l != exp {
+		t.Errorf("unexpected limits %+v; expected %+v", l, exp)
+	}
+
+	negative := -1
+	if _, err := c.UpdateLimits(ctx, 1, platform.LimitsUpdate{MaxBuckets: &negative}); platform.ErrorCode(err) != platform.EInvalid {
+		t.Errorf("expected an invalid error updating a negative limit, got %v", err)
+	}
+	if _, err := c.FindLimits(ctx, 2); platform.ErrorCode(err) != platform.ENotFound {
+		t.Errorf("expected not found error finding limits of a missing organization, got %v", err)
+	}
+}
+
+func TestClient_CreateBucketLimit(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	for _, o := range []*platform.Organization{{ID: 1, Name: "org"}, {ID: 2, Name: "other"}} {
+		if err := c.PutOrganization(ctx, o); err != nil {
+			t.Fatalf("failed to populate organizations: %v", err)
+		}
+	}
+	maxBuckets := 2
+	if _, err := c.UpdateLimits(ctx, 1, platform.LimitsUpdate{MaxBuckets: &maxBuckets}); err != nil {
+		t.Fatalf("failed to update limits: %v", err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		if err := c.CreateBucket(ctx, &platform.Bucket{OrganizationID: 1, Name: name}); err != nil {
+			t.Fatalf("expected bucket %q to be created: %v", name, err)
+		}
+	}
+	if err := c.CreateBucket(ctx, &platform.Bucket{OrganizationID: 1, Name: "c"}); platform.ErrorCode(err) != platform.ETooManyRequests {
+		t.Fatalf("expected bucket over the limit to be rejected, got %v", err)
+	}
+	// The buckets of other organizations are not counted.
+	for _, name := range []string{"a", "b", "c"} {
+		if err := c.CreateBucket(ctx, &platform.Bucket{OrganizationID: 2, Name: name}); err != nil {
+			t.Fatalf("expected bucket %q of another organization to be created: %v", name, err)
+		}
+	}
+}
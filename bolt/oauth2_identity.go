@@ -0,0 +1,118 @@
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/platform"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	oauth2IdentityBucket = []byte("oauth2identitiesv1")
+)
+
+var _ platform.OAuth2IdentityService = (*Client)(nil)
+
+func (c *Client) initializeOAuth2Identities(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(oauth2IdentityBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// oauth2IdentityKey is the key of the identity of subject at issuer. Neither
+// may contain a NUL byte, so the key is unique to the pair.
+func oauth2IdentityKey(issuer, subject string) []byte {
+	return []byte(issuer + "\x00" + subject)
+}
+
+// FindOAuth2Identity returns the identity of subject at the issuer.
+func (c *Client) FindOAuth2Identity(ctx context.Context, issuer, subject string) (*platform.OAuth2Identity, error) {
+	var i *platform.OAuth2Identity
+	err := c.db.View(func(tx *bolt.Tx) error {
+		id, err := c.findOAuth2Identity(ctx, tx, issuer, subject)
+		if err != nil {
+			return err
+		}
+		i = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+func (c *Client) findOAuth2Identity(ctx context.Context, tx *bolt.Tx, issuer, subject string) (*platform.OAuth2Identity, error) {
+	v := tx.Bucket(oauth2IdentityBucket).Get(oauth2IdentityKey(issuer, subject))
+	if len(v) == 0 {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  "oauth2 identity not found",
+		}
+	}
+
+	i := &platform.OAuth2Identity{}
+	if err := json.Unmarshal(v, i); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// CreateOAuth2Identity links the identity i to its user.
+func (c *Client) CreateOAuth2Identity(ctx context.Context, i *platform.OAuth2Identity) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		if i.Issuer == "" || i.Subject == "" {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "oauth2 identity requires an issuer and a subject",
+			}
+		}
+
+		if _, err := c.findOAuth2Identity(ctx, tx, i.Issuer, i.Subject); err == nil {
+			return &platform.Error{
+				Code: platform.EConflict,
+				Msg:  "oauth2 identity is already linked to a user",
+			}
+		}
+
+		if _, err := c.findUserByID(ctx, tx, i.UserID); err != nil {
+			return err
+		}
+
+		v, err := json.Marshal(i)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(oauth2IdentityBucket).Put(oauth2IdentityKey(i.Issuer, i.Subject), v)
+	})
+}
+
+// deleteUserOAuth2Identities removes the identities linked to the user id.
+func (c *Client) deleteUserOAuth2Identities(ctx context.Context, tx *bolt.Tx, id platform.ID) error {
+	b := tx.Bucket(oauth2IdentityBucket)
+
+	var keys [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		i := &platform.OAuth2Identity{}
+		if err := json.Unmarshal(v, i); err != nil {
+			return err
+		}
+		if i.UserID == id {
+			keys = append(keys, k)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initOAuth2IdentityService(f platformtesting.OAuth2IdentityFields, t *testing.T) (platform.OAuth2IdentityService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	ctx := context.Background()
+	for _, u := range f.Users {
+		if err := c.PutUser(ctx, u); err != nil {
+			t.Fatalf("failed to populate users")
+		}
+	}
+	for _, i := range f.Identities {
+		if err := c.CreateOAuth2Identity(ctx, i); err != nil {
+			t.Fatalf("failed to populate oauth2 identities: %v", err)
+		}
+	}
+	return c, func() {
+		defer closeFn()
+	}
+}
+
+func TestOAuth2IdentityService(t *testing.T) {
+	platformtesting.OAuth2IdentityService(initOAuth2IdentityService, t)
+}
@@ -109,17 +109,9 @@ func (c *Client) Generate(ctx context.Context, req *platform.OnboardingRequest)
 		return nil, err
 	}
 	auth := &platform.Authorization{
-		User:   u.Name,
-		UserID: u.ID,
-		Permissions: []platform.Permission{
-			platform.CreateUserPermission,
-			platform.DeleteUserPermission,
-			{
-				Resource: platform.OrganizationResource,
-				Action:   platform.WriteAction,
-			},
-			platform.WriteBucketPermission(bucket.ID),
-		},
+		User:        u.Name,
+		UserID:      u.ID,
+		Permissions: platform.OperPermissions(),
 	}
 	if err = c.CreateAuthorization(ctx, auth); err != nil {
 		return nil, err
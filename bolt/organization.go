@@ -9,6 +9,7 @@ import (
 	"github.com/influxdata/platform"
 	platformcontext "github.com/influxdata/platform/context"
 	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
 )
 
 var (
@@ -300,12 +301,27 @@ func (c *Client) updateOrganization(ctx context.Context, tx *bolt.Tx, id platfor
 
 // DeleteOrganization deletes a organization and prunes it from the index.
 func (c *Client) DeleteOrganization(ctx context.Context, id platform.ID) error {
-	return c.db.Update(func(tx *bolt.Tx) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
 		if err := c.deleteOrganizationsBuckets(ctx, tx, id); err != nil {
 			return err
 		}
+		if err := c.deleteLimits(ctx, tx, id); err != nil {
+			return err
+		}
 		return c.deleteOrganization(ctx, tx, id)
 	})
+	if err != nil {
+		return err
+	}
+
+	if c.StoragePurger != nil {
+		// The organization is deleted even if its data could not be purged.
+		if err := c.StoragePurger.PurgeOrganization(ctx, id); err != nil {
+			c.Logger.Warn("failed to purge the data of the deleted organization",
+				zap.String("org_id", id.String()), zap.Error(err))
+		}
+	}
+	return nil
 }
 
 func (c *Client) deleteOrganization(ctx context.Context, tx *bolt.Tx, id platform.ID) error {
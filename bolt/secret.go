@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/keyring"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -53,7 +54,7 @@ func (c *Client) loadSecret(ctx context.Context, tx *bolt.Tx, orgID platform.ID,
 		return "", fmt.Errorf("secret not found")
 	}
 
-	v, err := decodeSecretValue(val)
+	v, err := c.decodeSecretValue(key, val)
 	if err != nil {
 		return "", err
 	}
@@ -88,6 +89,9 @@ func (c *Client) getSecretKeys(ctx context.Context, tx *bolt.Tx, orgID platform.
 		return nil, err
 	}
 	k, _ := cur.Seek(prefix)
+	if len(k) == 0 {
+		return []string{}, nil
+	}
 
 	id, key, err := decodeSecretKey(k)
 	if err != nil {
@@ -95,7 +99,7 @@ func (c *Client) getSecretKeys(ctx context.Context, tx *bolt.Tx, orgID platform.
 	}
 
 	if id != orgID {
-		return nil, fmt.Errorf("organization has no secret keys")
+		return []string{}, nil
 	}
 
 	keys := []string{key}
@@ -137,7 +141,10 @@ func (c *Client) putSecret(ctx context.Context, tx *bolt.Tx, orgID platform.ID,
 		return err
 	}
 
-	val := encodeSecretValue(v)
+	val, err := c.encodeSecretValue(key, v)
+	if err != nil {
+		return err
+	}
 
 	if err := tx.Bucket(secretBucket).Put(key, val); err != nil {
 		return err
@@ -145,6 +152,27 @@ func (c *Client) putSecret(ctx context.Context, tx *bolt.Tx, orgID platform.ID,
 	return nil
 }
 
+// DeleteSecret removes the secrets with keys ks for the organization orgID.
+func (c *Client) DeleteSecret(ctx context.Context, orgID platform.ID, ks ...string) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		for _, k := range ks {
+			if err := c.deleteSecret(ctx, tx, orgID, k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (c *Client) deleteSecret(ctx context.Context, tx *bolt.Tx, orgID platform.ID, k string) error {
+	key, err := encodeSecretKey(orgID, k)
+	if err != nil {
+		return err
+	}
+
+	return tx.Bucket(secretBucket).Delete(key)
+}
+
 func encodeSecretKey(orgID platform.ID, k string) ([]byte, error) {
 	buf, err := orgID.Encode()
 	if err != nil {
@@ -174,18 +202,90 @@ func decodeSecretKey(key []byte) (platform.ID, string, error) {
 	return id, k, nil
 }
 
-func decodeSecretValue(val []byte) (string, error) {
-	// store the secret value base64 encoded so that it's marginally better than plaintext
+// decodeSecretValue returns the secret value stored at key. Values are
+// either sealed with the secret keyring, or base64 encoded if they were
+// stored without a keyring.
+func (c *Client) decodeSecretValue(key, val []byte) (string, error) {
+	if keyring.IsSealed(val) {
+		if c.SecretKeyring == nil {
+			return "", fmt.Errorf("secret is encrypted but no secret key is configured")
+		}
+		v, err := c.SecretKeyring.Open(val, key)
+		if err != nil {
+			return "", err
+		}
+		return string(v), nil
+	}
+
 	v := make([]byte, base64.StdEncoding.DecodedLen(len(val)))
-	if _, err := base64.StdEncoding.Decode(v, val); err != nil {
+	n, err := base64.StdEncoding.Decode(v, val)
+	if err != nil {
 		return "", err
 	}
 
-	return string(v), nil
+	return string(v[:n]), nil
+}
+
+// encodeSecretValue returns the secret value v to store at key. The value
+// is sealed with the secret keyring, which also authenticates the key so
+// sealed values can not be moved to other keys.
+func (c *Client) encodeSecretValue(key []byte, v string) ([]byte, error) {
+	if c.SecretKeyring == nil {
+		// store the secret value base64 encoded so that it's marginally better than plaintext
+		val := make([]byte, base64.StdEncoding.EncodedLen(len(v)))
+		base64.StdEncoding.Encode(val, []byte(v))
+		return val, nil
+	}
+	return c.SecretKeyring.Seal([]byte(v), key)
 }
 
-func encodeSecretValue(v string) []byte {
-	val := make([]byte, base64.StdEncoding.EncodedLen(len(v)))
-	base64.StdEncoding.Encode(val, []byte(v))
-	return val
+// ReencryptSecrets seals every secret value that is base64 encoded or
+// sealed with an old key with the primary key of the secret keyring. It
+// returns the number of secrets that were sealed again.
+func (c *Client) ReencryptSecrets(ctx context.Context) (int, error) {
+	if c.SecretKeyring == nil {
+		return 0, fmt.Errorf("no secret key is configured")
+	}
+
+	var n int
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(secretBucket)
+
+		type secret struct {
+			key []byte
+			val string
+		}
+		var secrets []secret
+		err := b.ForEach(func(k, val []byte) error {
+			if c.SecretKeyring.SealedWithPrimary(val) {
+				return nil
+			}
+			v, err := c.decodeSecretValue(k, val)
+			if err != nil {
+				return fmt.Errorf("unable to decode secret %q: %v", k[platform.IDLength:], err)
+			}
+			secrets = append(secrets, secret{key: append([]byte(nil), k...), val: v})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Keys must not be modified while iterating over the bucket.
+		for _, s := range secrets {
+			val, err := c.encodeSecretValue(s.key, s.val)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(s.key, val); err != nil {
+				return err
+			}
+		}
+		n = len(secrets)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
 }
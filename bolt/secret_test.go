@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/keyring"
 	platformtesting "github.com/influxdata/platform/testing"
+	bbolt "go.etcd.io/bbolt"
 )
 
 func initSecretService(f platformtesting.SecretServiceFields, t *testing.T) (platform.SecretService, func()) {
@@ -29,3 +32,112 @@ func initSecretService(f platformtesting.SecretServiceFields, t *testing.T) (pla
 func TestSecretService(t *testing.T) {
 	platformtesting.SecretService(initSecretService, t)
 }
+
+func newTestKeyring(t *testing.T, ids ...string) *keyring.Keyring {
+	t.Helper()
+	keys := make([]keyring.Key, len(ids))
+	for i, id := range ids {
+		k, err := keyring.GenerateKey(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = k
+	}
+	r, err := keyring.New(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func initEncryptedSecretService(f platformtesting.SecretServiceFields, t *testing.T) (platform.SecretService, func()) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	c.SecretKeyring = newTestKeyring(t, "k1")
+	ctx := context.TODO()
+	for _, s := range f.Secrets {
+		for k, v := range s.Env {
+			if err := c.PutSecret(ctx, s.OrganizationID, k, v); err != nil {
+				t.Fatalf("failed to populate secrets")
+			}
+		}
+	}
+	return c, func() {
+		defer closeFn()
+	}
+}
+
+func TestSecretService_Encrypted(t *testing.T) {
+	platformtesting.SecretService(initEncryptedSecretService, t)
+}
+
+// rawSecretValues returns the stored secret values of orgID by key.
+func rawSecretValues(t *testing.T, c *bolt.Client, orgID platform.ID) map[string]string {
+	t.Helper()
+	prefix, err := orgID.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals := make(map[string]string)
+	err = c.DB().View(func(tx *bbolt.Tx) error {
+		return tx.Bucket([]byte("secretsv1")).ForEach(func(k, v []byte) error {
+			if len(k) > len(prefix) && string(k[:len(prefix)]) == string(prefix) {
+				vals[string(k[len(prefix):])] = string(v)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vals
+}
+
+func TestClient_ReencryptSecrets(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	orgID := platform.ID(1)
+	if err := c.PutSecret(ctx, orgID, "legacy", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+
+	c.SecretKeyring = newTestKeyring(t, "k1")
+	if err := c.PutSecret(ctx, orgID, "sealed", "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := c.ReencryptSecrets(ctx); err != nil {
+		t.Fatal(err)
+	} else if n != 1 {
+		t.Fatalf("re-encrypted %d secrets, exp 1", n)
+	}
+
+	for k, v := range rawSecretValues(t, c, orgID) {
+		if !c.SecretKeyring.SealedWithPrimary([]byte(v)) {
+			t.Errorf("secret %q is not sealed with the primary key: %q", k, v)
+		}
+	}
+
+	want := map[string]string{"legacy": "hunter2", "sealed": "correct horse"}
+	for k, exp := range want {
+		got, err := c.LoadSecret(ctx, orgID, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != exp {
+			t.Errorf("secret %q is %q, exp %q", k, got, exp)
+		}
+	}
+
+	c.SecretKeyring = nil
+	if _, err := c.LoadSecret(ctx, orgID, "legacy"); err == nil {
+		t.Error("expected loading an encrypted secret without a keyring to fail")
+	}
+}
@@ -55,9 +55,75 @@ func (c *Client) findSession(ctx context.Context, tx *bolt.Tx, key string) (*pla
 		return nil, err
 	}
 
+	ps, err := c.findSessionPermissions(ctx, tx, s.UserID)
+	if err != nil {
+		return nil, err
+	}
+	s.Permissions = ps
+
 	return s, nil
 }
 
+// findSessionPermissions returns the permissions of a session of user id,
+// which are the permissions of the active authorizations of the user and
+// those of the resources the user owns or is a member of. They are computed
+// when the session is found so that they follow changes to the user's
+// authorizations and resources.
+func (c *Client) findSessionPermissions(ctx context.Context, tx *bolt.Tx, id platform.ID) ([]platform.Permission, error) {
+	ps := []platform.Permission{}
+	if !id.Valid() {
+		return ps, nil
+	}
+
+	as, err := c.findAuthorizations(ctx, tx, platform.AuthorizationFilter{UserID: &id})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range as {
+		if a.IsActive() && a.Expired() == nil {
+			ps = append(ps, a.Permissions...)
+		}
+	}
+
+	ms, err := c.findUserResourceMappings(ctx, tx, platform.UserResourceMappingFilter{UserID: id})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range ms {
+		ps = append(ps, mappingPermissions(m)...)
+	}
+
+	return ps, nil
+}
+
+// mappingPermissions returns the permissions a user resource mapping grants.
+// Owners may read, write and delete the resource, members may only read it.
+// The permissions on an organization extend to the resources within it, and
+// owners of an organization may also create resources within it.
+func mappingPermissions(m *platform.UserResourceMapping) []platform.Permission {
+	var ps []platform.Permission
+	if m.ResourceType == platform.OrgResourceType {
+		for _, p := range platform.OrgPermissions(m.ResourceID) {
+			if m.UserType == platform.Owner || p.Action == platform.ReadAction {
+				ps = append(ps, p)
+			}
+		}
+		return ps
+	}
+
+	actions := []platform.Action{platform.ReadAction}
+	if m.UserType == platform.Owner {
+		actions = append(actions, platform.WriteAction, platform.DeleteAction)
+	}
+	for _, a := range actions {
+		ps = append(ps, platform.Permission{
+			Action:   a,
+			Resource: platform.ResourceWithID(m.ResourceType, m.ResourceID),
+		})
+	}
+	return ps
+}
+
 // PutSession puts the session at key.
 func (c *Client) PutSession(ctx context.Context, s *platform.Session) error {
 	return c.db.Update(func(tx *bolt.Tx) error {
@@ -128,12 +194,16 @@ func (c *Client) createSession(ctx context.Context, tx *bolt.Tx, user string) (*
 	s.CreatedAt = time.Now()
 	// TODO(desa): make this configurable
 	s.ExpiresAt = s.CreatedAt.Add(time.Hour)
-	// TODO(desa): not totally sure what to do here. Possibly we should have a maximal privilege permission.
-	s.Permissions = []platform.Permission{}
 
 	if err := c.putSession(ctx, tx, s); err != nil {
 		return nil, err
 	}
 
+	ps, err := c.findSessionPermissions(ctx, tx, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.Permissions = ps
+
 	return s, nil
 }
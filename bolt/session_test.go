@@ -21,6 +21,16 @@ func initSessionService(f platformtesting.SessionFields, t *testing.T) (platform
 			t.Fatalf("failed to populate users")
 		}
 	}
+	for _, a := range f.Authorizations {
+		if err := c.PutAuthorization(ctx, a); err != nil {
+			t.Fatalf("failed to populate authorizations")
+		}
+	}
+	for _, m := range f.UserResourceMappings {
+		if err := c.CreateUserResourceMapping(ctx, m); err != nil {
+			t.Fatalf("failed to populate user resource mappings")
+		}
+	}
 	for _, s := range f.Sessions {
 		if err := c.PutSession(ctx, s); err != nil {
 			t.Fatalf("failed to populate sessions")
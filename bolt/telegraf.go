@@ -185,6 +185,7 @@ func (c *Client) UpdateTelegrafConfig(ctx context.Context, id platform.ID, tc *p
 			return err
 		}
 		tc.ID = id
+		tc.OrganizationID = oldTc.OrganizationID
 		tc.Created = oldTc.Created
 		tc.LastMod = now
 		tc.LastModBy = userID
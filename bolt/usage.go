@@ -0,0 +1,213 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	bolt "go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+var (
+	usageBucket = []byte("usagev1")
+)
+
+var _ platform.UsageService = (*Client)(nil)
+var _ platform.UsageRecorder = (*Client)(nil)
+
+// usageFlushInterval is how often recorded usage is stored.
+const usageFlushInterval = 10 * time.Second
+
+// usageKey identifies the usage of a metric of a bucket during an hour.
+// Usage is stored summed by the hour.
+type usageKey struct {
+	orgID    platform.ID
+	bucketID platform.ID
+	hour     int64
+	metric   platform.UsageMetric
+}
+
+// usageRecords batches recorded usage, so that recording usage does not
+// write to the database.
+type usageRecords struct {
+	mu     sync.Mutex
+	values map[usageKey]float64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func (c *Client) initializeUsage(ctx context.Context, tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(usageBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RecordUsage adds value to the usage metric m of the bucket bucketID of the
+// organization orgID at t. Usage is stored in batches every few seconds, and
+// when the client is closed.
+func (c *Client) RecordUsage(ctx context.Context, orgID, bucketID platform.ID, m platform.UsageMetric, value float64, t time.Time) {
+	k := usageKey{
+		orgID:    orgID,
+		bucketID: bucketID,
+		hour:     t.Truncate(time.Hour).Unix(),
+		metric:   m,
+	}
+
+	c.orgUsage.mu.Lock()
+	defer c.orgUsage.mu.Unlock()
+	if c.orgUsage.values == nil {
+		c.orgUsage.values = make(map[usageKey]float64)
+	}
+	c.orgUsage.values[k] += value
+}
+
+// GetUsage returns the usage of the organization and bucket of filter
+// during its range, summed by metric. Usage is counted by the hour, so the
+// whole hours the range starts and ends in are counted.
+func (c *Client) GetUsage(ctx context.Context, filter platform.UsageFilter) (map[platform.UsageMetric]*platform.Usage, error) {
+	// Include the usage still batched.
+	if err := c.flushUsage(ctx); err != nil {
+		return nil, err
+	}
+
+	usage := make(map[platform.UsageMetric]*platform.Usage)
+	err := c.db.View(func(tx *bolt.Tx) error {
+		var prefix []byte
+		if filter.OrgID != nil {
+			prefix = encodeUsageID(*filter.OrgID)
+			if filter.BucketID != nil {
+				prefix = append(prefix, encodeUsageID(*filter.BucketID)...)
+			}
+		}
+
+		cur := tx.Bucket(usageBucket).Cursor()
+		for k, v := cur.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cur.Next() {
+			key := decodeUsageKey(k)
+			if filter.BucketID != nil && key.bucketID != *filter.BucketID {
+				continue
+			}
+			if filter.Range != nil {
+				hour := time.Unix(key.hour, 0)
+				if hour.Before(filter.Range.Start.Truncate(time.Hour)) || !hour.Before(filter.Range.Stop) {
+					continue
+				}
+			}
+
+			u, ok := usage[key.metric]
+			if !ok {
+				u = &platform.Usage{
+					OrganizationID: filter.OrgID,
+					BucketID:       filter.BucketID,
+					Type:           key.metric,
+				}
+				usage[key.metric] = u
+			}
+			u.Value += math.Float64frombits(binary.BigEndian.Uint64(v))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, &platform.Error{
+			Op:  getOp("GetUsage"),
+			Err: err,
+		}
+	}
+	return usage, nil
+}
+
+// startUsage starts storing the batched usage periodically.
+func (c *Client) startUsage() {
+	c.orgUsage.done = make(chan struct{})
+	c.orgUsage.wg.Add(1)
+	go func() {
+		defer c.orgUsage.wg.Done()
+		ticker := time.NewTicker(usageFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.flushUsage(context.Background()); err != nil {
+					c.Logger.Info("failed to store usage", zap.Error(err))
+				}
+			case <-c.orgUsage.done:
+				return
+			}
+		}
+	}()
+}
+
+// stopUsage stops the periodic storing of usage and stores the usage still
+// batched.
+func (c *Client) stopUsage() error {
+	if c.orgUsage.done == nil {
+		return nil
+	}
+	close(c.orgUsage.done)
+	c.orgUsage.wg.Wait()
+	c.orgUsage.done = nil
+	return c.flushUsage(context.Background())
+}
+
+// flushUsage adds the batched usage to the stored usage in a single
+// transaction.
+func (c *Client) flushUsage(ctx context.Context) error {
+	c.orgUsage.mu.Lock()
+	values := c.orgUsage.values
+	c.orgUsage.values = nil
+	c.orgUsage.mu.Unlock()
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(usageBucket)
+		for k, value := range values {
+			key := encodeUsageKey(k)
+			if v := b.Get(key); v != nil {
+				value += math.Float64frombits(binary.BigEndian.Uint64(v))
+			}
+
+			v := make([]byte, 8)
+			binary.BigEndian.PutUint64(v, math.Float64bits(value))
+			if err := b.Put(key, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// encodeUsageID encodes id so that it sorts in order, including the invalid id
+// of the usage of a whole organization.
+func encodeUsageID(id platform.ID) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(id))
+	return b
+}
+
+// encodeUsageKey encodes k as the organization, bucket, hour and metric, so
+// that the usage of an organization or bucket can be found by prefix.
+func encodeUsageKey(k usageKey) []byte {
+	key := make([]byte, 24, 24+len(k.metric))
+	binary.BigEndian.PutUint64(key[0:8], uint64(k.orgID))
+	binary.BigEndian.PutUint64(key[8:16], uint64(k.bucketID))
+	binary.BigEndian.PutUint64(key[16:24], uint64(k.hour))
+	return append(key, k.metric...)
+}
+
+func decodeUsageKey(key []byte) usageKey {
+	return usageKey{
+		orgID:    platform.ID(binary.BigEndian.Uint64(key[0:8])),
+		bucketID: platform.ID(binary.BigEndian.Uint64(key[8:16])),
+		hour:     int64(binary.BigEndian.Uint64(key[16:24])),
+		metric:   platform.UsageMetric(key[24:]),
+	}
+}
@@ -0,0 +1,87 @@
+package bolt_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+func TestClient_Usage(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	ctx := context.Background()
+	hour := time.Date(2018, 11, 1, 10, 0, 0, 0, time.UTC)
+	c.RecordUsage(ctx, 1, 2, platform.UsageWriteRequestBytes, 100, hour.Add(time.Minute))
+	c.RecordUsage(ctx, 1, 3, platform.UsageWriteRequestBytes, 10, hour.Add(2*time.Minute))
+	c.RecordUsage(ctx, 1, platform.InvalidID(), platform.UsageQueryRequestCount, 1, hour)
+	c.RecordUsage(ctx, 4, 5, platform.UsageWriteRequestBytes, 1000, hour)
+
+	// Closing the client stores the batched usage, which is added to.
+	if err := c.Close(); err != nil {
+		t.Fatalf("failed to close client: %v", err)
+	}
+	if err := c.Open(ctx); err != nil {
+		t.Fatalf("failed to reopen client: %v", err)
+	}
+	c.RecordUsage(ctx, 1, 2, platform.UsageWriteRequestBytes, 50, hour.Add(2*time.Hour))
+
+	orgID, bucketID := platform.ID(1), platform.ID(2)
+	for _, tt := range []struct {
+		name   string
+		filter platform.UsageFilter
+		exp    map[platform.UsageMetric]float64
+	}{
+		{
+			name:   "organization",
+			filter: platform.UsageFilter{OrgID: &orgID},
+			exp: map[platform.UsageMetric]float64{
+				platform.UsageWriteRequestBytes: 160,
+				platform.UsageQueryRequestCount: 1,
+			},
+		},
+		{
+			name:   "bucket",
+			filter: platform.UsageFilter{OrgID: &orgID, BucketID: &bucketID},
+			exp: map[platform.UsageMetric]float64{
+				platform.UsageWriteRequestBytes: 150,
+			},
+		},
+		{
+			name: "range",
+			filter: platform.UsageFilter{
+				OrgID: &orgID,
+				Range: &platform.Timespan{Start: hour.Add(30 * time.Minute), Stop: hour.Add(time.Hour)},
+			},
+			exp: map[platform.UsageMetric]float64{
+				platform.UsageWriteRequestBytes: 110,
+				platform.UsageQueryRequestCount: 1,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			usage, err := c.GetUsage(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("failed to get usage: %v", err)
+			}
+
+			got := make(map[platform.UsageMetric]float64, len(usage))
+			for m, u := range usage {
+				got[m] = u.Value
+			}
+			if len(got) != len(tt.exp) {
+				t.Fatalf("unexpected usage %v; expected %v", got, tt.exp)
+			}
+			for m, v := range tt.exp {
+				if got[m] != v {
+					t.Errorf("unexpected usage %v of %s; expected %v", got[m], m, v)
+				}
+			}
+		})
+	}
+}
@@ -335,6 +335,9 @@ func (c *Client) deleteUser(ctx context.Context, tx *bolt.Tx, id platform.ID) er
 	if err := tx.Bucket(userUser).Delete(encodedID); err != nil {
 		return err
 	}
+	if err := c.deleteUserOAuth2Identities(ctx, tx, id); err != nil {
+		return err
+	}
 	return c.deleteUserResourceMappings(ctx, tx, platform.UserResourceMappingFilter{
 		UserID: id,
 	})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/bolt"
@@ -24,13 +25,20 @@ var authorizationCmd = &cobra.Command{
 
 // AuthorizationCreateFlags are command line args used when creating a authorization
 type AuthorizationCreateFlags struct {
-	user string
+	user        string
+	description string
+	expiresIn   time.Duration
 
 	createUserPermission bool
 	deleteUserPermission bool
 
 	readBucketPermissions  []string
 	writeBucketPermissions []string
+
+	readPermissions   []string
+	writePermissions  []string
+	createPermissions []string
+	deletePermissions []string
 }
 
 var authorizationCreateFlags AuthorizationCreateFlags
@@ -44,6 +52,8 @@ func init() {
 
 	authorizationCreateCmd.Flags().StringVarP(&authorizationCreateFlags.user, "user", "u", "", "user name (required)")
 	authorizationCreateCmd.MarkFlagRequired("user")
+	authorizationCreateCmd.Flags().StringVarP(&authorizationCreateFlags.description, "description", "d", "", "description of the authorization")
+	authorizationCreateCmd.Flags().DurationVarP(&authorizationCreateFlags.expiresIn, "expires-in", "", 0, "duration after which the authorization expires; it never expires when zero")
 
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.createUserPermission, "create-user", "", false, "grants the permission to create users")
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.deleteUserPermission, "delete-user", "", false, "grants the permission to delete users")
@@ -51,6 +61,12 @@ func init() {
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.readBucketPermissions, "read-bucket", "", []string{}, "bucket id")
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.writeBucketPermissions, "write-bucket", "", []string{}, "bucket id")
 
+	resourceUsage := "resource of the form [org/<org id>/]<type>[/<id>], where a missing id grants every resource of the type"
+	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.readPermissions, "read", "", []string{}, "grants read access to a "+resourceUsage)
+	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.writePermissions, "write", "", []string{}, "grants write access to a "+resourceUsage)
+	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.createPermissions, "create", "", []string{}, "grants create access to a "+resourceUsage)
+	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.deletePermissions, "delete", "", []string{}, "grants delete access to a "+resourceUsage)
+
 	authorizationCmd.AddCommand(authorizationCreateCmd)
 }
 
@@ -80,10 +96,34 @@ func authorizationCreateF(cmd *cobra.Command, args []string) {
 		permissions = append(permissions, platform.ReadBucketPermission(id))
 	}
 
+	for _, f := range []struct {
+		action    platform.Action
+		resources []string
+	}{
+		{platform.ReadAction, authorizationCreateFlags.readPermissions},
+		{platform.WriteAction, authorizationCreateFlags.writePermissions},
+		{platform.CreateAction, authorizationCreateFlags.createPermissions},
+		{platform.DeleteAction, authorizationCreateFlags.deletePermissions},
+	} {
+		for _, p := range f.resources {
+			r, err := platform.ParseResource(p)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			permissions = append(permissions, platform.Permission{Action: f.action, Resource: r})
+		}
+	}
+
 	authorization := &platform.Authorization{
 		User:        authorizationCreateFlags.user,
+		Description: authorizationCreateFlags.description,
 		Permissions: permissions,
 	}
+	if authorizationCreateFlags.expiresIn > 0 {
+		expiresAt := time.Now().Add(authorizationCreateFlags.expiresIn)
+		authorization.ExpiresAt = &expiresAt
+	}
 
 	s, err := newAuthorizationService(flags)
 	if err != nil {
@@ -100,6 +140,7 @@ func authorizationCreateF(cmd *cobra.Command, args []string) {
 	w.WriteHeaders(
 		"ID",
 		"Token",
+		"Description",
 		"Status",
 		"User",
 		"UserID",
@@ -114,6 +155,7 @@ func authorizationCreateF(cmd *cobra.Command, args []string) {
 	w.Write(map[string]interface{}{
 		"ID":          authorization.ID.String(),
 		"Token":       authorization.Token,
+		"Description": authorization.Description,
 		"Status":      authorization.Status,
 		"User":        authorization.User,
 		"UserID":      authorization.UserID.String(),
@@ -202,7 +244,7 @@ func authorizationFindF(cmd *cobra.Command, args []string) {
 	w := internal.NewTabWriter(os.Stdout)
 	w.WriteHeaders(
 		"ID",
-		"Token",
+		"Description",
 		"Status",
 		"User",
 		"UserID",
@@ -217,7 +259,7 @@ func authorizationFindF(cmd *cobra.Command, args []string) {
 
 		w.Write(map[string]interface{}{
 			"ID":          a.ID,
-			"Token":       a.Token,
+			"Description": a.Description,
 			"Status":      a.Status,
 			"User":        a.User,
 			"UserID":      a.UserID.String(),
@@ -275,7 +317,7 @@ func authorizationDeleteF(cmd *cobra.Command, args []string) {
 	w := internal.NewTabWriter(os.Stdout)
 	w.WriteHeaders(
 		"ID",
-		"Token",
+		"Description",
 		"User",
 		"UserID",
 		"Permissions",
@@ -289,7 +331,7 @@ func authorizationDeleteF(cmd *cobra.Command, args []string) {
 
 	w.Write(map[string]interface{}{
 		"ID":          a.ID.String(),
-		"Token":       a.Token,
+		"Description": a.Description,
 		"User":        a.User,
 		"UserID":      a.UserID.String(),
 		"Permissions": ps,
@@ -346,7 +388,7 @@ func authorizationActiveF(cmd *cobra.Command, args []string) {
 	w := internal.NewTabWriter(os.Stdout)
 	w.WriteHeaders(
 		"ID",
-		"Token",
+		"Description",
 		"Status",
 		"User",
 		"UserID",
@@ -360,7 +402,7 @@ func authorizationActiveF(cmd *cobra.Command, args []string) {
 
 	w.Write(map[string]interface{}{
 		"ID":          a.ID.String(),
-		"Token":       a.Token,
+		"Description": a.Description,
 		"Status":      a.Status,
 		"User":        a.User,
 		"UserID":      a.UserID.String(),
@@ -417,7 +459,7 @@ func authorizationInactiveF(cmd *cobra.Command, args []string) {
 	w := internal.NewTabWriter(os.Stdout)
 	w.WriteHeaders(
 		"ID",
-		"Token",
+		"Description",
 		"Status",
 		"User",
 		"UserID",
@@ -431,7 +473,7 @@ func authorizationInactiveF(cmd *cobra.Command, args []string) {
 
 	w.Write(map[string]interface{}{
 		"ID":          a.ID.String(),
-		"Token":       a.Token,
+		"Description": a.Description,
 		"Status":      a.Status,
 		"User":        a.User,
 		"UserID":      a.UserID.String(),
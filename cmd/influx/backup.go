@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/influxdata/platform/backup"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/kit/signals"
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the metadata and time-series data of influxdb",
+	Long: `Back up the metadata database and the storage engine files of a running
+		influxd into a directory. With --since, only the TSM files modified since
+		the previous backup are included, making an incremental backup.`,
+	Args: cobra.NoArgs,
+	RunE: backupF,
+}
+
+var backupFlags struct {
+	Path  string
+	Since string
+}
+
+func init() {
+	backupCmd.PersistentFlags().StringVar(&backupFlags.Path, "path", "", "directory to write the backup to (required)")
+	backupCmd.PersistentFlags().StringVar(&backupFlags.Since, "since", "", "time of the previous backup, in RFC3339 format, to make an incremental backup")
+	backupCmd.MarkPersistentFlagRequired("path")
+}
+
+func backupF(cmd *cobra.Command, args []string) error {
+	ctx := signals.WithStandardSignals(context.Background())
+
+	var since time.Time
+	if backupFlags.Since != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, backupFlags.Since); err != nil {
+			return fmt.Errorf("invalid since: %v", err)
+		}
+	}
+
+	s := &http.BackupService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(s.Backup(ctx, pw, since))
+	}()
+
+	m, err := backup.Extract(pr, backupFlags.Path)
+	pr.CloseWithError(err)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backed up %d buckets to %s at %s\n", len(m.Buckets), backupFlags.Path, m.CreatedAt.Format(time.RFC3339Nano))
+	return nil
+}
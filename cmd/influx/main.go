@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/influxdata/platform/http"
 	"github.com/influxdata/platform/internal/fs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -16,17 +17,23 @@ func main() {
 }
 
 var influxCmd = &cobra.Command{
-	Use:   "influx",
-	Short: "Influx Client",
-	Run:   influxF,
+	Use:               "influx",
+	Short:             "Influx Client",
+	Run:               influxF,
+	PersistentPreRunE: configureTLS,
 }
 
 func init() {
 	influxCmd.AddCommand(authorizationCmd)
+	influxCmd.AddCommand(backupCmd)
 	influxCmd.AddCommand(bucketCmd)
+	influxCmd.AddCommand(deleteCmd)
 	influxCmd.AddCommand(organizationCmd)
 	influxCmd.AddCommand(queryCmd)
 	influxCmd.AddCommand(replCmd)
+	influxCmd.AddCommand(restoreCmd)
+	influxCmd.AddCommand(scraperCmd)
+	influxCmd.AddCommand(secretCmd)
 	influxCmd.AddCommand(setupCmd)
 	influxCmd.AddCommand(taskCmd)
 	influxCmd.AddCommand(userCmd)
@@ -35,9 +42,13 @@ func init() {
 
 // Flags contains all the CLI flag values for influx.
 type Flags struct {
-	token string
-	host  string
-	local bool
+	token      string
+	host       string
+	local      bool
+	tlsCA      string
+	tlsCert    string
+	tlsKey     string
+	skipVerify bool
 }
 
 var flags Flags
@@ -80,6 +91,40 @@ func init() {
 	}
 
 	influxCmd.PersistentFlags().BoolVar(&flags.local, "local", false, "Run commands locally against the filesystem")
+
+	influxCmd.PersistentFlags().StringVar(&flags.tlsCA, "tls-ca", "", "path to the certificate authorities verifying the certificate of Influx")
+	viper.BindEnv("TLS_CA")
+	if h := viper.GetString("TLS_CA"); h != "" {
+		flags.tlsCA = h
+	}
+
+	influxCmd.PersistentFlags().StringVar(&flags.tlsCert, "tls-cert", "", "path to the client certificate for Influx requiring one")
+	viper.BindEnv("TLS_CERT")
+	if h := viper.GetString("TLS_CERT"); h != "" {
+		flags.tlsCert = h
+	}
+
+	influxCmd.PersistentFlags().StringVar(&flags.tlsKey, "tls-key", "", "path to the private key of the client certificate")
+	viper.BindEnv("TLS_KEY")
+	if h := viper.GetString("TLS_KEY"); h != "" {
+		flags.tlsKey = h
+	}
+
+	influxCmd.PersistentFlags().BoolVar(&flags.skipVerify, "skip-verify", false, "skip the verification of the certificate of Influx")
+	viper.BindEnv("SKIP_VERIFY")
+	if viper.GetBool("SKIP_VERIFY") {
+		flags.skipVerify = true
+	}
+}
+
+// configureTLS configures the TLS of the clients of every command.
+func configureTLS(cmd *cobra.Command, args []string) error {
+	return http.SetClientTLSConfig(http.ClientTLSConfig{
+		CAFile:             flags.tlsCA,
+		CertFile:           flags.tlsCert,
+		KeyFile:            flags.tlsKey,
+		InsecureSkipVerify: flags.skipVerify,
+	})
 }
 
 func influxF(cmd *cobra.Command, args []string) {
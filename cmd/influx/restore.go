@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/backup"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/kit/signals"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/write"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a backup of influxdb",
+	Long: `Restore a backup made with influx backup. With --full, the metadata
+		database and storage engine files of a stopped influxd are replaced by
+		those of the backup; incremental backups are restored after the full
+		backup they continue from, in the order they were made. With --bucket,
+		the points of a single bucket are written to a bucket of a running
+		influxd, which is created if it doesn't exist, possibly in another
+		organization.`,
+	Args: cobra.NoArgs,
+	RunE: restoreF,
+}
+
+var restoreFlags struct {
+	Path       string
+	Full       bool
+	BoltPath   string
+	EnginePath string
+	Bucket     string
+	Org        string
+	NewBucket  string
+	NewOrg     string
+}
+
+func init() {
+	restoreCmd.PersistentFlags().StringVar(&restoreFlags.Path, "path", "", "directory of the backup to restore (required)")
+	restoreCmd.PersistentFlags().BoolVar(&restoreFlags.Full, "full", false, "restore the whole instance; influxd must be stopped")
+	restoreCmd.PersistentFlags().StringVar(&restoreFlags.BoltPath, "bolt-path", "", "path to the bolt database of the instance to restore")
+	restoreCmd.PersistentFlags().StringVar(&restoreFlags.EnginePath, "engine-path", "", "path to the engine files of the instance to restore")
+	restoreCmd.PersistentFlags().StringVarP(&restoreFlags.Bucket, "bucket", "b", "", "name of the bucket to restore")
+	restoreCmd.PersistentFlags().StringVarP(&restoreFlags.Org, "org", "o", "", "name of the organization that owned the bucket to restore")
+	restoreCmd.PersistentFlags().StringVar(&restoreFlags.NewBucket, "new-bucket", "", "name of the bucket to restore into; defaults to the name of the restored bucket")
+	restoreCmd.PersistentFlags().StringVar(&restoreFlags.NewOrg, "new-org", "", "name of the organization to restore into; defaults to the organization of the restored bucket")
+	restoreCmd.MarkPersistentFlagRequired("path")
+}
+
+func restoreF(cmd *cobra.Command, args []string) error {
+	if restoreFlags.Full == (restoreFlags.Bucket != "") {
+		cmd.Usage()
+		return fmt.Errorf("Please specify one of full or bucket")
+	}
+
+	if restoreFlags.Full {
+		if restoreFlags.BoltPath == "" || restoreFlags.EnginePath == "" {
+			cmd.Usage()
+			return fmt.Errorf("Please specify the bolt-path and engine-path of the instance")
+		}
+		if err := backup.Restore(restoreFlags.Path, restoreFlags.BoltPath, restoreFlags.EnginePath); err != nil {
+			return err
+		}
+		fmt.Printf("Restored %s\n", restoreFlags.Path)
+		return nil
+	}
+
+	return restoreBucket()
+}
+
+// restoreBucket writes the points of the restored bucket to its new bucket.
+func restoreBucket() error {
+	ctx := signals.WithStandardSignals(context.Background())
+
+	m, err := backup.ReadManifest(restoreFlags.Path)
+	if err != nil {
+		return err
+	}
+	src, err := m.FindBucket(restoreFlags.Org, restoreFlags.Bucket)
+	if err != nil {
+		return err
+	}
+
+	dst := &platform.Bucket{
+		Name:            src.Name,
+		Organization:    src.Organization,
+		RetentionPeriod: src.RetentionPeriod,
+	}
+	if restoreFlags.NewBucket != "" {
+		dst.Name = restoreFlags.NewBucket
+	}
+	if restoreFlags.NewOrg != "" {
+		dst.Organization = restoreFlags.NewOrg
+	}
+
+	orgSvc := &http.OrganizationService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+	o, err := orgSvc.FindOrganization(ctx, platform.OrganizationFilter{Name: &dst.Organization})
+	if err != nil {
+		return err
+	}
+	dst.OrganizationID = o.ID
+
+	bs := &http.BucketService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+	buckets, n, err := bs.FindBuckets(ctx, platform.BucketFilter{
+		Name:           &dst.Name,
+		OrganizationID: &dst.OrganizationID,
+	})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		dst = buckets[0]
+	} else if err := bs.CreateBucket(ctx, dst); err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(backup.ReadBucket(restoreFlags.Path, src.OrganizationID, src.ID, func(p models.Point) error {
+			_, err := io.WriteString(pw, p.String()+"\n")
+			return err
+		}))
+	}()
+
+	s := write.Batcher{
+		Service: &http.WriteService{
+			Addr:  flags.host,
+			Token: flags.token,
+		},
+	}
+	err = s.Write(ctx, dst.OrganizationID, dst.ID, pr)
+	pr.CloseWithError(err)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored bucket %s of organization %s into bucket %s of organization %s\n", src.Name, src.Organization, dst.Name, dst.Organization)
+	return nil
+}
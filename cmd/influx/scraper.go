@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/cmd/influx/internal"
+	"github.com/influxdata/platform/http"
+	"github.com/influxdata/platform/internal/fs"
+	"github.com/spf13/cobra"
+)
+
+// Scraper Command
+var scraperCmd = &cobra.Command{
+	Use:   "scraper",
+	Short: "scraper target related commands",
+	Run:   scraperF,
+}
+
+func scraperF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+func newScraperService(f Flags) (platform.ScraperTargetStoreService, error) {
+	if flags.local {
+		boltFile, err := fs.BoltFile()
+		if err != nil {
+			return nil, err
+		}
+		c := bolt.NewClient()
+		c.Path = boltFile
+		if err := c.Open(context.Background()); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+	return &http.ScraperService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}, nil
+}
+
+func writeScraperTargets(targets []platform.ScraperTarget, deleted bool) {
+	w := internal.NewTabWriter(os.Stdout)
+	headers := []string{
+		"ID",
+		"Name",
+		"Type",
+		"URL",
+		"Organization",
+		"Bucket",
+		"Interval",
+		"Timeout",
+	}
+	if deleted {
+		headers = append(headers, "Deleted")
+	}
+	w.WriteHeaders(headers...)
+	for _, t := range targets {
+		row := map[string]interface{}{
+			"ID":           t.ID.String(),
+			"Name":         t.Name,
+			"Type":         t.Type,
+			"URL":          t.URL,
+			"Organization": t.OrgName,
+			"Bucket":       t.BucketName,
+			"Interval":     t.Interval,
+			"Timeout":      t.Timeout,
+		}
+		if deleted {
+			row["Deleted"] = true
+		}
+		w.Write(row)
+	}
+	w.Flush()
+}
+
+// ScraperCreateFlags define the Create Command
+type ScraperCreateFlags struct {
+	name        string
+	scraperType string
+	url         string
+	org         string
+	bucket      string
+	interval    time.Duration
+	timeout     time.Duration
+}
+
+var scraperCreateFlags ScraperCreateFlags
+
+func init() {
+	scraperCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create scraper target",
+		Run:   scraperCreateF,
+	}
+
+	scraperCreateCmd.Flags().StringVarP(&scraperCreateFlags.name, "name", "n", "", "name of the scraper target")
+	scraperCreateCmd.Flags().StringVarP(&scraperCreateFlags.scraperType, "type", "", platform.PrometheusScraperType, "type of the scraper target: prometheus, influx, json or openmetrics")
+	scraperCreateCmd.Flags().StringVarP(&scraperCreateFlags.url, "url", "u", "", "url of the metrics endpoint to scrape")
+	scraperCreateCmd.Flags().StringVarP(&scraperCreateFlags.org, "org", "o", "", "name of the organization the metrics are written to")
+	scraperCreateCmd.Flags().StringVarP(&scraperCreateFlags.bucket, "bucket", "b", "", "name of the bucket the metrics are written to")
+	scraperCreateCmd.Flags().DurationVarP(&scraperCreateFlags.interval, "interval", "", 0, "time between scrapes; defaults to the server's scrape interval")
+	scraperCreateCmd.Flags().DurationVarP(&scraperCreateFlags.timeout, "timeout", "", 0, "maximum duration of a scrape; defaults to the server's scrape timeout")
+	scraperCreateCmd.MarkFlagRequired("url")
+	scraperCreateCmd.MarkFlagRequired("org")
+	scraperCreateCmd.MarkFlagRequired("bucket")
+
+	scraperCmd.AddCommand(scraperCreateCmd)
+}
+
+func scraperCreateF(cmd *cobra.Command, args []string) {
+	if !platform.ValidScraperType(scraperCreateFlags.scraperType) {
+		fmt.Printf("unsupported scraper type: %s\n", scraperCreateFlags.scraperType)
+		os.Exit(1)
+	}
+
+	s, err := newScraperService(flags)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	t := &platform.ScraperTarget{
+		Name:       scraperCreateFlags.name,
+		Type:       platform.ScraperType(scraperCreateFlags.scraperType),
+		URL:        scraperCreateFlags.url,
+		OrgName:    scraperCreateFlags.org,
+		BucketName: scraperCreateFlags.bucket,
+		Interval:   scraperCreateFlags.interval,
+		Timeout:    scraperCreateFlags.timeout,
+	}
+
+	if err := s.AddTarget(context.Background(), t); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeScraperTargets([]platform.ScraperTarget{*t}, false)
+}
+
+// ScraperFindFlags define the Find Command
+type ScraperFindFlags struct {
+	id string
+}
+
+var scraperFindFlags ScraperFindFlags
+
+func init() {
+	scraperFindCmd := &cobra.Command{
+		Use:   "find",
+		Short: "Find scraper targets",
+		Run:   scraperFindF,
+	}
+
+	scraperFindCmd.Flags().StringVarP(&scraperFindFlags.id, "id", "i", "", "scraper target ID")
+
+	scraperCmd.AddCommand(scraperFindCmd)
+}
+
+func scraperFindF(cmd *cobra.Command, args []string) {
+	s, err := newScraperService(flags)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	if scraperFindFlags.id != "" {
+		var id platform.ID
+		if err := id.DecodeFromString(scraperFindFlags.id); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		t, err := s.GetTargetByID(ctx, id)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		writeScraperTargets([]platform.ScraperTarget{*t}, false)
+		return
+	}
+
+	targets, err := s.ListTargets(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeScraperTargets(targets, false)
+}
+
+// ScraperUpdateFlags define the Update Command
+type ScraperUpdateFlags struct {
+	id       string
+	name     string
+	url      string
+	org      string
+	bucket   string
+	interval time.Duration
+	timeout  time.Duration
+}
+
+var scraperUpdateFlags ScraperUpdateFlags
+
+func init() {
+	scraperUpdateCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update scraper target",
+		Run:   scraperUpdateF,
+	}
+
+	scraperUpdateCmd.Flags().StringVarP(&scraperUpdateFlags.id, "id", "i", "", "scraper target ID (required)")
+	scraperUpdateCmd.Flags().StringVarP(&scraperUpdateFlags.name, "name", "n", "", "new scraper target name")
+	scraperUpdateCmd.Flags().StringVarP(&scraperUpdateFlags.url, "url", "u", "", "new url of the metrics endpoint to scrape")
+	scraperUpdateCmd.Flags().StringVarP(&scraperUpdateFlags.org, "org", "o", "", "new name of the organization the metrics are written to")
+	scraperUpdateCmd.Flags().StringVarP(&scraperUpdateFlags.bucket, "bucket", "b", "", "new name of the bucket the metrics are written to")
+	scraperUpdateCmd.Flags().DurationVarP(&scraperUpdateFlags.interval, "interval", "", 0, "new time between scrapes")
+	scraperUpdateCmd.Flags().DurationVarP(&scraperUpdateFlags.timeout, "timeout", "", 0, "new maximum duration of a scrape")
+	scraperUpdateCmd.MarkFlagRequired("id")
+
+	scraperCmd.AddCommand(scraperUpdateCmd)
+}
+
+func scraperUpdateF(cmd *cobra.Command, args []string) {
+	s, err := newScraperService(flags)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(scraperUpdateFlags.id); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	t, err := s.GetTargetByID(ctx, id)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if scraperUpdateFlags.name != "" {
+		t.Name = scraperUpdateFlags.name
+	}
+	if scraperUpdateFlags.url != "" {
+		t.URL = scraperUpdateFlags.url
+	}
+	if scraperUpdateFlags.org != "" || scraperUpdateFlags.bucket != "" {
+		// The bucket is found again by its names.
+		t.OrgID, t.BucketID = platform.InvalidID(), platform.InvalidID()
+	}
+	if scraperUpdateFlags.org != "" {
+		t.OrgName = scraperUpdateFlags.org
+	}
+	if scraperUpdateFlags.bucket != "" {
+		t.BucketName = scraperUpdateFlags.bucket
+	}
+	if scraperUpdateFlags.interval != 0 {
+		t.Interval = scraperUpdateFlags.interval
+	}
+	if scraperUpdateFlags.timeout != 0 {
+		t.Timeout = scraperUpdateFlags.timeout
+	}
+
+	t, err = s.UpdateTarget(ctx, t)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeScraperTargets([]platform.ScraperTarget{*t}, false)
+}
+
+// ScraperDeleteFlags define the Delete command
+type ScraperDeleteFlags struct {
+	id string
+}
+
+var scraperDeleteFlags ScraperDeleteFlags
+
+func init() {
+	scraperDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete scraper target",
+		Run:   scraperDeleteF,
+	}
+
+	scraperDeleteCmd.Flags().StringVarP(&scraperDeleteFlags.id, "id", "i", "", "scraper target id (required)")
+	scraperDeleteCmd.MarkFlagRequired("id")
+
+	scraperCmd.AddCommand(scraperDeleteCmd)
+}
+
+func scraperDeleteF(cmd *cobra.Command, args []string) {
+	s, err := newScraperService(flags)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(scraperDeleteFlags.id); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	t, err := s.GetTargetByID(ctx, id)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := s.RemoveTarget(ctx, id); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeScraperTargets([]platform.ScraperTarget{*t}, true)
+}
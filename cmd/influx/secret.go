@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/cmd/influx/internal"
+	"github.com/influxdata/platform/http"
+	"github.com/spf13/cobra"
+	"github.com/tcnksm/go-input"
+)
+
+// Secret Command
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Secret management commands",
+	Run:   secretF,
+}
+
+func secretF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+// SecretOrgFlags select the organization owning the secrets
+type SecretOrgFlags struct {
+	orgID string
+	org   string
+}
+
+func (f *SecretOrgFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.orgID, "org-id", "", "id of the organization that owns the secrets")
+	cmd.Flags().StringVarP(&f.org, "org", "o", "", "name of the organization that owns the secrets")
+}
+
+// organizationID returns the ID of the organization selected by the flags.
+func (f *SecretOrgFlags) organizationID(ctx context.Context) (platform.ID, error) {
+	if (f.org == "") == (f.orgID == "") {
+		return platform.InvalidID(), fmt.Errorf("please specify one of org or org-id")
+	}
+
+	if f.orgID != "" {
+		var id platform.ID
+		if err := id.DecodeFromString(f.orgID); err != nil {
+			return platform.InvalidID(), err
+		}
+		return id, nil
+	}
+
+	s := &http.OrganizationService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+	o, err := s.FindOrganization(ctx, platform.OrganizationFilter{Name: &f.org})
+	if err != nil {
+		return platform.InvalidID(), err
+	}
+	return o.ID, nil
+}
+
+func newSecretService(f Flags) *http.SecretService {
+	return &http.SecretService{
+		Addr:  f.host,
+		Token: f.token,
+	}
+}
+
+func writeSecretKeys(orgID platform.ID, keys []string, deleted bool) {
+	w := internal.NewTabWriter(os.Stdout)
+	headers := []string{
+		"Key",
+		"OrganizationID",
+	}
+	if deleted {
+		headers = append(headers, "Deleted")
+	}
+	w.WriteHeaders(headers...)
+	for _, k := range keys {
+		row := map[string]interface{}{
+			"Key":            k,
+			"OrganizationID": orgID.String(),
+		}
+		if deleted {
+			row["Deleted"] = true
+		}
+		w.Write(row)
+	}
+	w.Flush()
+}
+
+var secretFindFlags SecretOrgFlags
+
+func init() {
+	secretFindCmd := &cobra.Command{
+		Use:   "find",
+		Short: "List the keys of the secrets of an organization",
+		Run:   secretFindF,
+	}
+
+	secretFindFlags.register(secretFindCmd)
+
+	secretCmd.AddCommand(secretFindCmd)
+}
+
+func secretFindF(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	orgID, err := secretFindFlags.organizationID(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	keys, err := newSecretService(flags).GetSecretKeys(ctx, orgID)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeSecretKeys(orgID, keys, false)
+}
+
+// SecretUpdateFlags define the Update Command
+type SecretUpdateFlags struct {
+	SecretOrgFlags
+	key   string
+	value string
+}
+
+var secretUpdateFlags SecretUpdateFlags
+
+func init() {
+	secretUpdateCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Add or update a secret",
+		Long: `Add or update a secret of an organization. When no value is given,
+		it is read from the terminal without being echoed.`,
+		Run: secretUpdateF,
+	}
+
+	secretUpdateFlags.register(secretUpdateCmd)
+	secretUpdateCmd.Flags().StringVarP(&secretUpdateFlags.key, "key", "k", "", "key of the secret (required)")
+	secretUpdateCmd.Flags().StringVarP(&secretUpdateFlags.value, "value", "v", "", "value of the secret")
+	secretUpdateCmd.MarkFlagRequired("key")
+
+	secretCmd.AddCommand(secretUpdateCmd)
+}
+
+func secretUpdateF(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	orgID, err := secretUpdateFlags.organizationID(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	value := secretUpdateFlags.value
+	if value == "" {
+		ui := &input.UI{
+			Writer: os.Stdout,
+			Reader: os.Stdin,
+		}
+		value, err = ui.Ask(promptWithColor("Please type the secret value", colorCyan), &input.Options{
+			Required:  true,
+			HideOrder: true,
+			Hide:      true,
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	if err := newSecretService(flags).PutSecret(ctx, orgID, secretUpdateFlags.key, value); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeSecretKeys(orgID, []string{secretUpdateFlags.key}, false)
+}
+
+// SecretDeleteFlags define the Delete command
+type SecretDeleteFlags struct {
+	SecretOrgFlags
+	keys []string
+}
+
+var secretDeleteFlags SecretDeleteFlags
+
+func init() {
+	secretDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete secrets",
+		Run:   secretDeleteF,
+	}
+
+	secretDeleteFlags.register(secretDeleteCmd)
+	secretDeleteCmd.Flags().StringSliceVarP(&secretDeleteFlags.keys, "key", "k", nil, "keys of the secrets to delete (required)")
+	secretDeleteCmd.MarkFlagRequired("key")
+
+	secretCmd.AddCommand(secretDeleteCmd)
+}
+
+func secretDeleteF(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	orgID, err := secretDeleteFlags.organizationID(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := newSecretService(flags).DeleteSecret(ctx, orgID, secretDeleteFlags.keys...); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeSecretKeys(orgID, secretDeleteFlags.keys, true)
+}
@@ -579,6 +579,190 @@ func taskRunFindF(cmd *cobra.Command, args []string) {
 	w.Flush()
 }
 
+type RunBackfillFlags struct {
+	taskID       string
+	start, end   string
+	scheduledFor string
+}
+
+var runBackfillFlags RunBackfillFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "run a task for every schedule in a time range",
+		Run:   runBackfillF,
+	}
+
+	cmd.Flags().StringVarP(&runBackfillFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&runBackfillFlags.start, "start", "", "", "earliest schedule to run the task for, RFC3339")
+	cmd.Flags().StringVarP(&runBackfillFlags.end, "end", "", "", "latest schedule to run the task for, RFC3339")
+	cmd.Flags().StringVarP(&runBackfillFlags.scheduledFor, "scheduled-for", "", "", "single schedule to run the task for, RFC3339")
+	cmd.MarkFlagRequired("task-id")
+
+	cmd.AddCommand(newRunBackfillFindCmd(), newRunBackfillCancelCmd())
+	runCmd.AddCommand(cmd)
+}
+
+func runBackfillF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(runBackfillFlags.taskID); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	start, end := runBackfillFlags.start, runBackfillFlags.end
+	if runBackfillFlags.scheduledFor != "" {
+		if start != "" || end != "" {
+			fmt.Println("must specify either start and end, or scheduled-for")
+			os.Exit(1)
+		}
+		start, end = runBackfillFlags.scheduledFor, runBackfillFlags.scheduledFor
+	} else if start == "" || end == "" {
+		fmt.Println("must specify either start and end, or scheduled-for")
+		os.Exit(1)
+	}
+
+	st, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	et, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	mr, err := s.ManuallyRunTimeRange(context.Background(), taskID, st.Unix(), et.Unix(), time.Now().Unix())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeManualRuns([]*platform.ManualRun{mr})
+}
+
+type RunBackfillFindFlags struct {
+	taskID string
+}
+
+var runBackfillFindFlags RunBackfillFindFlags
+
+func newRunBackfillFindCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "find",
+		Short: "find the backfills of a task that still have runs to be scheduled",
+		Run:   runBackfillFindF,
+	}
+
+	cmd.Flags().StringVarP(&runBackfillFindFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.MarkFlagRequired("task-id")
+
+	return cmd
+}
+
+func runBackfillFindF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(runBackfillFindFlags.taskID); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	mrs, err := s.FindManualRuns(context.Background(), taskID)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	writeManualRuns(mrs)
+}
+
+type RunBackfillCancelFlags struct {
+	taskID     string
+	start, end string
+}
+
+var runBackfillCancelFlags RunBackfillCancelFlags
+
+func newRunBackfillCancelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "cancel the runs of a backfill that are not yet scheduled",
+		Run:   runBackfillCancelF,
+	}
+
+	cmd.Flags().StringVarP(&runBackfillCancelFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&runBackfillCancelFlags.start, "start", "", "", "start of the backfill, RFC3339 (required)")
+	cmd.Flags().StringVarP(&runBackfillCancelFlags.end, "end", "", "", "end of the backfill, RFC3339 (required)")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("start")
+	cmd.MarkFlagRequired("end")
+
+	return cmd
+}
+
+func runBackfillCancelF(cmd *cobra.Command, args []string) {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(runBackfillCancelFlags.taskID); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	st, err := time.Parse(time.RFC3339, runBackfillCancelFlags.start)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	et, err := time.Parse(time.RFC3339, runBackfillCancelFlags.end)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := s.CancelManualRun(context.Background(), taskID, st.Unix(), et.Unix()); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Backfill of task %s from %s to %s canceled.\n", taskID, runBackfillCancelFlags.start, runBackfillCancelFlags.end)
+}
+
+func writeManualRuns(mrs []*platform.ManualRun) {
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"TaskID",
+		"Start",
+		"End",
+		"LatestCompleted",
+		"RequestedAt",
+	)
+	for _, mr := range mrs {
+		w.Write(map[string]interface{}{
+			"TaskID":          mr.TaskID,
+			"Start":           mr.Start,
+			"End":             mr.End,
+			"LatestCompleted": mr.LatestCompleted,
+			"RequestedAt":     mr.RequestedAt,
+		})
+	}
+	w.Flush()
+}
+
 type RunRetryFlags struct {
 	taskID, runID string
 }
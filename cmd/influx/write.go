@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/cmd/influx/internal"
 	"github.com/influxdata/platform/http"
 	"github.com/influxdata/platform/kit/signals"
 	"github.com/influxdata/platform/models"
@@ -31,6 +32,7 @@ var writeFlags struct {
 	BucketID  string
 	Bucket    string
 	Precision string
+	Partial   bool
 }
 
 func init() {
@@ -63,6 +65,8 @@ func init() {
 	if p := viper.GetString("PRECISION"); p != "" {
 		writeFlags.Precision = p
 	}
+
+	writeCmd.PersistentFlags().BoolVar(&writeFlags.Partial, "partial", false, "write the valid lines and report the rejected ones instead of failing the whole write")
 }
 
 func fluxWriteF(cmd *cobra.Command, args []string) error {
@@ -141,12 +145,35 @@ func fluxWriteF(cmd *cobra.Command, args []string) error {
 			Addr:      flags.host,
 			Token:     flags.token,
 			Precision: writeFlags.Precision,
+			Partial:   writeFlags.Partial,
 		},
 	}
 
 	ctx = signals.WithStandardSignals(ctx)
-	if err := s.Write(ctx, orgID, bucketID, r); err != context.Canceled {
+	err = s.Write(ctx, orgID, bucketID, r)
+	if perr, ok := err.(*platform.PartialWriteError); ok {
+		writeRejectedLines(perr.Report)
+		return perr
+	}
+	if err != context.Canceled {
 		return err
 	}
 	return nil
 }
+
+func writeRejectedLines(report platform.WriteReport) {
+	w := internal.NewTabWriter(os.Stderr)
+	w.WriteHeaders(
+		"Line",
+		"Code",
+		"Reason",
+	)
+	for _, l := range report.Rejected {
+		w.Write(map[string]interface{}{
+			"Line":   l.Line,
+			"Code":   l.Code,
+			"Reason": l.Reason,
+		})
+	}
+	w.Flush()
+}
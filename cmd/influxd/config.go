@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/influxdata/platform/storage"
+	itoml "github.com/influxdata/platform/toml"
+	"github.com/spf13/cobra"
+)
+
+// Config is the configuration of influxd. It is read from the TOML file of
+// the --config flag. Settings are overridden by environment variables named
+// after their keys, such as INFLUXD_STORAGE_WAL_FSYNC_DELAY for
+// [storage.wal] fsync-delay, and by flags.
+type Config struct {
+	LogLevel      string `toml:"log-level"`
+	BoltPath      string `toml:"bolt-path"`
+	EnginePath    string `toml:"engine-path"`
+	NATSPath      string `toml:"nats-path"`
+	DeveloperMode bool   `toml:"developer-mode"`
+
+	HTTP    HTTPConfig     `toml:"http"`
+	Storage storage.Config `toml:"storage"`
+	Scraper ScraperConfig  `toml:"scraper"`
+	Tasks   TaskConfig     `toml:"tasks"`
+}
+
+// HTTPConfig is the configuration of the HTTP server.
+type HTTPConfig struct {
+	BindAddress string `toml:"bind-address"`
+
+	// Timeouts of the requests of the server; 0 means no timeout.
+	ReadTimeout       itoml.Duration `toml:"read-timeout"`
+	ReadHeaderTimeout itoml.Duration `toml:"read-header-timeout"`
+	WriteTimeout      itoml.Duration `toml:"write-timeout"`
+	IdleTimeout       itoml.Duration `toml:"idle-timeout"`
+
+	// Limits of write requests; 0 means no limit.
+	MaxWriteBodySize    int `toml:"max-write-body-size"`
+	MaxWritePoints      int `toml:"max-write-points"`
+	MaxConcurrentWrites int `toml:"max-concurrent-writes"`
+
+	// The server serves HTTPS and HTTP/2 when both a certificate and key are
+	// set. The certificate is reloaded once its files change.
+	TLSCert string `toml:"tls-cert"`
+	TLSKey  string `toml:"tls-key"`
+
+	// TLSClientCA is a bundle of the certificate authorities verifying the
+	// certificates of clients; they are only required when
+	// TLSRequireClientCert is set.
+	TLSClientCA          string `toml:"tls-client-ca"`
+	TLSRequireClientCert bool   `toml:"tls-require-client-cert"`
+	// TLSClientAuthorizations map the subjects of client certificates to
+	// the authorizations of their requests, as <authorization id>:<subject>.
+	TLSClientAuthorizations []string `toml:"tls-client-authorizations"`
+}
+
+// ScraperConfig is the configuration of the scraper scheduler.
+type ScraperConfig struct {
+	// Workers is the number of scrapers gathering metrics concurrently.
+	Workers int `toml:"workers"`
+	// Interval is the scrape interval of targets without their own.
+	Interval itoml.Duration `toml:"interval"`
+	// Timeout is the timeout of a scrape.
+	Timeout itoml.Duration `toml:"timeout"`
+}
+
+// TaskConfig is the configuration of the task scheduler.
+type TaskConfig struct {
+	// TickInterval is how often the scheduler checks for due runs.
+	TickInterval itoml.Duration `toml:"tick-interval"`
+	// MaxConcurrency limits the concurrent runs of each task; 0 means the
+	// concurrency of the task is used.
+	MaxConcurrency int `toml:"max-concurrency"`
+	// LeaseDuration is how long a task stays leased to this instance without
+	// a heartbeat, when instances share a task store. Each instance only runs
+	// the tasks it holds the lease of, and takes over the expired leases of
+	// stopped instances. 0 disables leases, and all tasks are run.
+	LeaseDuration itoml.Duration `toml:"lease-duration"`
+
+	// WebhookAllowedHosts are the only hosts the webhooks of notification
+	// rules may be at; any host is allowed if empty.
+	WebhookAllowedHosts []string `toml:"webhook-allowed-hosts"`
+	// WebhookDeniedNetworks are the networks, in CIDR notation, whose
+	// addresses notifications are never posted to.
+	WebhookDeniedNetworks []string `toml:"webhook-denied-networks"`
+}
+
+// webhookDeniedNetworks parses the configured networks notifications are
+// never posted to.
+func (c TaskConfig) webhookDeniedNetworks() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(c.WebhookDeniedNetworks))
+	for _, s := range c.WebhookDeniedNetworks {
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook denied network %q: %v", s, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// NewConfig returns the default configuration of influxd, which stores its
+// data in dir.
+func NewConfig(dir string) Config {
+	return Config{
+		LogLevel:   "info",
+		BoltPath:   filepath.Join(dir, "influxd.bolt"),
+		EnginePath: filepath.Join(dir, "engine"),
+		NATSPath:   filepath.Join(dir, "nats"),
+
+		HTTP: HTTPConfig{
+			BindAddress: ":9999",
+		},
+		Storage: storage.NewConfig(),
+		Scraper: ScraperConfig{
+			Workers:  10,
+			Interval: itoml.Duration(60 * time.Second),
+			Timeout:  itoml.Duration(30 * time.Second),
+		},
+		Tasks: TaskConfig{
+			TickInterval: itoml.Duration(time.Second),
+			// The loopback and link-local addresses of the server, such
+			// as those of cloud metadata services.
+			WebhookDeniedNetworks: []string{"127.0.0.0/8", "::1/128", "169.254.0.0/16", "fe80::/10"},
+		},
+	}
+}
+
+// newPrintConfigCommand returns the command printing the configuration
+// influxd would run with.
+func (m *Main) newPrintConfigCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "print-config",
+		Short: "Print the configuration of influxd",
+		Long: `Print the configuration of influxd as TOML, after applying the
+configuration file and environment variables.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return toml.NewEncoder(m.Stdout).Encode(m.config)
+		},
+	}
+}
@@ -8,11 +8,11 @@ import (
 	nethttp "net/http"
 	_ "net/http/pprof"
 	"os"
-	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/backup"
 	"github.com/influxdata/platform/bolt"
 	"github.com/influxdata/platform/chronograf/server"
 	"github.com/influxdata/platform/gather"
@@ -25,6 +25,7 @@ import (
 	"github.com/influxdata/platform/nats"
 	"github.com/influxdata/platform/query"
 	_ "github.com/influxdata/platform/query/builtin"
+	"github.com/influxdata/platform/quota"
 	"github.com/influxdata/platform/snowflake"
 	"github.com/influxdata/platform/source"
 	"github.com/influxdata/platform/storage"
@@ -34,6 +35,7 @@ import (
 	taskbolt "github.com/influxdata/platform/task/backend/bolt"
 	"github.com/influxdata/platform/task/backend/coordinator"
 	taskexecutor "github.com/influxdata/platform/task/backend/executor"
+	tasknotify "github.com/influxdata/platform/task/backend/notify"
 	_ "github.com/influxdata/platform/tsdb/tsi1"
 	_ "github.com/influxdata/platform/tsdb/tsm1"
 	pzap "github.com/influxdata/platform/zap"
@@ -54,6 +56,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if m.cancel == nil {
+		// A subcommand ran to completion instead of the server.
+		return
+	}
+
 	<-ctx.Done()
 
 	// Attempt clean shutdown.
@@ -67,22 +74,25 @@ type Main struct {
 	wg     sync.WaitGroup
 	cancel func()
 
-	logLevel        string
-	httpBindAddress string
-	boltPath        string
-	natsPath        string
-	developerMode   bool
-	enginePath      string
+	config Config
+
+	secretsKeyFile string
+	secretsKey     string
+
+	oauth2 oauth2Config
 
 	boltClient *bolt.Client
 	engine     *storage.Engine
 
 	httpPort   int
+	httpScheme string
 	httpServer *nethttp.Server
 
 	natsServer *nats.Server
 
-	scheduler *taskbackend.TickScheduler
+	scheduler       *taskbackend.TickScheduler
+	taskNotifier    *tasknotify.Notifier
+	taskCoordinator *coordinator.Coordinator
 
 	logger *zap.Logger
 
@@ -102,7 +112,7 @@ func NewMain() *Main {
 
 // URL returns the URL to connect to the HTTP server.
 func (m *Main) URL() string {
-	return fmt.Sprintf("http://localhost:%d", m.httpPort)
+	return fmt.Sprintf("%s://localhost:%d", m.httpScheme, m.httpPort)
 }
 
 // Shutdown shuts down the HTTP server and waits for all services to clean up.
@@ -111,7 +121,11 @@ func (m *Main) Shutdown(ctx context.Context) {
 	m.httpServer.Shutdown(ctx)
 
 	m.logger.Info("Stopping", zap.String("service", "task"))
+	if err := m.taskCoordinator.ReleaseLeases(ctx); err != nil {
+		m.logger.Info("failed releasing task leases", zap.Error(err))
+	}
 	m.scheduler.Stop()
+	m.taskNotifier.Close()
 
 	m.logger.Info("Stopping", zap.String("service", "nats"))
 	m.natsServer.Close()
@@ -137,51 +151,248 @@ func (m *Main) Run(ctx context.Context, args ...string) error {
 	if err != nil {
 		return fmt.Errorf("Failed to determine influx directory: %v", err)
 	}
+	m.config = NewConfig(dir)
 
 	prog := &cli.Program{
-		Name: "influxd",
-		Run:  func() error { return m.run(ctx) },
+		Name:   "influxd",
+		Run:    func() error { return m.run(ctx) },
+		Config: &m.config,
 		Opts: []cli.Opt{
 			{
-				DestP:   &m.logLevel,
+				DestP:   &m.config.LogLevel,
 				Flag:    "log-level",
-				Default: "info",
+				Default: m.config.LogLevel,
 				Desc:    "supported log levels are debug, info, and error",
 			},
 			{
-				DestP:   &m.httpBindAddress,
+				DestP:   &m.config.HTTP.BindAddress,
 				Flag:    "http-bind-address",
-				Default: ":9999",
+				Default: m.config.HTTP.BindAddress,
 				Desc:    "bind address for the REST HTTP API",
 			},
 			{
-				DestP:   &m.boltPath,
+				DestP:   &m.config.BoltPath,
 				Flag:    "bolt-path",
-				Default: filepath.Join(dir, "influxd.bolt"),
+				Default: m.config.BoltPath,
 				Desc:    "path to boltdb database",
 			},
 			{
-				DestP:   &m.developerMode,
+				DestP:   &m.config.DeveloperMode,
 				Flag:    "developer-mode",
-				Default: false,
+				Default: m.config.DeveloperMode,
 				Desc:    "serve assets from the local filesystem in developer mode",
 			},
 			{
-				DestP:   &m.natsPath,
+				DestP:   &m.config.NATSPath,
 				Flag:    "nats-path",
-				Default: filepath.Join(dir, "nats"),
+				Default: m.config.NATSPath,
 				Desc:    "path to NATS queue for scraping tasks",
 			},
 			{
-				DestP:   &m.enginePath,
+				DestP:   &m.config.EnginePath,
 				Flag:    "engine-path",
-				Default: filepath.Join(dir, "engine"),
+				Default: m.config.EnginePath,
 				Desc:    "path to persistent engine files",
 			},
+			{
+				DestP:   &m.config.HTTP.MaxWriteBodySize,
+				Flag:    "max-write-body-size",
+				Default: m.config.HTTP.MaxWriteBodySize,
+				Desc:    "maximum size in bytes of a decompressed write request body; 0 means no limit",
+			},
+			{
+				DestP:   &m.config.HTTP.MaxWritePoints,
+				Flag:    "max-write-points",
+				Default: m.config.HTTP.MaxWritePoints,
+				Desc:    "maximum number of points in a write request; 0 means no limit",
+			},
+			{
+				DestP:   &m.config.HTTP.MaxConcurrentWrites,
+				Flag:    "max-concurrent-writes",
+				Default: m.config.HTTP.MaxConcurrentWrites,
+				Desc:    "maximum number of write requests processed at once; 0 means no limit",
+			},
+			{
+				DestP:   &m.config.Storage.MaxSeriesPerBucket,
+				Flag:    "max-series-per-bucket",
+				Default: m.config.Storage.MaxSeriesPerBucket,
+				Desc:    "maximum number of series of a bucket; new series over the limit are dropped; 0 means no limit",
+			},
+			{
+				DestP:   &m.config.Storage.MaxValuesPerTag,
+				Flag:    "max-values-per-tag",
+				Default: m.config.Storage.MaxValuesPerTag,
+				Desc:    "maximum number of values of a tag key of a bucket; new series over the limit are dropped; 0 means no limit",
+			},
+			{
+				DestP:   &m.config.HTTP.TLSCert,
+				Flag:    "tls-cert",
+				Default: m.config.HTTP.TLSCert,
+				Desc:    "path to the TLS certificate of the HTTP server; HTTPS is served when tls-cert and tls-key are set, and reloaded once they change",
+			},
+			{
+				DestP:   &m.config.HTTP.TLSKey,
+				Flag:    "tls-key",
+				Default: m.config.HTTP.TLSKey,
+				Desc:    "path to the private key of the TLS certificate of the HTTP server",
+			},
+			{
+				DestP:   &m.config.HTTP.TLSClientCA,
+				Flag:    "tls-client-ca",
+				Default: m.config.HTTP.TLSClientCA,
+				Desc:    "path to the certificate authorities verifying client certificates",
+			},
+			{
+				DestP:   &m.config.HTTP.TLSRequireClientCert,
+				Flag:    "tls-require-client-cert",
+				Default: m.config.HTTP.TLSRequireClientCert,
+				Desc:    "require clients to present a certificate verified by tls-client-ca",
+			},
+			{
+				DestP: &m.config.HTTP.TLSClientAuthorizations,
+				Flag:  "tls-client-authorizations",
+				Desc:  "authorizations of requests made with a client certificate and no token, as <authorization id>:<certificate subject>",
+			},
+			{
+				DestP:   &m.secretsKeyFile,
+				Flag:    "secrets-key-file",
+				Default: "",
+				Desc:    "path to a file of the keys encrypting secrets, one <id>:<base64 key> per line; the first key encrypts new secrets",
+			},
+			{
+				DestP:   &m.secretsKey,
+				Flag:    "secrets-key",
+				Default: "",
+				Desc:    "comma separated keys encrypting secrets, as <id>:<base64 key>; used instead of secrets-key-file",
+			},
+			{
+				DestP:   &m.oauth2.tokenSecret,
+				Flag:    "oauth-token-secret",
+				Default: "",
+				Desc:    "secret signing the state of oauth2 logins; required to sign in with oauth2 providers",
+			},
+			{
+				DestP:   &m.oauth2.jwksURL,
+				Flag:    "oauth-jwks-url",
+				Default: "",
+				Desc:    "URL of the keys verifying the signature of OpenID id_tokens",
+			},
+			{
+				DestP:   &m.oauth2.publicURL,
+				Flag:    "oauth-public-url",
+				Default: "",
+				Desc:    "public URL of influxd that oauth2 providers redirect users to",
+			},
+			{
+				DestP:   &m.oauth2.useIDToken,
+				Flag:    "oauth-use-id-token",
+				Default: false,
+				Desc:    "read users from the OpenID id_token of oauth2 providers",
+			},
+			{
+				DestP:   &m.oauth2.createUsers,
+				Flag:    "oauth-create-users",
+				Default: true,
+				Desc:    "create users signing in with an oauth2 provider for the first time; existing users only sign in once their oauth2 identity is linked to them",
+			},
+			{
+				DestP: &m.oauth2.orgMappings,
+				Flag:  "oauth-org-mappings",
+				Desc:  "organizations of users signing in with oauth2 providers, as <provider>:<group>:<organization>[:<owner|member>]; * matches every provider or group",
+			},
+			{
+				DestP:   &m.oauth2.githubClientID,
+				Flag:    "github-client-id",
+				Default: "",
+				Desc:    "github client id for oauth2 sign in",
+			},
+			{
+				DestP:   &m.oauth2.githubClientSecret,
+				Flag:    "github-client-secret",
+				Default: "",
+				Desc:    "github client secret for oauth2 sign in",
+			},
+			{
+				DestP: &m.oauth2.githubOrgs,
+				Flag:  "github-organization",
+				Desc:  "github organizations users must be a member of to sign in",
+			},
+			{
+				DestP:   &m.oauth2.googleClientID,
+				Flag:    "google-client-id",
+				Default: "",
+				Desc:    "google client id for oauth2 sign in",
+			},
+			{
+				DestP:   &m.oauth2.googleClientSecret,
+				Flag:    "google-client-secret",
+				Default: "",
+				Desc:    "google client secret for oauth2 sign in",
+			},
+			{
+				DestP: &m.oauth2.googleDomains,
+				Flag:  "google-domains",
+				Desc:  "email domains of google users allowed to sign in",
+			},
+			{
+				DestP:   &m.oauth2.genericName,
+				Flag:    "generic-name",
+				Default: "",
+				Desc:    "name of the generic oauth2 provider",
+			},
+			{
+				DestP:   &m.oauth2.genericClientID,
+				Flag:    "generic-client-id",
+				Default: "",
+				Desc:    "client id of the generic oauth2 provider",
+			},
+			{
+				DestP:   &m.oauth2.genericClientSecret,
+				Flag:    "generic-client-secret",
+				Default: "",
+				Desc:    "client secret of the generic oauth2 provider",
+			},
+			{
+				DestP:   &m.oauth2.genericScopes,
+				Flag:    "generic-scopes",
+				Default: []string{"user:email"},
+				Desc:    "scopes requested from the generic oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2.genericDomains,
+				Flag:  "generic-domains",
+				Desc:  "email domains of users of the generic oauth2 provider allowed to sign in",
+			},
+			{
+				DestP:   &m.oauth2.genericAuthURL,
+				Flag:    "generic-auth-url",
+				Default: "",
+				Desc:    "authorization endpoint of the generic oauth2 provider",
+			},
+			{
+				DestP:   &m.oauth2.genericTokenURL,
+				Flag:    "generic-token-url",
+				Default: "",
+				Desc:    "token endpoint of the generic oauth2 provider",
+			},
+			{
+				DestP:   &m.oauth2.genericAPIURL,
+				Flag:    "generic-api-url",
+				Default: "",
+				Desc:    "URL of the OpenID userinfo of the generic oauth2 provider",
+			},
+			{
+				DestP:   &m.oauth2.genericAPIKey,
+				Flag:    "generic-api-key",
+				Default: "email",
+				Desc:    "key of the user email in the userinfo of the generic oauth2 provider",
+			},
 		},
 	}
 
 	cmd := cli.NewCommand(prog)
+	cmd.AddCommand(m.newReencryptSecretsCommand())
+	cmd.AddCommand(m.newPrintConfigCommand())
 	cmd.SetArgs(args)
 	return cmd.Execute()
 }
@@ -190,7 +401,7 @@ func (m *Main) run(ctx context.Context) (err error) {
 	ctx, m.cancel = context.WithCancel(ctx)
 
 	var lvl zapcore.Level
-	if err := lvl.Set(m.logLevel); err != nil {
+	if err := lvl.Set(m.config.LogLevel); err != nil {
 		return fmt.Errorf("unknown log level; supported levels are debug, info, and error")
 	}
 
@@ -215,9 +426,16 @@ func (m *Main) run(ctx context.Context) (err error) {
 	reg.WithLogger(m.logger)
 
 	m.boltClient = bolt.NewClient()
-	m.boltClient.Path = m.boltPath
+	m.boltClient.Path = m.config.BoltPath
 	m.boltClient.WithLogger(m.logger.With(zap.String("service", "bolt")))
 
+	if m.boltClient.SecretKeyring, err = m.secretKeyring(); err != nil {
+		m.logger.Error("failed to load secret keys", zap.Error(err))
+		return err
+	} else if m.boltClient.SecretKeyring == nil {
+		m.logger.Warn("No secret key configured, secrets are stored unencrypted")
+	}
+
 	if err := m.boltClient.Open(ctx); err != nil {
 		m.logger.Error("failed opening bolt", zap.Error(err))
 		return err
@@ -226,6 +444,7 @@ func (m *Main) run(ctx context.Context) (err error) {
 	var (
 		orgSvc           platform.OrganizationService             = m.boltClient
 		authSvc          platform.AuthorizationService            = m.boltClient
+		authUsageSvc     platform.AuthorizationUsageRecorder      = m.boltClient
 		userSvc          platform.UserService                     = m.boltClient
 		viewSvc          platform.ViewService                     = m.boltClient
 		macroSvc         platform.MacroService                    = m.boltClient
@@ -240,6 +459,7 @@ func (m *Main) run(ctx context.Context) (err error) {
 		orgLogSvc        platform.OrganizationOperationLogService = m.boltClient
 		onboardingSvc    platform.OnboardingService               = m.boltClient
 		scraperTargetSvc platform.ScraperTargetStoreService       = m.boltClient
+		secretSvc        platform.SecretService                   = m.boltClient
 		telegrafSvc      platform.TelegrafConfigStore             = m.boltClient
 		userResourceSvc  platform.UserResourceMappingService      = m.boltClient
 	)
@@ -253,9 +473,7 @@ func (m *Main) run(ctx context.Context) (err error) {
 	var storageQueryService query.ProxyQueryService
 	var pointsWriter storage.PointsWriter
 	{
-		config := storage.NewConfig()
-
-		m.engine = storage.NewEngine(m.enginePath, config, storage.WithRetentionEnforcer(bucketSvc))
+		m.engine = storage.NewEngine(m.config.EnginePath, m.config.Storage, storage.WithRetentionEnforcer(bucketSvc), storage.WithOrphanPurges(bucketSvc))
 		m.engine.WithLogger(m.logger)
 		reg.MustRegister(m.engine.PrometheusCollectors()...)
 
@@ -263,11 +481,13 @@ func (m *Main) run(ctx context.Context) (err error) {
 			m.logger.Error("failed to open engine", zap.Error(err))
 			return err
 		}
+		// Purge the data of buckets and organizations once they are deleted.
+		m.boltClient.StoragePurger = m.engine
 
 		pointsWriter = m.engine
 
 		service, err := readservice.NewProxyQueryService(
-			m.engine, bucketSvc, orgSvc, m.logger.With(zap.String("service", "storage-reads")))
+			m.engine, bucketSvc, orgSvc, secretSvc, m.logger.With(zap.String("service", "storage-reads")))
 		if err != nil {
 			m.logger.Error("failed to create query service", zap.Error(err))
 			return err
@@ -285,21 +505,48 @@ func (m *Main) run(ctx context.Context) (err error) {
 			return err
 		}
 
-		executor := taskexecutor.NewQueryServiceExecutor(m.logger.With(zap.String("service", "task-executor")), queryService, boltStore)
+		executor := taskexecutor.NewQueryServiceExecutor(m.logger.With(zap.String("service", "task-executor")), queryService, boltStore,
+			taskexecutor.WithSecretService(secretSvc))
+
+		deniedNets, err := m.config.Tasks.webhookDeniedNetworks()
+		if err != nil {
+			m.logger.Error("invalid task configuration", zap.Error(err))
+			return err
+		}
+		m.taskNotifier = tasknotify.NewNotifier(m.logger, boltStore,
+			tasknotify.WithSecretService(secretSvc),
+			tasknotify.WithAllowedHosts(m.config.Tasks.WebhookAllowedHosts...),
+			tasknotify.WithDeniedNetworks(deniedNets...))
 
 		lw := taskbackend.NewPointLogWriter(pointsWriter)
-		m.scheduler = taskbackend.NewScheduler(boltStore, executor, lw, time.Now().UTC().Unix(), taskbackend.WithTicker(ctx, time.Second), taskbackend.WithLogger(m.logger))
+		schOpts := []taskbackend.TickSchedulerOption{
+			taskbackend.WithTicker(ctx, time.Duration(m.config.Tasks.TickInterval)),
+			taskbackend.WithMaxConcurrency(m.config.Tasks.MaxConcurrency),
+			taskbackend.WithRunNotifier(m.taskNotifier),
+			taskbackend.WithLogger(m.logger),
+		}
+		var coordOpts []coordinator.Option
+		if d := time.Duration(m.config.Tasks.LeaseDuration); d > 0 {
+			// Leases are renewed three times per lease, so that a missed heartbeat does not lose them.
+			owner := snowflake.NewIDGenerator().ID()
+			schOpts = append(schOpts, taskbackend.WithLeaseOwner(owner))
+			coordOpts = append(coordOpts,
+				coordinator.WithLeases(owner, d),
+				coordinator.WithHeartbeat(ctx, d/3))
+		}
+
+		m.scheduler = taskbackend.NewScheduler(boltStore, executor, lw, time.Now().UTC().Unix(), schOpts...)
 		m.scheduler.Start(ctx)
 		reg.MustRegister(m.scheduler.PrometheusCollectors()...)
 
+		m.taskCoordinator = coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, boltStore, coordOpts...)
+
 		lr := taskbackend.NewQueryLogReader(queryService)
-		taskSvc = task.PlatformAdapter(coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, boltStore), lr, m.scheduler)
-		// TODO(lh): Add in `taskSvc = task.NewValidator(taskSvc)` once we have Authentication coming in the context.
-		// see issue #563
+		taskSvc = task.PlatformAdapter(m.taskCoordinator, lr, m.scheduler)
 	}
 
 	// NATS streaming server
-	m.natsServer = nats.NewServer(nats.Config{FilestoreDir: m.natsPath})
+	m.natsServer = nats.NewServer(nats.Config{FilestoreDir: m.config.NATSPath})
 	if err := m.natsServer.Open(); err != nil {
 		m.logger.Error("failed to start nats streaming server", zap.Error(err))
 		return err
@@ -318,12 +565,25 @@ func (m *Main) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, publisher, subscriber, 0, 0)
+	scraperScheduler, err := gather.NewScheduler(m.config.Scraper.Workers, m.logger, scraperTargetSvc, publisher, subscriber,
+		time.Duration(m.config.Scraper.Interval), time.Duration(m.config.Scraper.Timeout),
+		gather.WithSecretService(secretSvc))
 	if err != nil {
 		m.logger.Error("failed to create scraper subscriber", zap.Error(err))
 		return err
 	}
 
+	if err := subscriber.Subscribe(gather.MetricsSubject, "", &gather.StorageHandler{
+		Logger: m.logger.With(zap.String("service", "scraper-storage")),
+		Storage: &gather.PointWriter{
+			Writer:        pointsWriter,
+			BucketService: bucketSvc,
+		},
+	}); err != nil {
+		m.logger.Error("failed to create scraper storage subscriber", zap.Error(err))
+		return err
+	}
+
 	m.wg.Add(1)
 	go func(logger *zap.Logger) {
 		defer m.wg.Done()
@@ -335,7 +595,39 @@ func (m *Main) run(ctx context.Context) (err error) {
 	}(m.logger)
 
 	m.httpServer = &nethttp.Server{
-		Addr: m.httpBindAddress,
+		Addr:              m.config.HTTP.BindAddress,
+		ReadTimeout:       time.Duration(m.config.HTTP.ReadTimeout),
+		ReadHeaderTimeout: time.Duration(m.config.HTTP.ReadHeaderTimeout),
+		WriteTimeout:      time.Duration(m.config.HTTP.WriteTimeout),
+		IdleTimeout:       time.Duration(m.config.HTTP.IdleTimeout),
+	}
+
+	oauth2Providers, err := m.oauth2.oauth2Providers(http.NewChronografLogger(m.logger.With(zap.String("service", "oauth2"))))
+	if err != nil {
+		m.logger.Error("failed to configure oauth2 providers", zap.Error(err))
+		return err
+	}
+	oauth2OrgMappings, err := m.oauth2.oauth2OrgMappings()
+	if err != nil {
+		m.logger.Error("failed to configure oauth2 org mappings", zap.Error(err))
+		return err
+	}
+
+	backupSvc := &backup.Service{
+		Metadata:      m.boltClient,
+		Engine:        m.engine,
+		BucketService: bucketSvc,
+	}
+
+	// The enforcer serves the limits of organizations, so that updated
+	// limits are enforced at once.
+	limitSvc := quota.NewEnforcer(m.boltClient, m.engine)
+	limitSvc.Logger = m.logger.With(zap.String("service", "quota"))
+
+	certificateAuthorizations, err := m.config.HTTP.certificateAuthorizations()
+	if err != nil {
+		m.logger.Error("failed to configure tls client authorizations", zap.Error(err))
+		return err
 	}
 
 	handlerConfig := &http.APIBackend{
@@ -343,15 +635,23 @@ func (m *Main) run(ctx context.Context) (err error) {
 		NewBucketService:                source.NewBucketService,
 		NewQueryService:                 source.NewQueryService,
 		PointsWriter:                    pointsWriter,
+		MaxWriteBodySize:                int64(m.config.HTTP.MaxWriteBodySize),
+		MaxWritePointsPerRequest:        m.config.HTTP.MaxWritePoints,
+		MaxConcurrentWrites:             m.config.HTTP.MaxConcurrentWrites,
+		DeleteService:                   m.engine,
+		BackupService:                   backupSvc,
 		AuthorizationService:            authSvc,
+		AuthorizationUsageRecorder:      authUsageSvc,
 		BucketService:                   bucketSvc,
 		SessionService:                  sessionSvc,
 		UserService:                     userSvc,
+		OAuth2IdentityService:           m.boltClient,
 		OrganizationService:             orgSvc,
 		UserResourceMappingService:      userResourceSvc,
 		DashboardService:                dashboardSvc,
 		DashboardOperationLogService:    dashboardLogSvc,
 		BucketOperationLogService:       bucketLogSvc,
+		BucketSchemaService:             m.engine,
 		UserOperationLogService:         userLogSvc,
 		OrganizationOperationLogService: orgLogSvc,
 		ViewService:                     viewSvc,
@@ -363,7 +663,16 @@ func (m *Main) run(ctx context.Context) (err error) {
 		TaskService:                     taskSvc,
 		TelegrafService:                 telegrafSvc,
 		ScraperTargetStoreService:       scraperTargetSvc,
+		SecretService:                   secretSvc,
+		LimitService:                    limitSvc,
+		LimitEnforcer:                   limitSvc,
+		UsageService:                    m.boltClient,
+		UsageRecorder:                   m.boltClient,
 		ChronografService:               chronografSvc,
+		OAuth2Providers:                 oauth2Providers,
+		OAuth2CreateUsers:               m.oauth2.createUsers,
+		OAuth2OrgMappings:               oauth2OrgMappings,
+		CertificateAuthorizations:       certificateAuthorizations,
 	}
 
 	// HTTP server
@@ -378,7 +687,7 @@ func (m *Main) run(ctx context.Context) (err error) {
 
 	m.httpServer.Handler = h
 
-	ln, err := net.Listen("tcp", m.httpBindAddress)
+	ln, err := net.Listen("tcp", m.config.HTTP.BindAddress)
 	if err != nil {
 		httpLogger.Error("failed http listener", zap.Error(err))
 		httpLogger.Info("Stopping")
@@ -389,12 +698,28 @@ func (m *Main) run(ctx context.Context) (err error) {
 		m.httpPort = addr.Port
 	}
 
+	m.httpScheme = "http"
+	if m.config.HTTP.TLSCert != "" && m.config.HTTP.TLSKey != "" {
+		if err := m.configureTLS(httpLogger); err != nil {
+			httpLogger.Error("failed to configure tls", zap.Error(err))
+			ln.Close()
+			return err
+		}
+		m.httpScheme = "https"
+	}
+
 	m.wg.Add(1)
 	go func(logger *zap.Logger) {
 		defer m.wg.Done()
-		logger.Info("Listening", zap.String("transport", "http"), zap.String("addr", m.httpBindAddress), zap.Int("port", m.httpPort))
+		logger.Info("Listening", zap.String("transport", m.httpScheme), zap.String("addr", m.config.HTTP.BindAddress), zap.Int("port", m.httpPort))
 
-		if err := m.httpServer.Serve(ln); err != nethttp.ErrServerClosed {
+		var err error
+		if m.httpScheme == "https" {
+			err = m.httpServer.ServeTLS(ln, "", "")
+		} else {
+			err = m.httpServer.Serve(ln)
+		}
+		if err != nethttp.ErrServerClosed {
 			logger.Error("failed http service", zap.Error(err))
 		}
 		logger.Info("Stopping")
@@ -77,6 +77,42 @@ func TestMain_Write(t *testing.T) {
 	}
 }
 
+func TestMain_PrintConfig(t *testing.T) {
+	m := NewMain()
+	defer os.RemoveAll(m.Path)
+
+	path := filepath.Join(m.Path, "influxd.toml")
+	if err := ioutil.WriteFile(path, []byte(`
+log-level = "debug"
+
+[storage.wal]
+  fsync-delay = "100ms"
+
+[scraper]
+  workers = 2
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("INFLUXD_SCRAPER_WORKERS", "3")
+	defer os.Unsetenv("INFLUXD_SCRAPER_WORKERS")
+
+	if err := m.Main.Run(ctx, "print-config", "--config", path); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, exp := range []string{
+		`log-level = "debug"`,
+		`fsync-delay = "100ms"`,
+		`workers = 3`,
+		`bind-address = ":9999"`,
+	} {
+		if !strings.Contains(m.Stdout.String(), exp) {
+			t.Errorf("expected %s in config:\n%s", exp, m.Stdout.String())
+		}
+	}
+}
+
 // Main is a test wrapper for main.Main.
 type Main struct {
 	*main.Main
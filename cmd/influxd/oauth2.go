@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/influxdata/platform/chronograf"
+	"github.com/influxdata/platform/chronograf/oauth2"
+	"github.com/influxdata/platform/http"
+)
+
+// oauth2Config are the settings of the OAuth2 providers users may sign in
+// with.
+type oauth2Config struct {
+	tokenSecret string
+	jwksURL     string
+	publicURL   string
+	useIDToken  bool
+	createUsers bool
+	orgMappings []string
+
+	githubClientID     string
+	githubClientSecret string
+	githubOrgs         []string
+
+	googleClientID     string
+	googleClientSecret string
+	googleDomains      []string
+
+	genericName         string
+	genericClientID     string
+	genericClientSecret string
+	genericScopes       []string
+	genericDomains      []string
+	genericAuthURL      string
+	genericTokenURL     string
+	genericAPIURL       string
+	genericAPIKey       string
+}
+
+// oauth2Providers returns the configured OAuth2 providers. Providers are
+// only configured when their client id and secret are set.
+func (c *oauth2Config) oauth2Providers(logger chronograf.Logger) ([]http.OAuth2Provider, error) {
+	var ps []oauth2.Provider
+	if c.githubClientID != "" && c.githubClientSecret != "" {
+		ps = append(ps, &oauth2.Github{
+			ClientID:     c.githubClientID,
+			ClientSecret: c.githubClientSecret,
+			Orgs:         c.githubOrgs,
+			Logger:       logger,
+		})
+	}
+	if c.googleClientID != "" && c.googleClientSecret != "" {
+		ps = append(ps, &oauth2.Google{
+			ClientID:     c.googleClientID,
+			ClientSecret: c.googleClientSecret,
+			Domains:      c.googleDomains,
+			RedirectURL:  c.callbackURL("google"),
+			Logger:       logger,
+		})
+	}
+	if c.genericClientID != "" && c.genericClientSecret != "" {
+		g := &oauth2.Generic{
+			PageName:       c.genericName,
+			ClientID:       c.genericClientID,
+			ClientSecret:   c.genericClientSecret,
+			RequiredScopes: c.genericScopes,
+			Domains:        c.genericDomains,
+			AuthURL:        c.genericAuthURL,
+			TokenURL:       c.genericTokenURL,
+			APIURL:         c.genericAPIURL,
+			APIKey:         c.genericAPIKey,
+			Logger:         logger,
+		}
+		g.RedirectURL = c.callbackURL(g.Name())
+		ps = append(ps, g)
+	}
+
+	if len(ps) == 0 {
+		return nil, nil
+	}
+	if c.tokenSecret == "" {
+		return nil, fmt.Errorf("oauth-token-secret is required to sign in with oauth2 providers")
+	}
+
+	providers := make([]http.OAuth2Provider, 0, len(ps))
+	for _, p := range ps {
+		providers = append(providers, http.OAuth2Provider{
+			Provider:   p,
+			Tokens:     oauth2.NewJWT(c.tokenSecret, c.jwksURL),
+			UseIDToken: c.useIDToken,
+		})
+	}
+	return providers, nil
+}
+
+// callbackURL returns the URL the provider named name redirects users to
+// once they signed in.
+func (c *oauth2Config) callbackURL(name string) string {
+	return strings.TrimSuffix(c.publicURL, "/") + "/api/v2/oauth/" + url.PathEscape(strings.ToLower(name)) + "/callback"
+}
+
+// oauth2OrgMappings parses the configured mappings of users to organizations.
+func (c *oauth2Config) oauth2OrgMappings() ([]http.OAuth2OrgMapping, error) {
+	ms := make([]http.OAuth2OrgMapping, 0, len(c.orgMappings))
+	for _, s := range c.orgMappings {
+		m, err := http.ParseOAuth2OrgMapping(s)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, m)
+	}
+	return ms, nil
+}
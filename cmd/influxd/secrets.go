@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform/bolt"
+	"github.com/influxdata/platform/keyring"
+	"github.com/spf13/cobra"
+)
+
+// secretKeyring returns the keyring encrypting secrets, or nil if no
+// secret keys are configured.
+func (m *Main) secretKeyring() (*keyring.Keyring, error) {
+	switch {
+	case m.secretsKey != "":
+		return keyring.Parse(m.secretsKey)
+	case m.secretsKeyFile != "":
+		return keyring.Load(m.secretsKeyFile)
+	}
+	return nil, nil
+}
+
+// newReencryptSecretsCommand returns the command encrypting every secret
+// that is stored unencrypted or under an old key with the primary secret key.
+func (m *Main) newReencryptSecretsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reencrypt-secrets",
+		Short: "Encrypt all secrets with the primary secret key",
+		Long: `Encrypt all secrets that are stored unencrypted or under an old key
+with the first of the configured secret keys. influxd must not be running.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return m.reencryptSecrets(context.Background())
+		},
+	}
+
+	cmd.Flags().StringVar(&m.config.BoltPath, "bolt-path", m.config.BoltPath, "path to boltdb database")
+	cmd.Flags().StringVar(&m.secretsKeyFile, "secrets-key-file", m.secretsKeyFile, "path to a file of the keys encrypting secrets, one <id>:<base64 key> per line")
+	cmd.Flags().StringVar(&m.secretsKey, "secrets-key", m.secretsKey, "comma separated keys encrypting secrets, as <id>:<base64 key>")
+	return cmd
+}
+
+func (m *Main) reencryptSecrets(ctx context.Context) error {
+	r, err := m.secretKeyring()
+	if err != nil {
+		return err
+	} else if r == nil {
+		return fmt.Errorf("one of secrets-key or secrets-key-file is required")
+	}
+
+	c := bolt.NewClient()
+	c.Path = m.config.BoltPath
+	c.SecretKeyring = r
+	if err := c.Open(ctx); err != nil {
+		return err
+	}
+	defer c.Close()
+
+	n, err := c.ReencryptSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(m.Stdout, "Encrypted %d secrets with key %q\n", n, r.PrimaryID())
+	return nil
+}
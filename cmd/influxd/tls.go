@@ -0,0 +1,60 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/http"
+	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+)
+
+// configureTLS configures the HTTP server to serve HTTPS and HTTP/2 with the
+// configured certificate, verifying the certificates of clients when a
+// client certificate authority is configured.
+func (m *Main) configureTLS(logger *zap.Logger) error {
+	c := m.config.HTTP
+
+	certs, err := http.NewCertificateLoader(c.TLSCert, c.TLSKey)
+	if err != nil {
+		return err
+	}
+	certs.Logger = logger
+
+	config := &tls.Config{
+		GetCertificate: certs.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+	if c.TLSRequireClientCert && c.TLSClientCA == "" {
+		return fmt.Errorf("tls-require-client-cert requires tls-client-ca")
+	}
+	if c.TLSClientCA != "" {
+		pool, err := http.LoadCertPool(c.TLSClientCA)
+		if err != nil {
+			return err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if c.TLSRequireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	m.httpServer.TLSConfig = config
+
+	return http2.ConfigureServer(m.httpServer, nil)
+}
+
+// certificateAuthorizations parses the configured mappings of client
+// certificate subjects to authorizations.
+func (c HTTPConfig) certificateAuthorizations() (map[string]platform.ID, error) {
+	as := make(map[string]platform.ID, len(c.TLSClientAuthorizations))
+	for _, s := range c.TLSClientAuthorizations {
+		subject, id, err := http.ParseCertificateAuthorization(s)
+		if err != nil {
+			return nil, err
+		}
+		as[subject] = id
+	}
+	return as, nil
+}
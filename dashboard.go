@@ -47,11 +47,12 @@ type DashboardService interface {
 
 // Dashboard represents all visual and query data for a dashboard.
 type Dashboard struct {
-	ID          ID            `json:"id,omitempty"`
-	Name        string        `json:"name"`
-	Description string        `json:"description"`
-	Cells       []*Cell       `json:"cells"`
-	Meta        DashboardMeta `json:"meta"`
+	ID             ID            `json:"id,omitempty"`
+	OrganizationID ID            `json:"orgID,omitempty"`
+	Name           string        `json:"name"`
+	Description    string        `json:"description"`
+	Cells          []*Cell       `json:"cells"`
+	Meta           DashboardMeta `json:"meta"`
 }
 
 // Dashboard meta contains meta information about dashboards
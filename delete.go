@@ -0,0 +1,26 @@
+package platform
+
+import "context"
+
+// DeleteService deletes data from buckets.
+type DeleteService interface {
+	// DeleteBucketRangePredicate deletes the data of bucketID owned by orgID
+	// with a time in the range [start, stop) of series matching predicate.
+	// The predicate is an expression such as
+	//
+	//	_measurement = 'cpu' AND host = 'server01'
+	//
+	// An empty predicate matches all series of the bucket.
+	DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID ID, start, stop int64, predicate string) error
+}
+
+// StoragePurger removes all stored data of deleted buckets and organizations.
+type StoragePurger interface {
+	// PurgeBucket schedules the removal of all data of bucketID owned by
+	// orgID. It returns once the purge is scheduled.
+	PurgeBucket(ctx context.Context, orgID, bucketID ID) error
+
+	// PurgeOrganization schedules the removal of all data of every bucket
+	// owned by orgID. It returns once the purge is scheduled.
+	PurgeOrganization(ctx context.Context, orgID ID) error
+}
@@ -0,0 +1,219 @@
+package gather
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// SecretLoader loads scraper target credentials from the secrets
+// of the organization the target belongs to.
+type SecretLoader struct {
+	SecretService platform.SecretService
+}
+
+// LoadSecret returns the value of the secret k of the org orgID.
+func (l *SecretLoader) LoadSecret(ctx context.Context, orgID platform.ID, k string) (string, error) {
+	if l == nil || l.SecretService == nil {
+		return "", fmt.Errorf("scraper target secret %q cannot be loaded: no secret service configured", k)
+	}
+	if !orgID.Valid() {
+		return "", fmt.Errorf("scraper target secret %q cannot be loaded: target has no organization id", k)
+	}
+	return l.SecretService.LoadSecret(ctx, orgID, k)
+}
+
+// newScrapeRequest creates a GET request to the target url,
+// with the credentials configured on the target.
+func newScrapeRequest(ctx context.Context, target platform.ScraperTarget, secrets *SecretLoader) (*http.Request, error) {
+	req, err := http.NewRequest("GET", target.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if target.BearerTokenSecret != "" {
+		token, err := secrets.LoadSecret(ctx, target.OrgID, target.BearerTokenSecret)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if target.BasicAuth != nil {
+		var password string
+		if target.BasicAuth.PasswordSecret != "" {
+			password, err = secrets.LoadSecret(ctx, target.OrgID, target.BasicAuth.PasswordSecret)
+			if err != nil {
+				return nil, err
+			}
+		}
+		req.SetBasicAuth(target.BasicAuth.Username, password)
+	}
+
+	return req, nil
+}
+
+// ClientCache holds the http client of each scraper target, so that
+// connections to a target are reused across scrapes rather than a new
+// transport being left behind by every scrape. The clients of targets
+// that are removed or reconfigured are evicted with Retain.
+type ClientCache struct {
+	mu      sync.Mutex
+	clients map[platform.ID]*targetClient
+}
+
+// targetClient is the client of a target and the TLS config it was built with.
+type targetClient struct {
+	tls    platform.ScraperTLSConfig
+	client *http.Client
+}
+
+// NewClientCache returns an empty ClientCache.
+func NewClientCache() *ClientCache {
+	return &ClientCache{
+		clients: make(map[platform.ID]*targetClient),
+	}
+}
+
+// Client returns the http client used to scrape the target.
+// Targets without a TLS config share the default client. The client of
+// a target is rebuilt when its TLS config changes.
+// A nil cache builds a client that does not keep connections open.
+func (c *ClientCache) Client(target platform.ScraperTarget) (*http.Client, error) {
+	if target.TLS == nil {
+		return http.DefaultClient, nil
+	}
+
+	if c == nil {
+		return newScrapeClient(*target.TLS, true)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tc, ok := c.clients[target.ID]; ok {
+		if tc.tls == *target.TLS {
+			return tc.client, nil
+		}
+		tc.client.Transport.(*http.Transport).CloseIdleConnections()
+		delete(c.clients, target.ID)
+	}
+
+	client, err := newScrapeClient(*target.TLS, false)
+	if err != nil {
+		return nil, err
+	}
+	c.clients[target.ID] = &targetClient{
+		tls:    *target.TLS,
+		client: client,
+	}
+	return client, nil
+}
+
+// Retain evicts the clients of every target that is not one of targets,
+// or whose TLS config has changed since its client was built, and closes
+// their idle connections.
+func (c *ClientCache) Retain(targets []platform.ScraperTarget) {
+	if c == nil {
+		return
+	}
+
+	keep := make(map[platform.ID]platform.ScraperTLSConfig, len(targets))
+	for _, t := range targets {
+		if t.TLS != nil {
+			keep[t.ID] = *t.TLS
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, tc := range c.clients {
+		if tls, ok := keep[id]; ok && tls == tc.tls {
+			continue
+		}
+		tc.client.Transport.(*http.Transport).CloseIdleConnections()
+		delete(c.clients, id)
+	}
+}
+
+// newScrapeClient returns a client with the TLS config tc.
+// Idle connections are closed after a while, so that the connections of
+// removed targets are not held forever.
+func newScrapeClient(tc platform.ScraperTLSConfig, disableKeepAlives bool) (*http.Client, error) {
+	tlsConfig, err := newTLSConfig(&tc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			TLSClientConfig:   tlsConfig,
+			DisableKeepAlives: disableKeepAlives,
+			IdleConnTimeout:   90 * time.Second,
+		},
+	}, nil
+}
+
+func newTLSConfig(c *platform.ScraperTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read scraper CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("unable to parse scraper CA file %q", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load scraper client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// scrape fetches the target url with its client from clients and returns
+// the response if the target answered with a 200.
+func scrape(ctx context.Context, target platform.ScraperTarget, secrets *SecretLoader, clients *ClientCache) (*http.Response, error) {
+	req, err := newScrapeRequest(ctx, target, secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := clients.Client(target)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("scraper target %q returned status %s", target.URL, resp.Status)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,93 @@
+package gather
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/influxdata/platform"
+)
+
+func TestClientCache_Client(t *testing.T) {
+	c := NewClientCache()
+
+	client, err := c.Client(platform.ScraperTarget{ID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client != http.DefaultClient {
+		t.Errorf("expected targets without TLS config to use the default client")
+	}
+
+	target := platform.ScraperTarget{
+		ID:  1,
+		TLS: &platform.ScraperTLSConfig{ServerName: "a"},
+	}
+	first, err := c.Client(target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := c.Client(target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != second {
+		t.Errorf("expected the client of the target to be reused")
+	}
+
+	other, err := c.Client(platform.ScraperTarget{
+		ID:  2,
+		TLS: &platform.ScraperTLSConfig{ServerName: "a"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == first {
+		t.Errorf("expected each target to have its own client")
+	}
+
+	target.TLS = &platform.ScraperTLSConfig{ServerName: "b"}
+	updated, err := c.Client(target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated == first {
+		t.Errorf("expected the client to be rebuilt when the TLS config of the target changes")
+	}
+	if got := updated.Transport.(*http.Transport).TLSClientConfig.ServerName; got != "b" {
+		t.Errorf("expected server name %q, got %q", "b", got)
+	}
+}
+
+func TestClientCache_Retain(t *testing.T) {
+	c := NewClientCache()
+
+	kept := platform.ScraperTarget{ID: 1, TLS: &platform.ScraperTLSConfig{ServerName: "a"}}
+	removed := platform.ScraperTarget{ID: 2, TLS: &platform.ScraperTLSConfig{ServerName: "a"}}
+	reconfigured := platform.ScraperTarget{ID: 3, TLS: &platform.ScraperTLSConfig{ServerName: "a"}}
+	clients := make(map[platform.ID]*http.Client)
+	for _, target := range []platform.ScraperTarget{kept, removed, reconfigured} {
+		client, err := c.Client(target)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		clients[target.ID] = client
+	}
+
+	c.Retain([]platform.ScraperTarget{
+		kept,
+		{ID: 3, TLS: &platform.ScraperTLSConfig{ServerName: "b"}},
+	})
+
+	if got := len(c.clients); got != 1 {
+		t.Fatalf("expected 1 client to be retained, got %d", got)
+	}
+	if client, _ := c.Client(kept); client != clients[kept.ID] {
+		t.Errorf("expected the client of the unchanged target to be retained")
+	}
+	if client, _ := c.Client(removed); client == clients[removed.ID] {
+		t.Errorf("expected the client of the removed target to be evicted")
+	}
+	if client, _ := c.Client(reconfigured); client == clients[reconfigured.ID] {
+		t.Errorf("expected the client of the reconfigured target to be evicted")
+	}
+}
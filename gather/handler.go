@@ -12,7 +12,8 @@ import (
 
 // handler implents nats Handler interface.
 type handler struct {
-	Scraper   Scraper
+	// Scrapers are the scrapers for each supported scraper type.
+	Scrapers  map[platform.ScraperType]Scraper
 	Publisher nats.Publisher
 	Logger    *zap.Logger
 }
@@ -29,15 +30,36 @@ func (h *handler) Process(s nats.Subscription, m nats.Message) {
 		return
 	}
 
-	ms, err := h.Scraper.Gather(context.TODO(), *req)
+	scraper, ok := h.Scrapers[req.Type]
+	if !ok {
+		h.Logger.Error("unsupported scraper type", zap.String("type", string(req.Type)))
+		return
+	}
+
+	ctx := context.Background()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	ms, err := scraper.Gather(ctx, *req)
 	if err != nil {
 		h.Logger.Error("unable to gather", zap.Error(err))
 		return
 	}
 
+	collected := MetricsCollection{
+		OrgName:    req.OrgName,
+		BucketName: req.BucketName,
+		OrgID:      req.OrgID,
+		BucketID:   req.BucketID,
+		Metrics:    ms,
+	}
+
 	// send metrics to storage queue
 	buf := new(bytes.Buffer)
-	if err := json.NewEncoder(buf).Encode(ms); err != nil {
+	if err := json.NewEncoder(buf).Encode(collected); err != nil {
 		h.Logger.Error("unable to marshal json", zap.Error(err))
 		return
 	}
@@ -0,0 +1,61 @@
+package gather
+
+import (
+	"context"
+	"io/ioutil"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+)
+
+// influxScraper handles parsing line protocol served over http.
+// implements Scraper interfaces.
+type influxScraper struct {
+	Secrets *SecretLoader
+	Clients *ClientCache
+}
+
+// Gather parse metrics from a scraper target url.
+func (p *influxScraper) Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error) {
+	resp, err := scrape(ctx, target, p.Secrets, p.Clients)
+	if err != nil {
+		return ms, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ms, err = p.parse(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return applyTargetRules(target, ms)
+}
+
+func (p *influxScraper) parse(data []byte, now time.Time) ([]Metrics, error) {
+	points, err := models.ParsePointsWithPrecision(data, now, "ns")
+	if err != nil {
+		return nil, err
+	}
+
+	ms := make([]Metrics, 0, len(points))
+	for _, pt := range points {
+		fields, err := pt.Fields()
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, Metrics{
+			Name:      string(pt.Name()),
+			Tags:      pt.Tags().Map(),
+			Fields:    fields,
+			Timestamp: pt.UnixNano(),
+			Type:      MetricTypeUntyped,
+		})
+	}
+	return ms, nil
+}
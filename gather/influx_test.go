@@ -0,0 +1,44 @@
+package gather
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInfluxScraper_parse(t *testing.T) {
+	now := time.Unix(0, 1000)
+	data := []byte(`cpu,host=a usage_idle=99.5,cores=8i 12345
+mem,host=a used=1024i,ok=true,state="on"
+`)
+
+	ms, err := new(influxScraper).parse(data, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Metrics{
+		{
+			Name:      "cpu",
+			Tags:      map[string]string{"host": "a"},
+			Fields:    map[string]interface{}{"usage_idle": 99.5, "cores": int64(8)},
+			Timestamp: 12345,
+			Type:      MetricTypeUntyped,
+		},
+		{
+			Name:      "mem",
+			Tags:      map[string]string{"host": "a"},
+			Fields:    map[string]interface{}{"used": int64(1024), "ok": true, "state": "on"},
+			Timestamp: 1000,
+			Type:      MetricTypeUntyped,
+		},
+	}
+	if diff := cmp.Diff(want, ms); diff != "" {
+		t.Fatalf("unexpected metrics -want/+got:\n%s", diff)
+	}
+
+	if _, err := new(influxScraper).parse([]byte("cpu,host=a\n"), now); err == nil {
+		t.Fatal("expected error parsing point without fields")
+	}
+}
@@ -0,0 +1,189 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// jsonScraper handles parsing metrics from a JSON document.
+// implements Scraper interfaces.
+type jsonScraper struct {
+	Secrets *SecretLoader
+	Clients *ClientCache
+}
+
+// Gather parse metrics from a scraper target url.
+func (p *jsonScraper) Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error) {
+	if err := target.JSON.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := scrape(ctx, target, p.Secrets, p.Clients)
+	if err != nil {
+		return ms, err
+	}
+	defer resp.Body.Close()
+
+	ms, err = p.parse(resp.Body, target.JSON, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return applyTargetRules(target, ms)
+}
+
+func (p *jsonScraper) parse(r io.Reader, c *platform.JSONScraperConfig, now time.Time) ([]Metrics, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("reading json document failed: %v", err)
+	}
+
+	root, ok := lookupJSONPath(doc, c.MetricsPath)
+	if !ok {
+		return nil, fmt.Errorf("json path %q not found", c.MetricsPath)
+	}
+
+	var objs []interface{}
+	switch v := root.(type) {
+	case []interface{}:
+		objs = v
+	case map[string]interface{}:
+		objs = []interface{}{v}
+	default:
+		return nil, fmt.Errorf("json path %q must be an object or an array of objects", c.MetricsPath)
+	}
+
+	ms := make([]Metrics, 0, len(objs))
+	for _, obj := range objs {
+		m := Metrics{
+			Name:      c.Measurement,
+			Tags:      make(map[string]string, len(c.TagPaths)),
+			Fields:    make(map[string]interface{}, len(c.FieldPaths)),
+			Timestamp: now.UnixNano(),
+			Type:      MetricTypeUntyped,
+		}
+
+		for k, path := range c.TagPaths {
+			v, ok := lookupJSONPath(obj, path)
+			if !ok || v == nil {
+				continue
+			}
+			switch v := v.(type) {
+			case string:
+				m.Tags[k] = v
+			case json.Number:
+				m.Tags[k] = v.String()
+			case bool:
+				m.Tags[k] = strconv.FormatBool(v)
+			}
+		}
+
+		for k, path := range c.FieldPaths {
+			v, ok := lookupJSONPath(obj, path)
+			if !ok {
+				continue
+			}
+			switch v := v.(type) {
+			case string, bool:
+				m.Fields[k] = v
+			case json.Number:
+				f, err := v.Float64()
+				if err != nil {
+					return nil, fmt.Errorf("invalid number at json path %q: %v", path, err)
+				}
+				m.Fields[k] = f
+			}
+		}
+
+		if c.TimePath != "" {
+			v, ok := lookupJSONPath(obj, c.TimePath)
+			if !ok {
+				return nil, fmt.Errorf("json path %q not found", c.TimePath)
+			}
+			ts, err := parseJSONTime(v, c.TimeFormat)
+			if err != nil {
+				return nil, err
+			}
+			m.Timestamp = ts.UnixNano()
+		}
+
+		if len(m.Fields) == 0 {
+			continue
+		}
+		ms = append(ms, m)
+	}
+
+	return ms, nil
+}
+
+// lookupJSONPath returns the value found at the dot separated path in v.
+func lookupJSONPath(v interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return v, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			v = next
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func parseJSONTime(v interface{}, format string) (time.Time, error) {
+	switch format {
+	case "unix", "unix_ms", "unix_us", "unix_ns":
+		n, ok := v.(json.Number)
+		if !ok {
+			return time.Time{}, fmt.Errorf("timestamp %v is not a number", v)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		switch format {
+		case "unix":
+			return time.Unix(0, int64(f*float64(time.Second))), nil
+		case "unix_ms":
+			return time.Unix(0, int64(f*float64(time.Millisecond))), nil
+		case "unix_us":
+			return time.Unix(0, int64(f*float64(time.Microsecond))), nil
+		}
+		ns, err := n.Int64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(0, ns), nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("timestamp %v is not a string", v)
+	}
+	if format == "" {
+		format = time.RFC3339
+	}
+	return time.Parse(format, s)
+}
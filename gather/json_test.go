@@ -0,0 +1,95 @@
+package gather
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+)
+
+func TestJSONScraper_parse(t *testing.T) {
+	now := time.Unix(0, 1000)
+	const doc = `{
+	"data": {
+		"servers": [
+			{"name": "a", "time": 1540000000, "stats": {"cpu": [0.5, 0.25], "up": true}},
+			{"name": "b", "time": 1540000001, "stats": {"cpu": [0.75], "up": false}},
+			{"name": "c", "time": 1540000002, "stats": {}}
+		]
+	}
+}`
+
+	cases := []struct {
+		name   string
+		config platform.JSONScraperConfig
+		want   []Metrics
+		hasErr bool
+	}{
+		{
+			name: "array of objects",
+			config: platform.JSONScraperConfig{
+				MetricsPath: "data.servers",
+				Measurement: "servers",
+				TimePath:    "time",
+				TimeFormat:  "unix",
+				TagPaths:    map[string]string{"server": "name"},
+				FieldPaths:  map[string]string{"cpu0": "stats.cpu.0", "up": "stats.up"},
+			},
+			want: []Metrics{
+				{
+					Name:      "servers",
+					Tags:      map[string]string{"server": "a"},
+					Fields:    map[string]interface{}{"cpu0": 0.5, "up": true},
+					Timestamp: 1540000000 * int64(time.Second),
+					Type:      MetricTypeUntyped,
+				},
+				{
+					Name:      "servers",
+					Tags:      map[string]string{"server": "b"},
+					Fields:    map[string]interface{}{"cpu0": 0.75, "up": false},
+					Timestamp: 1540000001 * int64(time.Second),
+					Type:      MetricTypeUntyped,
+				},
+			},
+		},
+		{
+			name: "single object",
+			config: platform.JSONScraperConfig{
+				MetricsPath: "data.servers.1",
+				Measurement: "server",
+				FieldPaths:  map[string]string{"cpu": "stats.cpu.0"},
+			},
+			want: []Metrics{
+				{
+					Name:      "server",
+					Tags:      map[string]string{},
+					Fields:    map[string]interface{}{"cpu": 0.75},
+					Timestamp: 1000,
+					Type:      MetricTypeUntyped,
+				},
+			},
+		},
+		{
+			name: "missing metrics path",
+			config: platform.JSONScraperConfig{
+				MetricsPath: "data.clients",
+				Measurement: "clients",
+				FieldPaths:  map[string]string{"cpu": "cpu"},
+			},
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ms, err := new(jsonScraper).parse(strings.NewReader(doc), &c.config, now)
+			if (err != nil) != c.hasErr {
+				t.Fatalf("expected error %v, got %v", c.hasErr, err)
+			}
+			if diff := cmp.Diff(c.want, ms); diff != "" {
+				t.Fatalf("unexpected metrics -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
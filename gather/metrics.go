@@ -1,9 +1,24 @@
 package gather
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/gogo/protobuf/proto"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
 )
 
+// MetricsCollection is the metrics gathered from a single scraper target,
+// along with the org and bucket they should be written to.
+type MetricsCollection struct {
+	OrgName    string      `json:"org"`
+	BucketName string      `json:"bucket"`
+	OrgID      platform.ID `json:"orgID,omitempty"`
+	BucketID   platform.ID `json:"bucketID,omitempty"`
+	Metrics    []Metrics   `json:"metrics"`
+}
+
 // Metrics is the default influx based metrics.
 type Metrics struct {
 	Name      string                 `json:"name"`
@@ -13,6 +28,105 @@ type Metrics struct {
 	Type      MetricType             `json:"type"`
 }
 
+// metricsJSON is the json representation of Metrics.
+type metricsJSON struct {
+	Name      string                     `json:"name"`
+	Tags      map[string]string          `json:"tags"`
+	Fields    map[string]json.RawMessage `json:"fields"`
+	Timestamp int64                      `json:"timestamp"`
+	Type      MetricType                 `json:"type"`
+}
+
+// integerField wraps integer field values so that their type
+// survives the round trip through the metrics queue.
+type integerField struct {
+	Int  *int64  `json:"int,omitempty"`
+	Uint *uint64 `json:"uint,omitempty"`
+}
+
+// MarshalJSON implements the marshaler interface.
+func (m Metrics) MarshalJSON() ([]byte, error) {
+	mj := metricsJSON{
+		Name:      m.Name,
+		Tags:      m.Tags,
+		Timestamp: m.Timestamp,
+		Type:      m.Type,
+	}
+	if m.Fields != nil {
+		mj.Fields = make(map[string]json.RawMessage, len(m.Fields))
+	}
+	for k, v := range m.Fields {
+		switch x := v.(type) {
+		case int64:
+			v = integerField{Int: &x}
+		case uint64:
+			v = integerField{Uint: &x}
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		mj.Fields[k] = b
+	}
+	return json.Marshal(mj)
+}
+
+// UnmarshalJSON implements the unmarshaler interface.
+func (m *Metrics) UnmarshalJSON(data []byte) error {
+	var mj metricsJSON
+	if err := json.Unmarshal(data, &mj); err != nil {
+		return err
+	}
+	*m = Metrics{
+		Name:      mj.Name,
+		Tags:      mj.Tags,
+		Timestamp: mj.Timestamp,
+		Type:      mj.Type,
+	}
+	if mj.Fields == nil {
+		return nil
+	}
+	m.Fields = make(map[string]interface{}, len(mj.Fields))
+	for k, b := range mj.Fields {
+		if len(b) > 0 && b[0] == '{' {
+			var f integerField
+			if err := json.Unmarshal(b, &f); err != nil {
+				return err
+			}
+			if f.Int != nil {
+				m.Fields[k] = *f.Int
+			} else if f.Uint != nil {
+				m.Fields[k] = *f.Uint
+			}
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		m.Fields[k] = v
+	}
+	return nil
+}
+
+// Point converts the metrics to a models.Point.
+func (m Metrics) Point() (models.Point, error) {
+	return models.NewPoint(m.Name, models.NewTags(m.Tags), m.Fields, time.Unix(0, m.Timestamp))
+}
+
+// Points converts the collected metrics to models.Points.
+func (c MetricsCollection) Points() ([]models.Point, error) {
+	ps := make([]models.Point, 0, len(c.Metrics))
+	for _, m := range c.Metrics {
+		pt, err := m.Point()
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, pt)
+	}
+	return ps, nil
+}
+
 // MetricType is prometheus metrics type.
 type MetricType int
 
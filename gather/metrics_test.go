@@ -66,6 +66,25 @@ func TestMetrics(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "integer fields",
+			ms: []Metrics{
+				{
+					Name:      "cpu",
+					Timestamp: 12345,
+					Tags: map[string]string{
+						"host": "a",
+					},
+					Fields: map[string]interface{}{
+						"x": int64(-9007199254740993),
+						"y": uint64(18446744073709551615),
+						"z": 1.0,
+						"b": true,
+					},
+					Type: MetricTypeUntyped,
+				},
+			},
+		},
 	}
 	for _, c := range cases {
 		b, err := json.Marshal(c.ms)
@@ -0,0 +1,410 @@
+package gather
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+// openMetricsScraper handles parsing the OpenMetrics text format,
+// including exemplars.
+// implements Scraper interfaces.
+type openMetricsScraper struct {
+	Secrets *SecretLoader
+	Clients *ClientCache
+}
+
+// Gather parse metrics from a scraper target url.
+func (p *openMetricsScraper) Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error) {
+	resp, err := scrape(ctx, target, p.Secrets, p.Clients)
+	if err != nil {
+		return ms, err
+	}
+	defer resp.Body.Close()
+
+	ms, err = p.parse(resp.Body, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return applyTargetRules(target, ms)
+}
+
+// openMetricsSample is a single sample line of an OpenMetrics exposition.
+type openMetricsSample struct {
+	name      string
+	labels    map[string]string
+	value     float64
+	timestamp int64
+	hasTime   bool
+	exemplar  *openMetricsExemplar
+}
+
+// openMetricsExemplar is the exemplar attached to a sample.
+type openMetricsExemplar struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+	hasTime   bool
+}
+
+// openMetricsSuffixes are the sample name suffixes of the OpenMetrics metric types.
+var openMetricsSuffixes = []string{"_total", "_created", "_bucket", "_count", "_sum", "_gcount", "_gsum", "_info"}
+
+// parse reads an OpenMetrics exposition. Samples of the same metric family
+// and label set are gathered into a single metric, like the prometheus
+// scraper does. Each exemplar is returned as its own metric, with an
+// "exemplar" field holding its value and an "exemplar_<label>" field
+// for each of its labels.
+func (p *openMetricsScraper) parse(r io.Reader, now time.Time) ([]Metrics, error) {
+	types := make(map[string]string)
+	var (
+		ms    []Metrics
+		index = make(map[string]int)
+	)
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			parts := strings.Fields(line)
+			if len(parts) == 2 && parts[1] == "EOF" {
+				break
+			}
+			if len(parts) >= 4 && parts[1] == "TYPE" {
+				types[parts[2]] = parts[3]
+			}
+			continue
+		}
+
+		s, err := parseOpenMetricsSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("reading openmetrics line %d failed: %v", lineNum, err)
+		}
+
+		family, typ := openMetricsFamily(s.name, types)
+		suffix := strings.TrimPrefix(s.name, family)
+
+		tags := make(map[string]string, len(s.labels))
+		var fieldKey string
+		for k, v := range s.labels {
+			switch {
+			case k == "le" && suffix == "_bucket":
+				le, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("reading openmetrics line %d failed: invalid le %q", lineNum, v)
+				}
+				fieldKey = fmt.Sprint(le)
+			case k == "quantile" && typ == "summary" && suffix == "":
+				q, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("reading openmetrics line %d failed: invalid quantile %q", lineNum, v)
+				}
+				fieldKey = fmt.Sprint(q)
+			default:
+				tags[k] = v
+			}
+		}
+		if fieldKey == "" {
+			fieldKey = openMetricsFieldKey(typ, suffix)
+		}
+
+		ts := now.UnixNano()
+		if s.hasTime {
+			ts = s.timestamp
+		}
+
+		if !math.IsNaN(s.value) {
+			key := openMetricsSeriesKey(family, tags, ts)
+			i, ok := index[key]
+			if !ok {
+				i = len(ms)
+				index[key] = i
+				ms = append(ms, Metrics{
+					Name:      family,
+					Tags:      tags,
+					Fields:    make(map[string]interface{}),
+					Timestamp: ts,
+					Type:      openMetricsType(typ),
+				})
+			}
+			ms[i].Fields[fieldKey] = s.value
+		}
+
+		if e := s.exemplar; e != nil {
+			etags := make(map[string]string, len(s.labels))
+			for k, v := range s.labels {
+				etags[k] = v
+			}
+			fields := map[string]interface{}{
+				"exemplar": e.value,
+			}
+			for k, v := range e.labels {
+				fields["exemplar_"+k] = v
+			}
+			ets := ts
+			if e.hasTime {
+				ets = e.timestamp
+			}
+			ms = append(ms, Metrics{
+				Name:      family,
+				Tags:      etags,
+				Fields:    fields,
+				Timestamp: ets,
+				Type:      openMetricsType(typ),
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if ms == nil {
+		ms = make([]Metrics, 0)
+	}
+	return ms, nil
+}
+
+// openMetricsFamily returns the family name and type of the sample name.
+func openMetricsFamily(name string, types map[string]string) (string, string) {
+	if typ, ok := types[name]; ok {
+		return name, typ
+	}
+	for _, suffix := range openMetricsSuffixes {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		family := strings.TrimSuffix(name, suffix)
+		if typ, ok := types[family]; ok {
+			return family, typ
+		}
+	}
+	return name, "unknown"
+}
+
+func openMetricsFieldKey(typ, suffix string) string {
+	switch suffix {
+	case "_count", "_gcount":
+		return "count"
+	case "_sum", "_gsum":
+		return "sum"
+	case "_created":
+		return "created"
+	case "_info":
+		return "info"
+	}
+	switch typ {
+	case "counter":
+		return "counter"
+	case "gauge", "stateset":
+		return "gauge"
+	}
+	return "value"
+}
+
+func openMetricsType(typ string) MetricType {
+	switch typ {
+	case "counter":
+		return MetricTypeCounter
+	case "gauge", "stateset", "info":
+		return MetricTypeGauge
+	case "summary":
+		return MetricTypeSummary
+	case "histogram", "gaugehistogram":
+		return MetricTypeHistogrm
+	}
+	return MetricTypeUntyped
+}
+
+func openMetricsSeriesKey(family string, tags map[string]string, ts int64) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(family)
+	for _, k := range keys {
+		b.WriteByte(0)
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(tags[k])
+	}
+	b.WriteByte(0)
+	b.WriteString(strconv.FormatInt(ts, 10))
+	return b.String()
+}
+
+// parseOpenMetricsSample parses a line of the form
+//
+//	name{labels} value [timestamp] [# {labels} value [timestamp]]
+func parseOpenMetricsSample(line string) (openMetricsSample, error) {
+	var s openMetricsSample
+
+	i := strings.IndexAny(line, "{ ")
+	if i <= 0 {
+		return s, fmt.Errorf("missing metric name or value")
+	}
+	s.name = line[:i]
+	rest := line[i:]
+
+	if rest[0] == '{' {
+		labels, r, err := parseOpenMetricsLabels(rest)
+		if err != nil {
+			return s, err
+		}
+		s.labels, rest = labels, r
+	}
+
+	var exemplar string
+	if j := strings.Index(rest, " # "); j >= 0 {
+		rest, exemplar = rest[:j], rest[j+3:]
+	}
+
+	value, ts, hasTime, err := parseOpenMetricsValue(rest)
+	if err != nil {
+		return s, err
+	}
+	s.value, s.timestamp, s.hasTime = value, ts, hasTime
+
+	if exemplar != "" {
+		if exemplar[0] != '{' {
+			return s, fmt.Errorf("exemplar must begin with a label set")
+		}
+		labels, r, err := parseOpenMetricsLabels(exemplar)
+		if err != nil {
+			return s, err
+		}
+		value, ts, hasTime, err := parseOpenMetricsValue(r)
+		if err != nil {
+			return s, err
+		}
+		s.exemplar = &openMetricsExemplar{
+			labels:    labels,
+			value:     value,
+			timestamp: ts,
+			hasTime:   hasTime,
+		}
+	}
+
+	return s, nil
+}
+
+// parseOpenMetricsValue parses a value and optional timestamp.
+func parseOpenMetricsValue(s string) (value float64, ts int64, hasTime bool, err error) {
+	parts := strings.Fields(s)
+	if len(parts) == 0 || len(parts) > 2 {
+		return 0, 0, false, fmt.Errorf("expected a value and an optional timestamp, got %q", strings.TrimSpace(s))
+	}
+	value, err = strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("invalid value %q", parts[0])
+	}
+	if len(parts) == 2 {
+		ts, err = parseOpenMetricsTimestamp(parts[1])
+		if err != nil {
+			return 0, 0, false, err
+		}
+		hasTime = true
+	}
+	return value, ts, hasTime, nil
+}
+
+// parseOpenMetricsTimestamp converts a timestamp in seconds to nanoseconds,
+// without losing precision to floating point for decimal timestamps.
+func parseOpenMetricsTimestamp(s string) (int64, error) {
+	sec, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		sec, frac = s[:i], s[i+1:]
+	}
+	n, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil || len(frac) > 9 {
+		f, ferr := strconv.ParseFloat(s, 64)
+		if ferr != nil {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		return int64(f * float64(time.Second)), nil
+	}
+	ns := n * int64(time.Second)
+	if frac != "" {
+		f, err := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		if strings.HasPrefix(sec, "-") {
+			f = -f
+		}
+		ns += f
+	}
+	return ns, nil
+}
+
+// parseOpenMetricsLabels parses a label set beginning with '{'
+// and returns the labels and the rest of the line.
+func parseOpenMetricsLabels(s string) (map[string]string, string, error) {
+	labels := make(map[string]string)
+	i := 1
+	for {
+		if i >= len(s) {
+			return nil, "", fmt.Errorf("unterminated label set")
+		}
+		if s[i] == '}' {
+			return labels, s[i+1:], nil
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq <= 0 {
+			return nil, "", fmt.Errorf("invalid label set")
+		}
+		name := s[i : i+eq]
+		i += eq + 1
+		if i >= len(s) || s[i] != '"' {
+			return nil, "", fmt.Errorf("label %q value must be quoted", name)
+		}
+		i++
+
+		var value strings.Builder
+		for {
+			if i >= len(s) {
+				return nil, "", fmt.Errorf("unterminated label %q value", name)
+			}
+			c := s[i]
+			if c == '"' {
+				i++
+				break
+			}
+			if c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				i++
+				continue
+			}
+			value.WriteByte(c)
+			i++
+		}
+		labels[name] = value.String()
+
+		if i < len(s) && s[i] == ',' {
+			i++
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package gather
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const sampleOpenMetrics = `# TYPE acme_http_router_request_seconds summary
+# UNIT acme_http_router_request_seconds seconds
+# HELP acme_http_router_request_seconds Latency though all of ACME's HTTP request router.
+acme_http_router_request_seconds_sum{path="/api/v1",method="GET"} 9036.32
+acme_http_router_request_seconds_count{path="/api/v1",method="GET"} 807283.0
+# TYPE foo histogram
+foo_bucket{le="0.01"} 0
+foo_bucket{le="0.1"} 8 # {trace_id="KOO5S4vxi0o"} 0.067 1520879602.890
+foo_bucket{le="+Inf"} 17
+foo_count 17
+foo_sum 324789.3
+# TYPE http_requests counter
+http_requests_total{code="200"} 1027 1520879607.789
+# TYPE temperature gauge
+temperature{room="a \"b\""} NaN
+# EOF
+ignored 1
+`
+
+func TestOpenMetricsScraper_parse(t *testing.T) {
+	now := time.Unix(0, 1000)
+	ms, err := new(openMetricsScraper).parse(strings.NewReader(sampleOpenMetrics), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Metrics{
+		{
+			Name:      "acme_http_router_request_seconds",
+			Tags:      map[string]string{"path": "/api/v1", "method": "GET"},
+			Fields:    map[string]interface{}{"sum": 9036.32, "count": 807283.0},
+			Timestamp: 1000,
+			Type:      MetricTypeSummary,
+		},
+		{
+			Name:      "foo",
+			Tags:      map[string]string{},
+			Fields:    map[string]interface{}{"0.01": 0.0, "0.1": 8.0, "+Inf": 17.0, "count": 17.0, "sum": 324789.3},
+			Timestamp: 1000,
+			Type:      MetricTypeHistogrm,
+		},
+		{
+			Name:      "foo",
+			Tags:      map[string]string{"le": "0.1"},
+			Fields:    map[string]interface{}{"exemplar": 0.067, "exemplar_trace_id": "KOO5S4vxi0o"},
+			Timestamp: 1520879602890000000,
+			Type:      MetricTypeHistogrm,
+		},
+		{
+			Name:      "http_requests",
+			Tags:      map[string]string{"code": "200"},
+			Fields:    map[string]interface{}{"counter": 1027.0},
+			Timestamp: 1520879607789000000,
+			Type:      MetricTypeCounter,
+		},
+	}
+	if diff := cmp.Diff(want, ms); diff != "" {
+		t.Fatalf("unexpected metrics -want/+got:\n%s", diff)
+	}
+}
+
+func TestOpenMetricsScraper_parseErrors(t *testing.T) {
+	for _, line := range []string{
+		`foo{bar="baz} 1`,
+		`foo{bar=baz} 1`,
+		`foo abc`,
+		`foo 1 2 3`,
+		`foo 1 # 0.5`,
+	} {
+		if _, err := new(openMetricsScraper).parse(strings.NewReader(line+"\n"), time.Now()); err == nil {
+			t.Errorf("expected error parsing %q", line)
+		}
+	}
+}
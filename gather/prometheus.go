@@ -17,17 +17,25 @@ import (
 
 // prometheusScraper handles parsing prometheus metrics.
 // implements Scraper interfaces.
-type prometheusScraper struct{}
+type prometheusScraper struct {
+	Secrets *SecretLoader
+	Clients *ClientCache
+}
 
 // Gather parse metrics from a scraper target url.
 func (p *prometheusScraper) Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error) {
-	resp, err := http.Get(target.URL)
+	resp, err := scrape(ctx, target, p.Secrets, p.Clients)
 	if err != nil {
 		return ms, err
 	}
 	defer resp.Body.Close()
 
-	return p.parse(resp.Body, resp.Header)
+	ms, err = p.parse(resp.Body, resp.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyTargetRules(target, ms)
 }
 
 func (p *prometheusScraper) parse(r io.Reader, header http.Header) ([]Metrics, error) {
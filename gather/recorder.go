@@ -0,0 +1,65 @@
+package gather
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+)
+
+// PointWriter implements the Storage interface by writing the
+// collected metrics with a storage.PointsWriter.
+type PointWriter struct {
+	Writer        storage.PointsWriter
+	BucketService platform.BucketService
+}
+
+// Record converts the collected metrics to points and writes them
+// to the bucket of the scraper target. Targets saved before they had a
+// bucket ID write to the bucket named on them.
+func (s *PointWriter) Record(collected MetricsCollection) error {
+	if len(collected.Metrics) == 0 {
+		return nil
+	}
+
+	bucket, err := s.findBucket(context.TODO(), collected)
+	if err != nil {
+		return err
+	}
+
+	ps, err := collected.Points()
+	if err != nil {
+		return err
+	}
+
+	ps, err = tsdb.ExplodePoints(bucket.OrganizationID, bucket.ID, ps)
+	if err != nil {
+		return err
+	}
+
+	return s.Writer.WritePoints(ps)
+}
+
+func (s *PointWriter) findBucket(ctx context.Context, collected MetricsCollection) (*platform.Bucket, error) {
+	if !collected.BucketID.Valid() {
+		bucket, err := s.BucketService.FindBucket(ctx, platform.BucketFilter{
+			Organization: &collected.OrgName,
+			Name:         &collected.BucketName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("bucket %q not found in org %q: %v", collected.BucketName, collected.OrgName, err)
+		}
+		return bucket, nil
+	}
+
+	bucket, err := s.BucketService.FindBucketByID(ctx, collected.BucketID)
+	if err != nil {
+		return nil, fmt.Errorf("bucket %s not found: %v", collected.BucketID, err)
+	}
+	if bucket.OrganizationID != collected.OrgID {
+		return nil, fmt.Errorf("bucket %s does not belong to org %s", collected.BucketID, collected.OrgID)
+	}
+	return bucket, nil
+}
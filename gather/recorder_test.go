@@ -0,0 +1,152 @@
+package gather
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	platformtesting "github.com/influxdata/platform/testing"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestPointWriter_Record(t *testing.T) {
+	orgID := platformtesting.MustIDBase16("020f755c3c082000")
+	bucketID := platformtesting.MustIDBase16("020f755c3c082001")
+
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		if *filter.Organization != "org1" || *filter.Name != "bucket1" {
+			return nil, fmt.Errorf("bucket not found")
+		}
+		return &platform.Bucket{
+			ID:             bucketID,
+			OrganizationID: orgID,
+			Name:           "bucket1",
+		}, nil
+	}
+	bucketSvc.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		if id != bucketID {
+			return nil, fmt.Errorf("bucket not found")
+		}
+		return &platform.Bucket{
+			ID:             bucketID,
+			OrganizationID: orgID,
+			Name:           "bucket1",
+		}, nil
+	}
+
+	cases := []struct {
+		name      string
+		collected MetricsCollection
+		points    int
+		hasErr    bool
+	}{
+		{
+			name: "empty",
+			collected: MetricsCollection{
+				OrgName:    "org1",
+				BucketName: "bucket1",
+			},
+		},
+		{
+			name: "bucket not found",
+			collected: MetricsCollection{
+				OrgName:    "org1",
+				BucketName: "bucket2",
+				Metrics: []Metrics{
+					{
+						Name:      "go_goroutines",
+						Fields:    map[string]interface{}{"gauge": float64(36)},
+						Timestamp: 12345,
+					},
+				},
+			},
+			hasErr: true,
+		},
+		{
+			name: "exploded fields",
+			collected: MetricsCollection{
+				OrgName:    "org1",
+				BucketName: "bucket1",
+				Metrics: []Metrics{
+					{
+						Name: "go_gc_duration_seconds",
+						Tags: map[string]string{"host": "a"},
+						Fields: map[string]interface{}{
+							"count": float64(326),
+							"sum":   0.07497837,
+						},
+						Timestamp: 12345,
+						Type:      MetricTypeSummary,
+					},
+					{
+						Name:      "go_goroutines",
+						Tags:      map[string]string{},
+						Fields:    map[string]interface{}{"gauge": float64(36)},
+						Timestamp: 12345,
+						Type:      MetricTypeGauge,
+					},
+				},
+			},
+			points: 3,
+		},
+		{
+			name: "bucket id",
+			collected: MetricsCollection{
+				OrgID:    orgID,
+				BucketID: bucketID,
+				Metrics: []Metrics{
+					{
+						Name:      "go_goroutines",
+						Fields:    map[string]interface{}{"gauge": float64(36)},
+						Timestamp: 12345,
+					},
+				},
+			},
+			points: 1,
+		},
+		{
+			name: "bucket of another org",
+			collected: MetricsCollection{
+				OrgID:    bucketID,
+				BucketID: bucketID,
+				Metrics: []Metrics{
+					{
+						Name:      "go_goroutines",
+						Fields:    map[string]interface{}{"gauge": float64(36)},
+						Timestamp: 12345,
+					},
+				},
+			},
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pw := new(mock.PointsWriter)
+			s := &PointWriter{
+				Writer:        pw,
+				BucketService: bucketSvc,
+			}
+			err := s.Record(c.collected)
+			if (err != nil) != c.hasErr {
+				t.Fatalf("expected error %v, got %v", c.hasErr, err)
+			}
+			if len(pw.Points) != c.points {
+				t.Fatalf("expected %d points written, got %d", c.points, len(pw.Points))
+			}
+
+			name := tsdb.EncodeName(orgID, bucketID)
+			for _, pt := range pw.Points {
+				if string(pt.Name()) != string(name[:]) {
+					t.Fatalf("expected point to be written to org/bucket %s/%s, got name %q", orgID, bucketID, pt.Name())
+				}
+				if pt.UnixNano() != 12345 {
+					t.Fatalf("expected timestamp 12345, got %d", pt.UnixNano())
+				}
+			}
+		})
+	}
+}
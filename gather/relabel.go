@@ -0,0 +1,130 @@
+package gather
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/influxdata/platform"
+)
+
+// relabelRule is a platform.RelabelRule with its defaults applied
+// and its regex compiled.
+type relabelRule struct {
+	platform.RelabelRule
+	regex *regexp.Regexp
+}
+
+func compileRelabelRules(rules []platform.RelabelRule) ([]relabelRule, error) {
+	rs := make([]relabelRule, 0, len(rules))
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return nil, err
+		}
+		expr := r.Regex
+		if expr == "" {
+			expr = "(.*)"
+		}
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return nil, err
+		}
+		if r.Separator == "" {
+			r.Separator = ";"
+		}
+		if r.Replacement == "" {
+			r.Replacement = "$1"
+		}
+		rs = append(rs, relabelRule{RelabelRule: r, regex: re})
+	}
+	return rs, nil
+}
+
+// applyTargetRules adds the static tags of the target to each metric and then
+// applies the target's relabel rules, dropping metrics the rules filter out.
+func applyTargetRules(target platform.ScraperTarget, ms []Metrics) ([]Metrics, error) {
+	if len(target.Tags) == 0 && len(target.RelabelRules) == 0 {
+		return ms, nil
+	}
+
+	rules, err := compileRelabelRules(target.RelabelRules)
+	if err != nil {
+		return nil, err
+	}
+
+	out := ms[:0]
+	for _, m := range ms {
+		if m.Tags == nil {
+			m.Tags = make(map[string]string, len(target.Tags))
+		}
+		for k, v := range target.Tags {
+			m.Tags[k] = v
+		}
+		if m, ok := relabel(m, rules); ok {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+// relabel applies the rules to m in order.
+// It returns false if the metric should be dropped.
+func relabel(m Metrics, rules []relabelRule) (Metrics, bool) {
+	for _, r := range rules {
+		switch r.Action {
+		case platform.RelabelKeep:
+			if !r.regex.MatchString(r.sourceValue(m)) {
+				return m, false
+			}
+		case platform.RelabelDrop:
+			if r.regex.MatchString(r.sourceValue(m)) {
+				return m, false
+			}
+		case platform.RelabelReplace:
+			val := r.sourceValue(m)
+			idx := r.regex.FindStringSubmatchIndex(val)
+			if idx == nil {
+				continue
+			}
+			res := r.regex.ExpandString(nil, r.Replacement, val, idx)
+			setLabel(&m, r.TargetLabel, string(res))
+		case platform.RelabelLabelDrop:
+			for k := range m.Tags {
+				if r.regex.MatchString(k) {
+					delete(m.Tags, k)
+				}
+			}
+		case platform.RelabelLabelKeep:
+			for k := range m.Tags {
+				if !r.regex.MatchString(k) {
+					delete(m.Tags, k)
+				}
+			}
+		}
+	}
+	return m, m.Name != ""
+}
+
+// sourceValue joins the values of the rule's source labels.
+func (r relabelRule) sourceValue(m Metrics) string {
+	vals := make([]string, 0, len(r.SourceLabels))
+	for _, l := range r.SourceLabels {
+		if l == platform.MetricNameLabel {
+			vals = append(vals, m.Name)
+			continue
+		}
+		vals = append(vals, m.Tags[l])
+	}
+	return strings.Join(vals, r.Separator)
+}
+
+func setLabel(m *Metrics, label, value string) {
+	if label == platform.MetricNameLabel {
+		m.Name = value
+		return
+	}
+	if value == "" {
+		delete(m.Tags, label)
+		return
+	}
+	m.Tags[label] = value
+}
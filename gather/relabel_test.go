@@ -0,0 +1,183 @@
+package gather
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+)
+
+func TestApplyTargetRules(t *testing.T) {
+	newMetrics := func() []Metrics {
+		return []Metrics{
+			{
+				Name:   "go_goroutines",
+				Tags:   map[string]string{"instance": "host1:9100"},
+				Fields: map[string]interface{}{"gauge": float64(36)},
+			},
+			{
+				Name:   "go_info",
+				Tags:   map[string]string{"instance": "host1:9100", "version": "go1.10.3"},
+				Fields: map[string]interface{}{"gauge": float64(1)},
+			},
+			{
+				Name:   "http_requests_total",
+				Tags:   map[string]string{"instance": "host2:9100", "code": "200"},
+				Fields: map[string]interface{}{"counter": float64(12)},
+			},
+		}
+	}
+
+	cases := []struct {
+		name   string
+		target platform.ScraperTarget
+		want   []Metrics
+		hasErr bool
+	}{
+		{
+			name: "no rules",
+			want: newMetrics(),
+		},
+		{
+			name: "static tags",
+			target: platform.ScraperTarget{
+				Tags: map[string]string{"env": "prod"},
+			},
+			want: []Metrics{
+				{
+					Name:   "go_goroutines",
+					Tags:   map[string]string{"instance": "host1:9100", "env": "prod"},
+					Fields: map[string]interface{}{"gauge": float64(36)},
+				},
+				{
+					Name:   "go_info",
+					Tags:   map[string]string{"instance": "host1:9100", "version": "go1.10.3", "env": "prod"},
+					Fields: map[string]interface{}{"gauge": float64(1)},
+				},
+				{
+					Name:   "http_requests_total",
+					Tags:   map[string]string{"instance": "host2:9100", "code": "200", "env": "prod"},
+					Fields: map[string]interface{}{"counter": float64(12)},
+				},
+			},
+		},
+		{
+			name: "keep metric names",
+			target: platform.ScraperTarget{
+				RelabelRules: []platform.RelabelRule{
+					{
+						Action:       platform.RelabelKeep,
+						SourceLabels: []string{platform.MetricNameLabel},
+						Regex:        "go_.*",
+					},
+				},
+			},
+			want: newMetrics()[:2],
+		},
+		{
+			name: "drop by label value",
+			target: platform.ScraperTarget{
+				RelabelRules: []platform.RelabelRule{
+					{
+						Action:       platform.RelabelDrop,
+						SourceLabels: []string{"instance"},
+						Regex:        "host1:.*",
+					},
+				},
+			},
+			want: newMetrics()[2:],
+		},
+		{
+			name: "replace and labeldrop",
+			target: platform.ScraperTarget{
+				RelabelRules: []platform.RelabelRule{
+					{
+						Action:       platform.RelabelReplace,
+						SourceLabels: []string{"instance"},
+						Regex:        "(.*):.*",
+						TargetLabel:  "host",
+					},
+					{
+						Action: platform.RelabelLabelDrop,
+						Regex:  "instance|version",
+					},
+				},
+			},
+			want: []Metrics{
+				{
+					Name:   "go_goroutines",
+					Tags:   map[string]string{"host": "host1"},
+					Fields: map[string]interface{}{"gauge": float64(36)},
+				},
+				{
+					Name:   "go_info",
+					Tags:   map[string]string{"host": "host1"},
+					Fields: map[string]interface{}{"gauge": float64(1)},
+				},
+				{
+					Name:   "http_requests_total",
+					Tags:   map[string]string{"host": "host2", "code": "200"},
+					Fields: map[string]interface{}{"counter": float64(12)},
+				},
+			},
+		},
+		{
+			name: "rename metric",
+			target: platform.ScraperTarget{
+				RelabelRules: []platform.RelabelRule{
+					{
+						Action:       platform.RelabelReplace,
+						SourceLabels: []string{platform.MetricNameLabel},
+						Regex:        "go_(.*)",
+						TargetLabel:  platform.MetricNameLabel,
+						Replacement:  "golang_$1",
+					},
+					{
+						Action: platform.RelabelLabelKeep,
+						Regex:  "code",
+					},
+				},
+			},
+			want: []Metrics{
+				{
+					Name:   "golang_goroutines",
+					Tags:   map[string]string{},
+					Fields: map[string]interface{}{"gauge": float64(36)},
+				},
+				{
+					Name:   "golang_info",
+					Tags:   map[string]string{},
+					Fields: map[string]interface{}{"gauge": float64(1)},
+				},
+				{
+					Name:   "http_requests_total",
+					Tags:   map[string]string{"code": "200"},
+					Fields: map[string]interface{}{"counter": float64(12)},
+				},
+			},
+		},
+		{
+			name: "invalid regex",
+			target: platform.ScraperTarget{
+				RelabelRules: []platform.RelabelRule{
+					{
+						Action: platform.RelabelDrop,
+						Regex:  "(",
+					},
+				},
+			},
+			hasErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := applyTargetRules(c.target, newMetrics())
+			if (err != nil) != c.hasErr {
+				t.Fatalf("expected error %v, got %v", c.hasErr, err)
+			}
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Fatalf("unexpected metrics -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
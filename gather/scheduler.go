@@ -14,16 +14,18 @@ import (
 
 // nats subjects
 const (
-	MetricsSubject    = "metrics"
-	promTargetSubject = "promTarget"
+	MetricsSubject = "metrics"
+	targetSubject  = "scraperTarget"
 )
 
 // Scheduler is struct to run scrape jobs.
 type Scheduler struct {
 	Targets platform.ScraperTargetStoreService
 	// Interval is between each metrics gathering event.
+	// It is used for targets that do not set their own interval.
 	Interval time.Duration
-	// Timeout is the maxisium time duration allowed by each TCP request
+	// Timeout is the maxisium time duration allowed by each TCP request.
+	// It is used for targets that do not set their own timeout.
 	Timeout time.Duration
 
 	// Publisher will send the gather requests and gathered metrics to the queue.
@@ -32,6 +34,26 @@ type Scheduler struct {
 	Logger *zap.Logger
 
 	gather chan struct{}
+
+	// lastScrape is the time each target was last published for scraping.
+	// It is only accessed by the run loop.
+	lastScrape map[platform.ID]time.Time
+
+	secrets *SecretLoader
+	clients *ClientCache
+}
+
+// SchedulerOption is a option you can use to modify the scheduler's behavior.
+type SchedulerOption func(*Scheduler)
+
+// WithSecretService sets the service used to load the credentials of
+// scraper targets from the secrets of their organization.
+func WithSecretService(secrets platform.SecretService) SchedulerOption {
+	return func(s *Scheduler) {
+		s.secrets = &SecretLoader{
+			SecretService: secrets,
+		}
+	}
 }
 
 // NewScheduler creates a new Scheduler and subscriptions for scraper jobs.
@@ -43,6 +65,7 @@ func NewScheduler(
 	s nats.Subscriber,
 	interval time.Duration,
 	timeout time.Duration,
+	opts ...SchedulerOption,
 ) (*Scheduler, error) {
 	if interval == 0 {
 		interval = 60 * time.Second
@@ -51,17 +74,23 @@ func NewScheduler(
 		timeout = 30 * time.Second
 	}
 	scheduler := &Scheduler{
-		Targets:   targets,
-		Interval:  interval,
-		Timeout:   timeout,
-		Publisher: p,
-		Logger:    l,
-		gather:    make(chan struct{}, 100),
+		Targets:    targets,
+		Interval:   interval,
+		Timeout:    timeout,
+		Publisher:  p,
+		Logger:     l,
+		gather:     make(chan struct{}, 100),
+		lastScrape: make(map[platform.ID]time.Time),
+		clients:    NewClientCache(),
+	}
+
+	for _, opt := range opts {
+		opt(scheduler)
 	}
 
 	for i := 0; i < numScrapers; i++ {
-		err := s.Subscribe(promTargetSubject, "", &handler{
-			Scraper:   new(prometheusScraper),
+		err := s.Subscribe(targetSubject, "", &handler{
+			Scrapers:  newScrapers(scheduler.secrets, scheduler.clients),
 			Publisher: p,
 			Logger:    l,
 		})
@@ -76,12 +105,21 @@ func NewScheduler(
 // Run will retrieve scraper targets from the target storage,
 // and publish them to nats job queue for gather.
 func (s *Scheduler) Run(ctx context.Context) error {
+	// Targets may have their own interval, so check which targets
+	// are due at least every second.
+	resolution := time.Second
+	if s.Interval < resolution {
+		resolution = s.Interval
+	}
+
 	go func(s *Scheduler, ctx context.Context) {
+		ticker := time.NewTicker(resolution)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(s.Interval): // TODO: change to ticker because of garbage collection
+			case <-ticker.C:
 				s.gather <- struct{}{}
 			}
 		}
@@ -95,20 +133,51 @@ func (s *Scheduler) run(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		case <-s.gather:
-			ctx, cancel := context.WithTimeout(ctx, s.Timeout)
-			defer cancel()
-			targets, err := s.Targets.ListTargets(ctx)
-			if err != nil {
-				s.Logger.Error("cannot list targets", zap.Error(err))
-				continue
-			}
-			for _, target := range targets {
-				if err := requestScrape(target, s.Publisher); err != nil {
-					s.Logger.Error("json encoding error", zap.Error(err))
-				}
-			}
+			s.requestDueScrapes(ctx, time.Now())
+		}
+	}
+}
+
+// requestDueScrapes publishes a scrape request for every target
+// whose interval has elapsed since it was last scraped.
+func (s *Scheduler) requestDueScrapes(ctx context.Context, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	targets, err := s.Targets.ListTargets(ctx)
+	cancel()
+	if err != nil {
+		s.Logger.Error("cannot list targets", zap.Error(err))
+		return
+	}
+
+	seen := make(map[platform.ID]struct{}, len(targets))
+	for _, target := range targets {
+		seen[target.ID] = struct{}{}
+
+		interval := target.Interval
+		if interval == 0 {
+			interval = s.Interval
+		}
+		if last, ok := s.lastScrape[target.ID]; ok && now.Sub(last) < interval {
+			continue
+		}
+		s.lastScrape[target.ID] = now
+
+		if target.Timeout == 0 {
+			target.Timeout = s.Timeout
+		}
+		if err := requestScrape(target, s.Publisher); err != nil {
+			s.Logger.Error("unable to request scrape", zap.Stringer("target_id", target.ID), zap.Error(err))
+		}
+	}
+
+	// Forget targets that have been removed, and the clients of targets
+	// that have been removed or reconfigured.
+	for id := range s.lastScrape {
+		if _, ok := seen[id]; !ok {
+			delete(s.lastScrape, id)
 		}
 	}
+	s.clients.Retain(targets)
 }
 
 func requestScrape(t platform.ScraperTarget, publisher nats.Publisher) error {
@@ -117,9 +186,8 @@ func requestScrape(t platform.ScraperTarget, publisher nats.Publisher) error {
 	if err != nil {
 		return err
 	}
-	switch t.Type {
-	case platform.PrometheusScraperType:
-		return publisher.Publish(promTargetSubject, buf)
+	if !platform.ValidScraperType(string(t.Type)) {
+		return fmt.Errorf("unsupported target scrape type: %s", t.Type)
 	}
-	return fmt.Errorf("unsupported target scrape type: %s", t.Type)
+	return publisher.Publish(targetSubject, buf)
 }
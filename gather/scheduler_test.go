@@ -2,6 +2,8 @@ package gather
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http/httptest"
 	"os"
 	"testing"
@@ -12,6 +14,7 @@ import (
 	influxlogger "github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/mock"
 	platformtesting "github.com/influxdata/platform/testing"
+	"go.uber.org/zap"
 )
 
 func TestScheduler(t *testing.T) {
@@ -46,7 +49,7 @@ func TestScheduler(t *testing.T) {
 	})
 
 	scheduler, err := NewScheduler(10, logger,
-		storage, publisher, subscriber, time.Millisecond, time.Microsecond)
+		storage, publisher, subscriber, time.Millisecond, time.Second)
 
 	go func() {
 		err = scheduler.run(ctx)
@@ -95,3 +98,71 @@ const sampleRespSmall = `
 # TYPE go_goroutines gauge
 go_goroutines 36
 `
+
+type countingPublisher struct {
+	targets []platform.ScraperTarget
+}
+
+func (p *countingPublisher) Publish(subject string, r io.Reader) error {
+	var target platform.ScraperTarget
+	if err := json.NewDecoder(r).Decode(&target); err != nil {
+		return err
+	}
+	p.targets = append(p.targets, target)
+	return nil
+}
+
+func TestScheduler_requestDueScrapes(t *testing.T) {
+	fast := platformtesting.MustIDBase16("3a0d0a6365646120")
+	slow := platformtesting.MustIDBase16("3a0d0a6365646121")
+	storage := &mockStorage{
+		Targets: []platform.ScraperTarget{
+			{
+				ID:   fast,
+				Type: platform.PrometheusScraperType,
+			},
+			{
+				ID:       slow,
+				Type:     platform.PrometheusScraperType,
+				Interval: time.Minute,
+				Timeout:  5 * time.Second,
+			},
+		},
+	}
+	publisher := new(countingPublisher)
+	s := &Scheduler{
+		Targets:    storage,
+		Interval:   10 * time.Second,
+		Timeout:    time.Second,
+		Publisher:  publisher,
+		Logger:     zap.NewNop(),
+		lastScrape: make(map[platform.ID]time.Time),
+	}
+
+	now := time.Unix(0, 0)
+	ctx := context.Background()
+	for i := 0; i < 7; i++ {
+		s.requestDueScrapes(ctx, now.Add(time.Duration(i)*10*time.Second))
+	}
+
+	counts := make(map[platform.ID]int)
+	for _, target := range publisher.targets {
+		counts[target.ID]++
+		switch target.ID {
+		case fast:
+			if target.Timeout != time.Second {
+				t.Errorf("expected default timeout to be used, got %v", target.Timeout)
+			}
+		case slow:
+			if target.Timeout != 5*time.Second {
+				t.Errorf("expected target timeout to be kept, got %v", target.Timeout)
+			}
+		}
+	}
+	if counts[fast] != 7 {
+		t.Errorf("expected target with default interval to be scraped 7 times, got %d", counts[fast])
+	}
+	if counts[slow] != 2 {
+		t.Errorf("expected target with 1m interval to be scraped 2 times, got %d", counts[slow])
+	}
+}
@@ -10,3 +10,14 @@ import (
 type Scraper interface {
 	Gather(ctx context.Context, target platform.ScraperTarget) (ms []Metrics, err error)
 }
+
+// newScrapers returns a scraper for each supported scraper type.
+// The scrapers share the clients of clients.
+func newScrapers(secrets *SecretLoader, clients *ClientCache) map[platform.ScraperType]Scraper {
+	return map[platform.ScraperType]Scraper{
+		platform.PrometheusScraperType:  &prometheusScraper{Secrets: secrets, Clients: clients},
+		platform.InfluxScraperType:      &influxScraper{Secrets: secrets, Clients: clients},
+		platform.JSONScraperType:        &jsonScraper{Secrets: secrets, Clients: clients},
+		platform.OpenMetricsScraperType: &openMetricsScraper{Secrets: secrets, Clients: clients},
+	}
+}
@@ -11,7 +11,7 @@ import (
 // Storage stores the metrics of a time based.
 type Storage interface {
 	//Subscriber nats.Subscriber
-	Record([]Metrics) error
+	Record(MetricsCollection) error
 }
 
 // StorageHandler implements nats.Handler interface.
@@ -23,13 +23,13 @@ type StorageHandler struct {
 // Process consumes job queue, and use storage to record.
 func (h *StorageHandler) Process(s nats.Subscription, m nats.Message) {
 	defer m.Ack()
-	ms := make([]Metrics, 0)
-	err := json.Unmarshal(m.Data(), &ms)
+	collected := new(MetricsCollection)
+	err := json.Unmarshal(m.Data(), collected)
 	if err != nil {
 		h.Logger.Error(fmt.Sprintf("storage handler process err: %v", err))
 		return
 	}
-	err = h.Storage.Record(ms)
+	err = h.Storage.Record(*collected)
 	if err != nil {
 		h.Logger.Error(fmt.Sprintf("storage handler store err: %v", err))
 	}
@@ -5,9 +5,11 @@ import (
 	"strings"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
 	"github.com/influxdata/platform/chronograf/server"
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/task"
 	"go.uber.org/zap"
 )
 
@@ -25,10 +27,14 @@ type APIHandler struct {
 	MacroHandler         *MacroHandler
 	TaskHandler          *TaskHandler
 	TelegrafHandler      *TelegrafHandler
+	ScraperHandler       *ScraperHandler
 	QueryHandler         *FluxHandler
 	WriteHandler         *WriteHandler
+	DeleteHandler        *DeleteHandler
+	BackupHandler        *BackupHandler
 	SetupHandler         *SetupHandler
 	SessionHandler       *SessionHandler
+	OAuth2Handler        *OAuth2Handler
 }
 
 // APIBackend is all services and associated parameters required to construct
@@ -40,15 +46,23 @@ type APIBackend struct {
 	NewQueryService  func(*platform.Source) (query.ProxyQueryService, error)
 
 	PointsWriter                    storage.PointsWriter
+	MaxWriteBodySize                int64
+	MaxWritePointsPerRequest        int
+	MaxConcurrentWrites             int
+	DeleteService                   platform.DeleteService
+	BackupService                   platform.BackupService
 	AuthorizationService            platform.AuthorizationService
+	AuthorizationUsageRecorder      platform.AuthorizationUsageRecorder
 	BucketService                   platform.BucketService
 	SessionService                  platform.SessionService
 	UserService                     platform.UserService
+	OAuth2IdentityService           platform.OAuth2IdentityService
 	OrganizationService             platform.OrganizationService
 	UserResourceMappingService      platform.UserResourceMappingService
 	DashboardService                platform.DashboardService
 	DashboardOperationLogService    platform.DashboardOperationLogService
 	BucketOperationLogService       platform.BucketOperationLogService
+	BucketSchemaService             platform.BucketSchemaService
 	UserOperationLogService         platform.UserOperationLogService
 	OrganizationOperationLogService platform.OrganizationOperationLogService
 	ViewService                     platform.ViewService
@@ -60,43 +74,108 @@ type APIBackend struct {
 	TaskService                     platform.TaskService
 	TelegrafService                 platform.TelegrafConfigStore
 	ScraperTargetStoreService       platform.ScraperTargetStoreService
+	SecretService                   platform.SecretService
+	LimitService                    platform.LimitService
+	LimitEnforcer                   platform.LimitEnforcer
+	UsageService                    platform.UsageService
+	UsageRecorder                   platform.UsageRecorder
 	ChronografService               *server.Service
+
+	// OAuth2Providers are the providers users may sign in with besides
+	// their password.
+	OAuth2Providers []OAuth2Provider
+	// OAuth2CreateUsers creates users signing in with an OAuth2 provider
+	// for the first time.
+	OAuth2CreateUsers bool
+	// OAuth2OrgMappings put users signing in with an OAuth2 provider into
+	// organizations.
+	OAuth2OrgMappings []OAuth2OrgMapping
+
+	// CertificateAuthorizations map the subjects of verified client
+	// certificates to the authorizations of their requests.
+	CertificateAuthorizations map[string]platform.ID
 }
 
-// NewAPIHandler constructs all api handlers beneath it and returns an APIHandler
+// NewAPIHandler constructs all api handlers beneath it and returns an APIHandler.
+// The services of the resource handlers are wrapped so that every request is
+// checked against the permissions of its authorizer. The write, delete and
+// query handlers check the permissions of their buckets themselves, and the
+// backup handler requires permission to read every resource.
 func NewAPIHandler(b *APIBackend) *APIHandler {
 	h := &APIHandler{}
+
+	authorizationService := authorizer.NewAuthorizationService(b.AuthorizationService)
+	bucketService := authorizer.NewBucketService(b.BucketService)
+	userService := authorizer.NewUserService(b.UserService, b.UserResourceMappingService)
+	orgService := authorizer.NewOrgService(b.OrganizationService)
+	dashboardService := authorizer.NewDashboardService(b.DashboardService)
+	viewService := authorizer.NewViewService(b.ViewService)
+	macroService := authorizer.NewMacroService(b.MacroService)
+	urmService := authorizer.NewUserResourceMappingService(b.UserResourceMappingService, &authorizer.ResourceOrgFinder{
+		BucketService:             b.BucketService,
+		DashboardService:          b.DashboardService,
+		ViewService:               b.ViewService,
+		TaskService:               b.TaskService,
+		TelegrafConfigStore:       b.TelegrafService,
+		ScraperTargetStoreService: b.ScraperTargetStoreService,
+	})
+	telegrafService := authorizer.NewTelegrafConfigStore(b.TelegrafService, urmService)
+	limitService := authorizer.NewLimitService(b.LimitService)
+	usageService := authorizer.NewUsageService(b.UsageService)
+	secretService := authorizer.NewSecretService(b.SecretService)
+	taskService := task.NewValidator(b.TaskService)
+
 	h.SessionHandler = NewSessionHandler()
 	h.SessionHandler.BasicAuthService = b.BasicAuthService
 	h.SessionHandler.SessionService = b.SessionService
 	h.SessionHandler.Logger = b.Logger.With(zap.String("handler", "basicAuth"))
 
-	h.BucketHandler = NewBucketHandler(b.UserResourceMappingService)
-	h.BucketHandler.BucketService = b.BucketService
+	h.OAuth2Handler = NewOAuth2Handler(&OAuth2Authenticator{
+		Logger:                     b.Logger.With(zap.String("handler", "oauth2")),
+		UserService:                b.UserService,
+		SessionService:             b.SessionService,
+		OrganizationService:        b.OrganizationService,
+		UserResourceMappingService: b.UserResourceMappingService,
+		OAuth2IdentityService:      b.OAuth2IdentityService,
+		CreateUsers:                b.OAuth2CreateUsers,
+		OrgMappings:                b.OAuth2OrgMappings,
+	})
+	h.OAuth2Handler.Logger = b.Logger.With(zap.String("handler", "oauth2"))
+	for _, p := range b.OAuth2Providers {
+		h.OAuth2Handler.AddProvider(p)
+	}
+
+	h.BucketHandler = NewBucketHandler(urmService)
+	h.BucketHandler.BucketService = bucketService
 	h.BucketHandler.BucketOperationLogService = b.BucketOperationLogService
+	h.BucketHandler.BucketSchemaService = b.BucketSchemaService
 
-	h.OrgHandler = NewOrgHandler(b.UserResourceMappingService)
-	h.OrgHandler.OrganizationService = b.OrganizationService
-	h.OrgHandler.BucketService = b.BucketService
+	h.OrgHandler = NewOrgHandler(urmService)
+	h.OrgHandler.OrganizationService = orgService
+	h.OrgHandler.BucketService = bucketService
 	h.OrgHandler.OrganizationOperationLogService = b.OrganizationOperationLogService
+	h.OrgHandler.SecretService = secretService
+	h.OrgHandler.LimitService = limitService
+	h.OrgHandler.UsageService = usageService
 
 	h.UserHandler = NewUserHandler()
-	h.UserHandler.UserService = b.UserService
+	h.UserHandler.UserService = userService
 	h.UserHandler.BasicAuthService = b.BasicAuthService
+	h.UserHandler.OAuth2IdentityService = authorizer.NewOAuth2IdentityService(b.OAuth2IdentityService)
 	h.UserHandler.UserOperationLogService = b.UserOperationLogService
 
-	h.DashboardHandler = NewDashboardHandler(b.UserResourceMappingService)
-	h.DashboardHandler.DashboardService = b.DashboardService
+	h.DashboardHandler = NewDashboardHandler(urmService)
+	h.DashboardHandler.DashboardService = dashboardService
 	h.DashboardHandler.DashboardOperationLogService = b.DashboardOperationLogService
 
-	h.ViewHandler = NewViewHandler(b.UserResourceMappingService)
-	h.ViewHandler.ViewService = b.ViewService
+	h.ViewHandler = NewViewHandler(urmService)
+	h.ViewHandler.ViewService = viewService
 
 	h.MacroHandler = NewMacroHandler()
-	h.MacroHandler.MacroService = b.MacroService
+	h.MacroHandler.MacroService = macroService
 
 	h.AuthorizationHandler = NewAuthorizationHandler()
-	h.AuthorizationHandler.AuthorizationService = b.AuthorizationService
+	h.AuthorizationHandler.AuthorizationService = authorizationService
 	h.AuthorizationHandler.Logger = b.Logger.With(zap.String("handler", "auth"))
 
 	h.SourceHandler = NewSourceHandler()
@@ -107,28 +186,49 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.SetupHandler = NewSetupHandler()
 	h.SetupHandler.OnboardingService = b.OnboardingService
 
-	h.TaskHandler = NewTaskHandler(b.UserResourceMappingService, b.Logger)
-	h.TaskHandler.TaskService = b.TaskService
+	h.TaskHandler = NewTaskHandler(urmService, b.Logger)
+	h.TaskHandler.TaskService = taskService
 	h.TaskHandler.AuthorizationService = b.AuthorizationService
-	h.TaskHandler.UserResourceMappingService = b.UserResourceMappingService
+	h.TaskHandler.UserResourceMappingService = urmService
 
 	h.TelegrafHandler = NewTelegrafHandler(
 		b.Logger.With(zap.String("handler", "telegraf")),
-		b.UserResourceMappingService,
-		b.TelegrafService,
+		urmService,
+		telegrafService,
 	)
 
+	h.ScraperHandler = NewScraperHandler(urmService)
+	h.ScraperHandler.ScraperStorageService = authorizer.NewScraperTargetStoreService(b.ScraperTargetStoreService, b.BucketService)
+	h.ScraperHandler.BucketService = bucketService
+
 	h.WriteHandler = NewWriteHandler(b.PointsWriter)
 	h.WriteHandler.AuthorizationService = b.AuthorizationService
 	h.WriteHandler.OrganizationService = b.OrganizationService
 	h.WriteHandler.BucketService = b.BucketService
 	h.WriteHandler.Logger = b.Logger.With(zap.String("handler", "write"))
+	h.WriteHandler.MaxBodySize = b.MaxWriteBodySize
+	h.WriteHandler.MaxPointsPerRequest = b.MaxWritePointsPerRequest
+	h.WriteHandler.MaxConcurrentWrites = b.MaxConcurrentWrites
+	h.WriteHandler.LimitEnforcer = b.LimitEnforcer
+	h.WriteHandler.UsageRecorder = b.UsageRecorder
+
+	h.DeleteHandler = NewDeleteHandler(b.DeleteService)
+	h.DeleteHandler.AuthorizationService = b.AuthorizationService
+	h.DeleteHandler.OrganizationService = b.OrganizationService
+	h.DeleteHandler.BucketService = b.BucketService
+	h.DeleteHandler.Logger = b.Logger.With(zap.String("handler", "delete"))
+
+	h.BackupHandler = NewBackupHandler(b.BackupService)
+	h.BackupHandler.AuthorizationService = b.AuthorizationService
+	h.BackupHandler.Logger = b.Logger.With(zap.String("handler", "backup"))
 
 	h.QueryHandler = NewFluxHandler()
 	h.QueryHandler.AuthorizationService = b.AuthorizationService
 	h.QueryHandler.OrganizationService = b.OrganizationService
 	h.QueryHandler.Logger = b.Logger.With(zap.String("handler", "query"))
 	h.QueryHandler.ProxyQueryService = b.ProxyQueryService
+	h.QueryHandler.LimitEnforcer = b.LimitEnforcer
+	h.QueryHandler.UsageRecorder = b.UsageRecorder
 
 	h.ChronografHandler = NewChronografHandler(b.ChronografService)
 
@@ -138,11 +238,14 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 var apiLinks = map[string]interface{}{
 	"signin":         "/api/v2/signin",
 	"signout":        "/api/v2/signout",
+	"oauth":          "/api/v2/oauth",
 	"setup":          "/api/v2/setup",
 	"sources":        "/api/v2/sources",
 	"dashboards":     "/api/v2/dashboards",
 	"views":          "/api/v2/views",
 	"write":          "/api/v2/write",
+	"delete":         "/api/v2/delete",
+	"backup":         "/api/v2/backup",
 	"orgs":           "/api/v2/orgs",
 	"authorizations": "/api/v2/authorizations",
 	"buckets":        "/api/v2/buckets",
@@ -151,6 +254,7 @@ var apiLinks = map[string]interface{}{
 	"tasks":          "/api/v2/tasks",
 	"macros":         "/api/v2/macros",
 	"telegrafs":      "/api/v2/telegrafs",
+	"scrapers":       "/api/v2/scrapers",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
@@ -194,6 +298,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, oauth2Path) {
+		h.OAuth2Handler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/setup") {
 		h.SetupHandler.ServeHTTP(w, r)
 		return
@@ -204,6 +313,16 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/delete") {
+		h.DeleteHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, backupPath) {
+		h.BackupHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/query") {
 		h.QueryHandler.ServeHTTP(w, r)
 		return
@@ -254,6 +373,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/scrapers") {
+		h.ScraperHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/views") {
 		h.ViewHandler.ServeHTTP(w, r)
 		return
@@ -83,8 +83,6 @@ func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r
 		return
 	}
 
-	// TODO: Need to do some validation of req.Authorization.Permissions
-
 	if err := h.AuthorizationService.CreateAuthorization(ctx, req.Authorization); err != nil {
 		// Don't log here, it should already be handled by the service
 		EncodeError(ctx, err, w)
@@ -108,6 +106,19 @@ func decodePostAuthorizationRequest(ctx context.Context, r *http.Request) (*post
 		return nil, err
 	}
 
+	// The creation and last used times are set by the service.
+	a.CreatedAt, a.LastUsedAt = nil, nil
+
+	for _, p := range a.Permissions {
+		if err := p.Valid(); err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid permission %s", p),
+				Err:  err,
+			}
+		}
+	}
+
 	return &postAuthorizationRequest{
 		Authorization: a,
 	}, nil
@@ -2,8 +2,11 @@ package http
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/influxdata/platform"
 	platcontext "github.com/influxdata/platform/context"
@@ -18,6 +21,14 @@ type AuthenticationHandler struct {
 	AuthorizationService platform.AuthorizationService
 	SessionService       platform.SessionService
 
+	// AuthorizationUsageRecorder, when set, records each use of a token.
+	AuthorizationUsageRecorder platform.AuthorizationUsageRecorder
+
+	// CertificateAuthorizations map the subjects of verified client
+	// certificates to the authorizations of the requests made with them
+	// without a token or session.
+	CertificateAuthorizations map[string]platform.ID
+
 	// This is only really used for it's lookup method the specific http
 	// hanlder used to register routes does not matter.
 	noAuthRouter *httprouter.Router
@@ -41,24 +52,54 @@ func (h *AuthenticationHandler) RegisterNoAuthRoute(method, path string) {
 }
 
 const (
-	tokenAuthScheme   = "token"
-	sessionAuthScheme = "session"
+	tokenAuthScheme       = "token"
+	sessionAuthScheme     = "session"
+	certificateAuthScheme = "certificate"
 )
 
-// ProbeAuthScheme probes the http request for the requests for token or cookie session.
+// ProbeAuthScheme probes the http request for the requests for token or cookie session,
+// or else a verified client certificate.
 func ProbeAuthScheme(r *http.Request) (string, error) {
 	_, tokenErr := GetToken(r)
 	_, sessErr := decodeCookieSession(r.Context(), r)
 
-	if tokenErr != nil && sessErr != nil {
-		return "", fmt.Errorf("token required")
-	}
-
 	if tokenErr == nil {
 		return tokenAuthScheme, nil
 	}
 
-	return sessionAuthScheme, nil
+	if sessErr == nil {
+		return sessionAuthScheme, nil
+	}
+
+	if clientCertificate(r) != nil {
+		return certificateAuthScheme, nil
+	}
+
+	return "", fmt.Errorf("token required")
+}
+
+// clientCertificate returns the verified certificate of the client of r, if any.
+func clientCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return r.TLS.VerifiedChains[0][0]
+}
+
+// ParseCertificateAuthorization parses a mapping of a client certificate
+// subject to an authorization, of the form <authorization id>:<subject>.
+// The subject is in the form of RFC 2253, such as CN=telegraf,O=Acme.
+func ParseCertificateAuthorization(s string) (string, platform.ID, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", 0, fmt.Errorf("invalid certificate authorization %q; expected <authorization id>:<subject>", s)
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(parts[0]); err != nil {
+		return "", 0, fmt.Errorf("invalid certificate authorization %q: %v", s, err)
+	}
+	return parts[1], id, nil
 }
 
 // ServeHTTP extracts the session or token from the http request and places the resulting authorizer on the request context.
@@ -97,6 +138,14 @@ func (h *AuthenticationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		r = r.WithContext(ctx)
 		h.Handler.ServeHTTP(w, r)
 		return
+	case certificateAuthScheme:
+		ctx, err = h.extractCertificateAuthorization(ctx, r)
+		if err != nil {
+			break
+		}
+		r = r.WithContext(ctx)
+		h.Handler.ServeHTTP(w, r)
+		return
 	}
 
 	ForbiddenError(ctx, fmt.Errorf("unauthorized"), w)
@@ -119,6 +168,37 @@ func (h *AuthenticationHandler) extractAuthorization(ctx context.Context, r *htt
 		return ctx, err
 	}
 
+	if err := a.Expired(); err != nil {
+		return ctx, err
+	}
+
+	if h.AuthorizationUsageRecorder != nil {
+		h.AuthorizationUsageRecorder.RecordAuthorizationUsage(ctx, a.ID, time.Now())
+	}
+
+	return platcontext.SetAuthorizer(ctx, a), nil
+}
+
+func (h *AuthenticationHandler) extractCertificateAuthorization(ctx context.Context, r *http.Request) (context.Context, error) {
+	subject := clientCertificate(r).Subject.String()
+	id, ok := h.CertificateAuthorizations[subject]
+	if !ok {
+		return ctx, fmt.Errorf("no authorization for certificate %q", subject)
+	}
+
+	a, err := h.AuthorizationService.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return ctx, err
+	}
+
+	if err := a.Expired(); err != nil {
+		return ctx, err
+	}
+
+	if h.AuthorizationUsageRecorder != nil {
+		h.AuthorizationUsageRecorder.RecordAuthorizationUsage(ctx, a.ID, time.Now())
+	}
+
 	return platcontext.SetAuthorizer(ctx, a), nil
 }
 
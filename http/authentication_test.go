@@ -2,10 +2,14 @@ package http_test
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/influxdata/platform"
 	platformhttp "github.com/influxdata/platform/http"
@@ -14,12 +18,14 @@ import (
 
 func TestAuthenticationHandler(t *testing.T) {
 	type fields struct {
-		AuthorizationService platform.AuthorizationService
-		SessionService       platform.SessionService
+		AuthorizationService      platform.AuthorizationService
+		SessionService            platform.SessionService
+		CertificateAuthorizations map[string]platform.ID
 	}
 	type args struct {
-		token   string
-		session string
+		token       string
+		session     string
+		certificate string
 	}
 	type wants struct {
 		code int
@@ -82,6 +88,24 @@ func TestAuthenticationHandler(t *testing.T) {
 				code: http.StatusOK,
 			},
 		},
+		{
+			name: "token expired",
+			fields: fields{
+				AuthorizationService: &mock.AuthorizationService{
+					FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+						expiresAt := time.Now().Add(-time.Hour)
+						return &platform.Authorization{ExpiresAt: &expiresAt}, nil
+					},
+				},
+				SessionService: mock.NewSessionService(),
+			},
+			args: args{
+				token: "abc123",
+			},
+			wants: wants{
+				code: http.StatusForbidden,
+			},
+		},
 		{
 			name: "token does not exist",
 			fields: fields{
@@ -99,6 +123,41 @@ func TestAuthenticationHandler(t *testing.T) {
 				code: http.StatusForbidden,
 			},
 		},
+		{
+			name: "certificate mapped to an authorization",
+			fields: fields{
+				AuthorizationService: &mock.AuthorizationService{
+					FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+						if id != 1 {
+							return nil, fmt.Errorf("authorization not found")
+						}
+						return &platform.Authorization{ID: id}, nil
+					},
+				},
+				SessionService:            mock.NewSessionService(),
+				CertificateAuthorizations: map[string]platform.ID{"CN=telegraf": 1},
+			},
+			args: args{
+				certificate: "telegraf",
+			},
+			wants: wants{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "certificate without an authorization",
+			fields: fields{
+				AuthorizationService:      mock.NewAuthorizationService(),
+				SessionService:            mock.NewSessionService(),
+				CertificateAuthorizations: map[string]platform.ID{"CN=telegraf": 1},
+			},
+			args: args{
+				certificate: "other",
+			},
+			wants: wants{
+				code: http.StatusForbidden,
+			},
+		},
 		{
 			name: "no auth provided",
 			fields: fields{
@@ -121,11 +180,20 @@ func TestAuthenticationHandler(t *testing.T) {
 			h := platformhttp.NewAuthenticationHandler()
 			h.AuthorizationService = tt.fields.AuthorizationService
 			h.SessionService = tt.fields.SessionService
+			h.CertificateAuthorizations = tt.fields.CertificateAuthorizations
 			h.Handler = handler
 
 			w := httptest.NewRecorder()
 			r := httptest.NewRequest("POST", "http://any.url", nil)
 
+			if tt.args.certificate != "" {
+				r.TLS = &tls.ConnectionState{
+					VerifiedChains: [][]*x509.Certificate{{
+						{Subject: pkix.Name{CommonName: tt.args.certificate}},
+					}},
+				}
+			}
+
 			if tt.args.session != "" {
 				platformhttp.SetCookieSession(tt.args.session, r)
 			}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const backupPath = "/api/v2/backup"
+
+// BackupHandler receives requests to back up the instance.
+type BackupHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	AuthorizationService platform.AuthorizationService
+	BackupService        platform.BackupService
+}
+
+// NewBackupHandler creates a new handler at /api/v2/backup to back up the
+// instance.
+func NewBackupHandler(backupService platform.BackupService) *BackupHandler {
+	h := &BackupHandler{
+		Router:        httprouter.New(),
+		Logger:        zap.NewNop(),
+		BackupService: backupService,
+	}
+
+	h.HandlerFunc("POST", backupPath, h.handleBackup)
+	return h
+}
+
+// handleBackup is the HTTP handler for the POST /api/v2/backup route.
+func (h *BackupHandler) handleBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	auth, err := h.AuthorizationService.FindAuthorizationByID(ctx, a.Identifier())
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// A backup holds every resource of the instance.
+	for _, t := range platform.AllResourceTypes {
+		if !auth.Allowed(platform.Permission{Action: platform.ReadAction, Resource: platform.ResourceOfType(t)}) {
+			EncodeError(ctx, errors.Forbiddenf("insufficient permissions for backup"), w)
+			return
+		}
+	}
+
+	var since time.Time
+	if s := r.URL.Query().Get("since"); s != "" {
+		if since, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			EncodeError(ctx, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "invalid since",
+				Err:  err,
+			}, w)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.WriteHeader(http.StatusOK)
+
+	// Errors can't be reported once the archive is being written, the
+	// client sees a truncated archive instead.
+	if err := h.BackupService.Backup(ctx, w, since); err != nil {
+		h.Logger.Info("Failed to back up", zap.Error(err))
+	}
+}
+
+// BackupService backs up an instance over HTTP.
+type BackupService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.BackupService = (*BackupService)(nil)
+
+// Backup writes a backup of the instance to w as a tar archive.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer, since time.Time) error {
+	u, err := newURL(s.Addr, backupPath)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	if !since.IsZero() {
+		params := req.URL.Query()
+		params.Set("since", since.UTC().Format(time.RFC3339Nano))
+		req.URL.RawQuery = params.Encode()
+	}
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
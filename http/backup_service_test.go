@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+)
+
+func TestBackupHandler_handleBackup(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		query       string
+		wantStatus  int
+		wantSince   time.Time
+		wantBody    string
+	}{
+		{
+			name:        "full backup",
+			permissions: platform.OperPermissions(),
+			wantStatus:  http.StatusOK,
+			wantBody:    "backup",
+		},
+		{
+			name:        "incremental backup",
+			permissions: platform.OperPermissions(),
+			query:       "?since=2018-10-01T00:00:00Z",
+			wantStatus:  http.StatusOK,
+			wantSince:   time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantBody:    "backup",
+		},
+		{
+			name:        "invalid since",
+			permissions: platform.OperPermissions(),
+			query:       "?since=yesterday",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "organization permissions only",
+			permissions: platform.OrgPermissions(1),
+			wantStatus:  http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var since *time.Time
+			h := NewBackupHandler(&mock.BackupService{
+				BackupF: func(ctx context.Context, w io.Writer, s time.Time) error {
+					since = &s
+					_, err := w.Write([]byte("backup"))
+					return err
+				},
+			})
+			h.AuthorizationService = &mock.AuthorizationService{
+				FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+					return &platform.Authorization{
+						ID:          id,
+						Status:      platform.Active,
+						Permissions: tt.permissions,
+					}, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", backupPath+tt.query, nil)
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{ID: 3}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Code; got != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, got, w.Header().Get(ErrorHeader))
+			}
+			if tt.wantStatus != http.StatusOK {
+				if since != nil {
+					t.Errorf("expected no backup, got one since %v", *since)
+				}
+				return
+			}
+			if since == nil || !since.Equal(tt.wantSince) {
+				t.Errorf("expected backup since %v, got %v", tt.wantSince, since)
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("expected body %q, got %q", tt.wantBody, got)
+			}
+		})
+	}
+}
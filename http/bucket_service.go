@@ -22,6 +22,7 @@ type BucketHandler struct {
 
 	BucketService              platform.BucketService
 	BucketOperationLogService  platform.BucketOperationLogService
+	BucketSchemaService        platform.BucketSchemaService
 	UserResourceMappingService platform.UserResourceMappingService
 }
 
@@ -29,6 +30,7 @@ const (
 	bucketsPath            = "/api/v2/buckets"
 	bucketsIDPath          = "/api/v2/buckets/:id"
 	bucketsIDLogPath       = "/api/v2/buckets/:id/log"
+	bucketsIDSchemaPath    = "/api/v2/buckets/:id/schema"
 	bucketsIDMembersPath   = "/api/v2/buckets/:id/members"
 	bucketsIDMembersIDPath = "/api/v2/buckets/:id/members/:userID"
 	bucketsIDOwnersPath    = "/api/v2/buckets/:id/owners"
@@ -46,6 +48,7 @@ func NewBucketHandler(mappingService platform.UserResourceMappingService) *Bucke
 	h.HandlerFunc("GET", bucketsPath, h.handleGetBuckets)
 	h.HandlerFunc("GET", bucketsIDPath, h.handleGetBucket)
 	h.HandlerFunc("GET", bucketsIDLogPath, h.handleGetBucketLog)
+	h.HandlerFunc("GET", bucketsIDSchemaPath, h.handleGetBucketSchema)
 	h.HandlerFunc("PATCH", bucketsIDPath, h.handlePatchBucket)
 	h.HandlerFunc("DELETE", bucketsIDPath, h.handleDeleteBucket)
 
@@ -304,6 +307,38 @@ func decodeGetBucketRequest(ctx context.Context, r *http.Request) (*getBucketReq
 	return req, nil
 }
 
+// handleGetBucketSchema is the HTTP handler for the GET /api/v2/buckets/:id/schema route.
+func (h *BucketHandler) handleGetBucketSchema(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetBucketRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.BucketService.FindBucketByID(ctx, req.BucketID)
+	if err != nil {
+		// TODO(desa): fix this when using real errors library
+		if strings.Contains(err.Error(), "not found") {
+			err = errors.New(err.Error(), errors.NotFound)
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	schema, err := h.BucketSchemaService.FindBucketSchema(ctx, b.OrganizationID, b.ID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, schema); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
 // handleDeleteBucket is the HTTP handler for the DELETE /api/v2/buckets/:id route.
 func (h *BucketHandler) handleDeleteBucket(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -507,6 +542,38 @@ func (s *BucketService) FindBucketByID(ctx context.Context, id platform.ID) (*pl
 	return br.toPlatform()
 }
 
+// FindBucketSchema returns the schema of the bucket bucketID. The organization
+// of the bucket is resolved by the server, so orgID is not sent.
+func (s *BucketService) FindBucketSchema(ctx context.Context, orgID, bucketID platform.ID) (*platform.BucketSchema, error) {
+	u, err := newURL(s.Addr, bucketIDSchemaPath(bucketID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var schema platform.BucketSchema
+	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
 // FindBucket returns the first bucket that matches filter.
 func (s *BucketService) FindBucket(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
 	bs, n, err := s.FindBuckets(ctx, filter)
@@ -691,6 +758,10 @@ func bucketIDPath(id platform.ID) string {
 	return path.Join(bucketPath, id.String())
 }
 
+func bucketIDSchemaPath(id platform.ID) string {
+	return path.Join(bucketPath, id.String(), "schema")
+}
+
 // hanldeGetBucketLog retrieves a bucket log by the buckets ID.
 func (h *BucketHandler) handleGetBucketLog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -60,10 +60,8 @@ func NewService(addr, token string) *Service {
 
 // Shared transports for all clients to prevent leaking connections
 var (
-	skipVerifyTransport = &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
-	defaultTransport = &http.Transport{}
+	skipVerifyTransport = newTransport(&tls.Config{InsecureSkipVerify: true})
+	defaultTransport    = &http.Transport{}
 )
 
 func newURL(addr, path string) (*url.URL, error) {
@@ -83,10 +83,11 @@ func (d dashboardResponse) toPlatform() *platform.Dashboard {
 		cells = append(cells, d.Cells[i].toPlatform())
 	}
 	return &platform.Dashboard{
-		ID:    d.ID,
-		Name:  d.Name,
-		Meta:  d.Meta,
-		Cells: cells,
+		ID:             d.ID,
+		OrganizationID: d.OrganizationID,
+		Name:           d.Name,
+		Meta:           d.Meta,
+		Cells:          cells,
 	}
 }
 
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/kit/errors"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// DeleteHandler receives requests to delete data from a bucket.
+type DeleteHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	AuthorizationService platform.AuthorizationService
+	BucketService        platform.BucketService
+	OrganizationService  platform.OrganizationService
+
+	DeleteService platform.DeleteService
+}
+
+const (
+	deletePath = "/api/v2/delete"
+)
+
+// NewDeleteHandler creates a new handler at /api/v2/delete to delete data.
+func NewDeleteHandler(deleteService platform.DeleteService) *DeleteHandler {
+	h := &DeleteHandler{
+		Router:        httprouter.New(),
+		Logger:        zap.NewNop(),
+		DeleteService: deleteService,
+	}
+
+	h.HandlerFunc("POST", deletePath, h.handleDelete)
+	return h
+}
+
+// handleDelete is the HTTP handler for the POST /api/v2/delete route.
+func (h *DeleteHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	auth, err := h.AuthorizationService.FindAuthorizationByID(ctx, a.Identifier())
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req, err := decodeDeleteRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	logger := h.Logger.With(zap.String("org", req.Org), zap.String("bucket", req.Bucket))
+
+	org, bucket, err := findOrgBucket(ctx, h.OrganizationService, h.BucketService, logger, req.Org, req.Bucket)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if !auth.Allowed(platform.NewPermission(platform.WriteAction, platform.BucketResourceType, bucket.OrganizationID, bucket.ID)) {
+		EncodeError(ctx, errors.Forbiddenf("insufficient permissions for delete"), w)
+		return
+	}
+
+	if err := h.DeleteService.DeleteBucketRangePredicate(ctx, org.ID, bucket.ID, req.Start.UnixNano(), req.Stop.UnixNano(), req.Predicate); err != nil {
+		logger.Info("Failed to delete data", zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type deleteRequest struct {
+	Start     time.Time `json:"start"`
+	Stop      time.Time `json:"stop"`
+	Predicate string    `json:"predicate,omitempty"`
+}
+
+type postDeleteRequest struct {
+	Org    string
+	Bucket string
+	deleteRequest
+}
+
+func decodeDeleteRequest(ctx context.Context, r *http.Request) (*postDeleteRequest, error) {
+	qp := r.URL.Query()
+	req := &postDeleteRequest{
+		Org:    qp.Get("org"),
+		Bucket: qp.Get("bucket"),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req.deleteRequest); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid delete request",
+			Err:  err,
+		}
+	}
+
+	if req.Start.IsZero() || req.Stop.IsZero() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "start and stop are required",
+		}
+	}
+	if !req.Stop.After(req.Start) {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "stop must be after start",
+		}
+	}
+
+	return req, nil
+}
+
+// DeleteService deletes data from buckets over HTTP.
+type DeleteService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.DeleteService = (*DeleteService)(nil)
+
+// DeleteBucketRangePredicate deletes the data of bucketID with a time in the
+// range [start, stop) of the series matching predicate.
+func (s *DeleteService) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID platform.ID, start, stop int64, predicate string) error {
+	u, err := newURL(s.Addr, deletePath)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(deleteRequest{
+		Start:     time.Unix(0, start).UTC(),
+		Stop:      time.Unix(0, stop).UTC(),
+		Predicate: predicate,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	params := req.URL.Query()
+	params.Set("org", orgID.String())
+	params.Set("bucket", bucketID.String())
+	req.URL.RawQuery = params.Encode()
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+)
+
+func TestDeleteHandler_handleDelete(t *testing.T) {
+	type deleteCall struct {
+		orgID, bucketID platform.ID
+		start, stop     int64
+		predicate       string
+	}
+
+	start := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	stop := time.Date(2018, 10, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		permissions []platform.Permission
+		body        string
+		wantStatus  int
+		wantCall    *deleteCall
+	}{
+		{
+			name:        "delete with predicate",
+			permissions: []platform.Permission{platform.WriteBucketPermission(2)},
+			body:        `{"start":"2018-10-01T00:00:00Z","stop":"2018-10-02T00:00:00Z","predicate":"_measurement = 'cpu'"}`,
+			wantStatus:  http.StatusNoContent,
+			wantCall: &deleteCall{
+				orgID:     1,
+				bucketID:  2,
+				start:     start.UnixNano(),
+				stop:      stop.UnixNano(),
+				predicate: "_measurement = 'cpu'",
+			},
+		},
+		{
+			name:        "read permission only",
+			permissions: []platform.Permission{platform.ReadBucketPermission(2)},
+			body:        `{"start":"2018-10-01T00:00:00Z","stop":"2018-10-02T00:00:00Z"}`,
+			wantStatus:  http.StatusForbidden,
+		},
+		{
+			name:        "stop before start",
+			permissions: []platform.Permission{platform.WriteBucketPermission(2)},
+			body:        `{"start":"2018-10-02T00:00:00Z","stop":"2018-10-01T00:00:00Z"}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "missing range",
+			permissions: []platform.Permission{platform.WriteBucketPermission(2)},
+			body:        `{"predicate":"_measurement = 'cpu'"}`,
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var call *deleteCall
+			h := NewDeleteHandler(&mock.DeleteService{
+				DeleteBucketRangePredicateF: func(ctx context.Context, orgID, bucketID platform.ID, start, stop int64, predicate string) error {
+					call = &deleteCall{orgID, bucketID, start, stop, predicate}
+					return nil
+				},
+			})
+			h.AuthorizationService = &mock.AuthorizationService{
+				FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+					return &platform.Authorization{
+						ID:          id,
+						Status:      platform.Active,
+						Permissions: tt.permissions,
+					}, nil
+				},
+			}
+			h.OrganizationService = &mock.OrganizationService{
+				FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+					return &platform.Organization{ID: id}, nil
+				},
+			}
+			h.BucketService = &mock.BucketService{
+				FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+					return &platform.Bucket{ID: *filter.ID, OrganizationID: *filter.OrganizationID}, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", deletePath+"?org=0000000000000001&bucket=0000000000000002", strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{ID: 3}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Code; got != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, got, w.Header().Get(ErrorHeader))
+			}
+			if tt.wantCall == nil {
+				if call != nil {
+					t.Errorf("expected no delete, got %+v", call)
+				}
+				return
+			}
+			if call == nil || *call != *tt.wantCall {
+				t.Errorf("expected delete %+v, got %+v", tt.wantCall, call)
+			}
+		})
+	}
+}
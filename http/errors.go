@@ -35,6 +35,16 @@ type AuthzError interface {
 	AuthzError() error
 }
 
+// asAuthzError returns the AuthzError of err, looking through a wrapping
+// platform.Error.
+func asAuthzError(err error) (AuthzError, bool) {
+	if pe, ok := err.(*platform.Error); ok {
+		err = pe.Err
+	}
+	e, ok := err.(AuthzError)
+	return e, ok
+}
+
 // CheckErrorStatus for status and any error in the response.
 func CheckErrorStatus(code int, res *http.Response, isPlatformError ...bool) error {
 	err := CheckError(res)
@@ -179,11 +189,12 @@ func statusCode(e kerrors.Error) int {
 
 // statusCodePlatformError is the map convert platform.Error to error
 var statusCodePlatformError = map[string]int{
-	platform.EInternal:    http.StatusInternalServerError,
-	platform.EInvalid:     http.StatusBadRequest,
-	platform.EEmptyValue:  http.StatusBadRequest,
-	platform.EConflict:    http.StatusUnprocessableEntity,
-	platform.ENotFound:    http.StatusNotFound,
-	platform.EUnavailable: http.StatusServiceUnavailable,
-	platform.EForbidden:   http.StatusForbidden,
+	platform.EInternal:        http.StatusInternalServerError,
+	platform.EInvalid:         http.StatusBadRequest,
+	platform.EEmptyValue:      http.StatusBadRequest,
+	platform.EConflict:        http.StatusUnprocessableEntity,
+	platform.ENotFound:        http.StatusNotFound,
+	platform.EUnavailable:     http.StatusServiceUnavailable,
+	platform.EForbidden:       http.StatusForbidden,
+	platform.ETooManyRequests: http.StatusTooManyRequests,
 }
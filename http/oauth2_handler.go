@@ -0,0 +1,345 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf"
+	"github.com/influxdata/platform/chronograf/oauth2"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const oauth2Path = "/api/v2/oauth"
+
+// OAuth2Provider is an OAuth2 provider users may sign in with.
+type OAuth2Provider struct {
+	Provider oauth2.Provider
+	// Tokens creates and validates the OAuth2 state of logins.
+	Tokens oauth2.Tokenizer
+	// UseIDToken reads the user from the OpenID id_token of the provider
+	// instead of requesting it from the provider.
+	UseIDToken bool
+}
+
+// OAuth2OrgMapping puts users signing in with an OAuth2 provider into an
+// organization based on the groups, organizations or email domains the
+// provider reports for them.
+type OAuth2OrgMapping struct {
+	// Provider is the name of the provider, or "*" for every provider.
+	Provider string
+	// Group is the group, organization or domain of the user at the
+	// provider, or "*" for every user.
+	Group string
+	// Organization is the name of the organization the user is put in.
+	Organization string
+	// UserType is the type of the user in the organization.
+	UserType platform.UserType
+}
+
+// ParseOAuth2OrgMapping parses a mapping of the form
+// <provider>:<group>:<organization>[:<owner|member>]. Users are members of
+// the organization when no user type is given.
+func ParseOAuth2OrgMapping(s string) (OAuth2OrgMapping, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 3 || len(parts) > 4 {
+		return OAuth2OrgMapping{}, fmt.Errorf("invalid oauth2 org mapping %q; expected <provider>:<group>:<organization>[:<owner|member>]", s)
+	}
+
+	m := OAuth2OrgMapping{
+		Provider:     parts[0],
+		Group:        parts[1],
+		Organization: parts[2],
+		UserType:     platform.Member,
+	}
+	if len(parts) == 4 {
+		m.UserType = platform.UserType(parts[3])
+	}
+
+	if m.Provider == "" || m.Group == "" || m.Organization == "" {
+		return OAuth2OrgMapping{}, fmt.Errorf("invalid oauth2 org mapping %q; provider, group and organization are required", s)
+	}
+	if m.UserType != platform.Owner && m.UserType != platform.Member {
+		return OAuth2OrgMapping{}, fmt.Errorf("invalid oauth2 org mapping %q; unknown user type %q", s, m.UserType)
+	}
+	return m, nil
+}
+
+// matches returns true if the mapping applies to the principal p.
+func (m OAuth2OrgMapping) matches(p oauth2.Principal) bool {
+	if m.Provider != "*" && m.Provider != p.Issuer {
+		return false
+	}
+	if m.Group == "*" {
+		return true
+	}
+	for _, g := range strings.Split(p.Group, ",") {
+		if g == m.Group {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuth2Authenticator signs users in with a platform session once an OAuth2
+// provider authenticated them. It is the authenticator of the chronograf
+// OAuth2 muxes, so that their providers can be reused by the platform.
+type OAuth2Authenticator struct {
+	Logger *zap.Logger
+
+	UserService                platform.UserService
+	SessionService             platform.SessionService
+	OrganizationService        platform.OrganizationService
+	UserResourceMappingService platform.UserResourceMappingService
+	// OAuth2IdentityService finds the users linked to the identities of
+	// principals by their issuer and subject.
+	OAuth2IdentityService platform.OAuth2IdentityService
+
+	// CreateUsers creates a user for principals without one. Only users
+	// whose identity was linked to them may sign in otherwise.
+	CreateUsers bool
+	// OrgMappings put users into organizations when they sign in.
+	OrgMappings []OAuth2OrgMapping
+}
+
+var _ oauth2.Authenticator = (*OAuth2Authenticator)(nil)
+
+// Validate returns the principal of the session of the request.
+func (a *OAuth2Authenticator) Validate(ctx context.Context, r *http.Request) (oauth2.Principal, error) {
+	k, err := decodeCookieSession(ctx, r)
+	if err != nil {
+		return oauth2.Principal{}, oauth2.ErrAuthentication
+	}
+
+	s, err := a.SessionService.FindSession(ctx, k)
+	if err != nil {
+		return oauth2.Principal{}, oauth2.ErrAuthentication
+	}
+
+	return oauth2.Principal{
+		Subject:   s.UserID.String(),
+		IssuedAt:  s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+	}, nil
+}
+
+// Authorize signs in the user of the principal p, creating the user if
+// needed, and sets the cookie of the new session on w.
+func (a *OAuth2Authenticator) Authorize(ctx context.Context, w http.ResponseWriter, p oauth2.Principal) error {
+	u, err := a.findOrCreateUser(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	if err := a.mapOrgs(ctx, u, p); err != nil {
+		return err
+	}
+
+	s, err := a.SessionService.CreateSession(ctx, u.Name)
+	if err != nil {
+		return err
+	}
+
+	encodeCookieSession(w, s)
+	return nil
+}
+
+// Extend returns the principal p unchanged, as sessions are not extended.
+func (a *OAuth2Authenticator) Extend(ctx context.Context, w http.ResponseWriter, p oauth2.Principal) (oauth2.Principal, error) {
+	return p, nil
+}
+
+// Expire removes the session cookie. Sessions themselves are expired by
+// signing out.
+func (a *OAuth2Authenticator) Expire(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:    cookieSessionName,
+		Value:   "",
+		Expires: time.Unix(0, 0),
+		MaxAge:  -1,
+	})
+}
+
+// findOrCreateUser returns the user linked to the identity of the principal
+// p. Identities are never linked to an existing user implicitly, as the
+// subject of an identity may be the name of a user it does not belong to,
+// so a user is only created when no user has the name of the subject.
+func (a *OAuth2Authenticator) findOrCreateUser(ctx context.Context, p oauth2.Principal) (*platform.User, error) {
+	i, err := a.OAuth2IdentityService.FindOAuth2Identity(ctx, p.Issuer, p.Subject)
+	if err == nil {
+		return a.UserService.FindUserByID(ctx, i.UserID)
+	}
+	if platform.ErrorCode(err) != platform.ENotFound || !a.CreateUsers {
+		return nil, err
+	}
+
+	name := p.Subject
+	if _, err := a.UserService.FindUser(ctx, platform.UserFilter{Name: &name}); err == nil {
+		a.Logger.Info("refusing to sign in oauth2 identity of an existing user", zap.String("issuer", p.Issuer), zap.String("user", name))
+		return nil, &platform.Error{
+			Code: platform.EConflict,
+			Msg:  fmt.Sprintf("user %q already exists; its oauth2 identity must be linked to it explicitly", name),
+		}
+	} else if platform.ErrorCode(err) != platform.ENotFound {
+		return nil, err
+	}
+
+	u := &platform.User{Name: name}
+	if err := a.UserService.CreateUser(ctx, u); err != nil {
+		return nil, err
+	}
+	if err := a.OAuth2IdentityService.CreateOAuth2Identity(ctx, &platform.OAuth2Identity{
+		Issuer:  p.Issuer,
+		Subject: p.Subject,
+		UserID:  u.ID,
+	}); err != nil {
+		return nil, err
+	}
+	a.Logger.Info("created user signing in with oauth2", zap.String("issuer", p.Issuer), zap.String("user", name))
+	return u, nil
+}
+
+// mapOrgs adds the user u to the organizations of the mappings matching p.
+func (a *OAuth2Authenticator) mapOrgs(ctx context.Context, u *platform.User, p oauth2.Principal) error {
+	// Services fail to find a mapping by both its resource and user if it
+	// does not exist, so the orgs of the user are found by user instead.
+	ms, _, err := a.UserResourceMappingService.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+		ResourceType: platform.OrgResourceType,
+		UserID:       u.ID,
+	})
+	if err != nil {
+		return err
+	}
+	mapped := make(map[platform.ID]bool, len(ms))
+	for _, m := range ms {
+		mapped[m.ResourceID] = true
+	}
+
+	for _, m := range a.OrgMappings {
+		if !m.matches(p) {
+			continue
+		}
+
+		name := m.Organization
+		o, err := a.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &name})
+		if err != nil {
+			return err
+		}
+		if mapped[o.ID] {
+			continue
+		}
+
+		if err := a.UserResourceMappingService.CreateUserResourceMapping(ctx, &platform.UserResourceMapping{
+			ResourceID:   o.ID,
+			ResourceType: platform.OrgResourceType,
+			UserID:       u.ID,
+			UserType:     m.UserType,
+		}); err != nil {
+			return err
+		}
+		mapped[o.ID] = true
+	}
+	return nil
+}
+
+// OAuth2Handler represents an HTTP API handler for signing in with OAuth2
+// providers.
+type OAuth2Handler struct {
+	*httprouter.Router
+	Logger *zap.Logger
+
+	Authenticator oauth2.Authenticator
+
+	providers []oauth2Route
+}
+
+type oauth2Route struct {
+	Name  string `json:"name"`
+	Login string `json:"login"`
+}
+
+// NewOAuth2Handler returns a new instance of OAuth2Handler.
+func NewOAuth2Handler(a oauth2.Authenticator) *OAuth2Handler {
+	h := &OAuth2Handler{
+		Router:        httprouter.New(),
+		Logger:        zap.NewNop(),
+		Authenticator: a,
+	}
+
+	h.HandlerFunc("GET", oauth2Path, h.handleGetProviders)
+	return h
+}
+
+// AddProvider adds the login and callback routes of the provider p. The
+// callback route is /api/v2/oauth/<name>/callback, which is where the
+// provider must redirect users to.
+func (h *OAuth2Handler) AddProvider(p OAuth2Provider) {
+	name := strings.ToLower(p.Provider.Name())
+	m := oauth2.NewAuthMux(p.Provider, h.Authenticator, p.Tokens, "", NewChronografLogger(h.Logger), p.UseIDToken)
+
+	login := path.Join(oauth2Path, name, "login")
+	h.Handler("GET", login, m.Login())
+	h.Handler("GET", path.Join(oauth2Path, name, "callback"), m.Callback())
+
+	h.providers = append(h.providers, oauth2Route{
+		Name:  p.Provider.Name(),
+		Login: (&url.URL{Path: login}).String(),
+	})
+}
+
+type oauth2ProvidersResponse struct {
+	Providers []oauth2Route `json:"providers"`
+}
+
+// handleGetProviders is the HTTP handler for the GET /api/v2/oauth route.
+func (h *OAuth2Handler) handleGetProviders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res := oauth2ProvidersResponse{
+		Providers: append([]oauth2Route{}, h.providers...),
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		h.Logger.Info("failed to encode response", zap.String("handler", "getOAuth2Providers"), zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// chronografLogger logs the messages of the chronograf OAuth2 muxes and
+// providers.
+type chronografLogger struct {
+	l *zap.SugaredLogger
+}
+
+// NewChronografLogger returns a chronograf logger writing to l.
+func NewChronografLogger(l *zap.Logger) chronograf.Logger {
+	return &chronografLogger{l.Sugar()}
+}
+
+func (l *chronografLogger) Debug(args ...interface{}) { l.l.Debug(args...) }
+func (l *chronografLogger) Info(args ...interface{})  { l.l.Info(args...) }
+func (l *chronografLogger) Error(args ...interface{}) { l.l.Error(args...) }
+
+func (l *chronografLogger) WithField(key string, value interface{}) chronograf.Logger {
+	return &chronografLogger{l.l.With(key, value)}
+}
+
+// Writer returns a writer logging each line written to it as an error.
+func (l *chronografLogger) Writer() *io.PipeWriter {
+	r, w := io.Pipe()
+	go func() {
+		s := bufio.NewScanner(r)
+		for s.Scan() {
+			l.l.Error(s.Text())
+		}
+	}()
+	return w
+}
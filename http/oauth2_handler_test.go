@@ -0,0 +1,259 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/chronograf/oauth2"
+	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
+	"go.uber.org/zap"
+)
+
+func TestOAuth2Authenticator_Authorize(t *testing.T) {
+	type fields struct {
+		CreateUsers bool
+		OrgMappings []string
+		// Users are the names of existing users.
+		Users []string
+		// Identities link the subjects of the principal's issuer to users.
+		Identities map[string]string
+	}
+	type wants struct {
+		err bool
+		// user is the user signed in, which defaults to the subject.
+		user     string
+		userType platform.UserType
+	}
+
+	tests := []struct {
+		name      string
+		fields    fields
+		principal oauth2.Principal
+		wants     wants
+	}{
+		{
+			name: "creates the user and puts it in the org of its group",
+			fields: fields{
+				CreateUsers: true,
+				OrgMappings: []string{"github:influxdata:myorg:owner"},
+			},
+			principal: oauth2.Principal{
+				Subject: "user@example.com",
+				Issuer:  "github",
+				Group:   "other,influxdata",
+			},
+			wants: wants{
+				userType: platform.Owner,
+			},
+		},
+		{
+			name: "mapping of every group",
+			fields: fields{
+				CreateUsers: true,
+				OrgMappings: []string{"*:*:myorg"},
+			},
+			principal: oauth2.Principal{
+				Subject: "user@example.com",
+				Issuer:  "google",
+				Group:   "example.com",
+			},
+			wants: wants{
+				userType: platform.Member,
+			},
+		},
+		{
+			name: "mapping of another provider",
+			fields: fields{
+				CreateUsers: true,
+				OrgMappings: []string{"google:influxdata:myorg"},
+			},
+			principal: oauth2.Principal{
+				Subject: "user@example.com",
+				Issuer:  "github",
+				Group:   "influxdata",
+			},
+		},
+		{
+			name: "unknown user",
+			principal: oauth2.Principal{
+				Subject: "user@example.com",
+				Issuer:  "github",
+			},
+			wants: wants{
+				err: true,
+			},
+		},
+		{
+			name: "identity linked to a user",
+			fields: fields{
+				Users:      []string{"alice"},
+				Identities: map[string]string{"user@example.com": "alice"},
+			},
+			principal: oauth2.Principal{
+				Subject: "user@example.com",
+				Issuer:  "github",
+			},
+			wants: wants{
+				user: "alice",
+			},
+		},
+		{
+			name: "existing user with the name of the subject",
+			fields: fields{
+				CreateUsers: true,
+				Users:       []string{"user@example.com"},
+			},
+			principal: oauth2.Principal{
+				Subject: "user@example.com",
+				Issuer:  "github",
+			},
+			wants: wants{
+				err: true,
+			},
+		},
+		{
+			name: "identity of another issuer",
+			fields: fields{
+				Users:      []string{"alice"},
+				Identities: map[string]string{"user@example.com": "alice"},
+			},
+			principal: oauth2.Principal{
+				Subject: "user@example.com",
+				Issuer:  "google",
+			},
+			wants: wants{
+				err: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := inmem.NewService()
+			org := &platform.Organization{Name: "myorg"}
+			if err := svc.CreateOrganization(ctx, org); err != nil {
+				t.Fatalf("failed to create org: %v", err)
+			}
+
+			for _, name := range tt.fields.Users {
+				if err := svc.CreateUser(ctx, &platform.User{Name: name}); err != nil {
+					t.Fatalf("failed to create user: %v", err)
+				}
+			}
+			for subject, name := range tt.fields.Identities {
+				u, err := svc.FindUser(ctx, platform.UserFilter{Name: &name})
+				if err != nil {
+					t.Fatalf("failed to find user: %v", err)
+				}
+				if err := svc.CreateOAuth2Identity(ctx, &platform.OAuth2Identity{Issuer: "github", Subject: subject, UserID: u.ID}); err != nil {
+					t.Fatalf("failed to link oauth2 identity: %v", err)
+				}
+			}
+
+			var mappings []OAuth2OrgMapping
+			for _, s := range tt.fields.OrgMappings {
+				m, err := ParseOAuth2OrgMapping(s)
+				if err != nil {
+					t.Fatalf("failed to parse org mapping: %v", err)
+				}
+				mappings = append(mappings, m)
+			}
+
+			var sessionUser string
+			sessions := mock.NewSessionService()
+			sessions.CreateSessionFn = func(ctx context.Context, user string) (*platform.Session, error) {
+				sessionUser = user
+				return &platform.Session{Key: "abc123"}, nil
+			}
+
+			a := &OAuth2Authenticator{
+				Logger:                     zap.NewNop(),
+				UserService:                svc,
+				SessionService:             sessions,
+				OrganizationService:        svc,
+				UserResourceMappingService: svc,
+				OAuth2IdentityService:      svc,
+				CreateUsers:                tt.fields.CreateUsers,
+				OrgMappings:                mappings,
+			}
+
+			w := httptest.NewRecorder()
+			err := a.Authorize(ctx, w, tt.principal)
+			if (err != nil) != tt.wants.err {
+				t.Fatalf("expected error %v got %v", tt.wants.err, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := w.Result().Cookies(); len(got) != 1 || got[0].Value != "abc123" {
+				t.Errorf("expected the session cookie to be set, got %v", got)
+			}
+
+			name := tt.wants.user
+			if name == "" {
+				name = tt.principal.Subject
+			}
+			if sessionUser != name {
+				t.Errorf("expected a session of user %q got %q", name, sessionUser)
+			}
+			u, err := svc.FindUser(ctx, platform.UserFilter{Name: &name})
+			if err != nil {
+				t.Fatalf("failed to find user: %v", err)
+			}
+
+			ms, _, err := svc.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+				ResourceType: platform.OrgResourceType,
+				UserID:       u.ID,
+			})
+			if err != nil {
+				t.Fatalf("failed to find user resource mappings: %v", err)
+			}
+			var userType platform.UserType
+			for _, m := range ms {
+				if m.ResourceID == org.ID {
+					userType = m.UserType
+				}
+			}
+			if userType != tt.wants.userType {
+				t.Errorf("expected user type %q in the org got %q", tt.wants.userType, userType)
+			}
+		})
+	}
+}
+
+func TestParseOAuth2OrgMapping(t *testing.T) {
+	tests := []struct {
+		s    string
+		want OAuth2OrgMapping
+		err  bool
+	}{
+		{
+			s:    "github:influxdata:myorg",
+			want: OAuth2OrgMapping{Provider: "github", Group: "influxdata", Organization: "myorg", UserType: platform.Member},
+		},
+		{
+			s:    "*:example.com:myorg:owner",
+			want: OAuth2OrgMapping{Provider: "*", Group: "example.com", Organization: "myorg", UserType: platform.Owner},
+		},
+		{s: "github:influxdata", err: true},
+		{s: "github::myorg", err: true},
+		{s: "github:influxdata:myorg:admin", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseOAuth2OrgMapping(tt.s)
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error %v got %v", tt.err, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v got %+v", tt.want, got)
+			}
+		})
+	}
+}
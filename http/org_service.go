@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"sort"
 	"strconv"
 
 	"github.com/influxdata/platform"
@@ -22,16 +23,23 @@ type OrgHandler struct {
 	OrganizationOperationLogService platform.OrganizationOperationLogService
 	BucketService                   platform.BucketService
 	UserResourceMappingService      platform.UserResourceMappingService
+	SecretService                   platform.SecretService
+	LimitService                    platform.LimitService
+	UsageService                    platform.UsageService
 }
 
 const (
-	organizationsPath            = "/api/v2/orgs"
-	organizationsIDPath          = "/api/v2/orgs/:id"
-	organizationsIDLogPath       = "/api/v2/orgs/:id/log"
-	organizationsIDMembersPath   = "/api/v2/orgs/:id/members"
-	organizationsIDMembersIDPath = "/api/v2/orgs/:id/members/:organizationID"
-	organizationsIDOwnersPath    = "/api/v2/orgs/:id/owners"
-	organizationsIDOwnersIDPath  = "/api/v2/orgs/:id/owners/:organizationID"
+	organizationsPath                = "/api/v2/orgs"
+	organizationsIDPath              = "/api/v2/orgs/:id"
+	organizationsIDLogPath           = "/api/v2/orgs/:id/log"
+	organizationsIDMembersPath       = "/api/v2/orgs/:id/members"
+	organizationsIDMembersIDPath     = "/api/v2/orgs/:id/members/:organizationID"
+	organizationsIDOwnersPath        = "/api/v2/orgs/:id/owners"
+	organizationsIDOwnersIDPath      = "/api/v2/orgs/:id/owners/:organizationID"
+	organizationsIDSecretsPath       = "/api/v2/orgs/:id/secrets"
+	organizationsIDSecretsDeletePath = "/api/v2/orgs/:id/secrets/delete"
+	organizationsIDLimitsPath        = "/api/v2/orgs/:id/limits"
+	organizationsIDUsagePath         = "/api/v2/orgs/:id/usage"
 )
 
 // NewOrgHandler returns a new instance of OrgHandler.
@@ -56,6 +64,14 @@ func NewOrgHandler(mappingService platform.UserResourceMappingService) *OrgHandl
 	h.HandlerFunc("GET", organizationsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Owner))
 	h.HandlerFunc("DELETE", organizationsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
+	h.HandlerFunc("GET", organizationsIDSecretsPath, h.handleGetSecrets)
+	h.HandlerFunc("PATCH", organizationsIDSecretsPath, h.handlePatchSecrets)
+	h.HandlerFunc("POST", organizationsIDSecretsDeletePath, h.handleDeleteSecrets)
+
+	h.HandlerFunc("GET", organizationsIDLimitsPath, h.handleGetLimits)
+	h.HandlerFunc("PATCH", organizationsIDLimitsPath, h.handlePatchLimits)
+	h.HandlerFunc("GET", organizationsIDUsagePath, h.handleGetOrgUsage)
+
 	return h
 }
 
@@ -96,6 +112,8 @@ func newOrgResponse(o *platform.Organization) *orgResponse {
 			"self":       fmt.Sprintf("/api/v2/orgs/%s", o.ID),
 			"log":        fmt.Sprintf("/api/v2/orgs/%s/log", o.ID),
 			"members":    fmt.Sprintf("/api/v2/orgs/%s/members", o.ID),
+			"limits":     fmt.Sprintf("/api/v2/orgs/%s/limits", o.ID),
+			"usage":      fmt.Sprintf("/api/v2/orgs/%s/usage", o.ID),
 			"buckets":    fmt.Sprintf("/api/v2/buckets?org=%s", o.Name),
 			"tasks":      fmt.Sprintf("/api/v2/tasks?org=%s", o.Name),
 			"dashboards": fmt.Sprintf("/api/v2/dashboards?org=%s", o.Name),
@@ -580,3 +598,271 @@ func newOrganizationLogResponse(id platform.ID, es []*platform.OperationLogEntry
 		Log: log,
 	}
 }
+
+type secretsResponse struct {
+	Links   map[string]string `json:"links"`
+	Secrets []string          `json:"secrets"`
+}
+
+func newSecretsResponse(orgID platform.ID, ks []string) *secretsResponse {
+	return &secretsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/orgs/%s/secrets", orgID),
+			"org":  fmt.Sprintf("/api/v2/orgs/%s", orgID),
+		},
+		Secrets: ks,
+	}
+}
+
+// handleGetSecrets is the HTTP handler for the GET /api/v2/orgs/:id/secrets route.
+// Only the keys of the secrets are returned, never their values.
+func (h *OrgHandler) handleGetSecrets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetSecretsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	ks, err := h.SecretService.GetSecretKeys(ctx, req.orgID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newSecretsResponse(req.orgID, ks)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type getSecretsRequest struct {
+	orgID platform.ID
+}
+
+func decodeGetSecretsRequest(ctx context.Context, r *http.Request) (*getSecretsRequest, error) {
+	orgID, err := decodeOrgIDParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &getSecretsRequest{
+		orgID: orgID,
+	}, nil
+}
+
+// handlePatchSecrets is the HTTP handler for the PATCH /api/v2/orgs/:id/secrets route.
+// The body is a JSON object of the secret keys and values to store.
+func (h *OrgHandler) handlePatchSecrets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePatchSecretsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	for _, k := range req.keys {
+		if err := h.SecretService.PutSecret(ctx, req.orgID, k, req.secrets[k]); err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type patchSecretsRequest struct {
+	orgID   platform.ID
+	keys    []string
+	secrets map[string]string
+}
+
+func decodePatchSecretsRequest(ctx context.Context, r *http.Request) (*patchSecretsRequest, error) {
+	orgID, err := decodeOrgIDParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &patchSecretsRequest{
+		orgID: orgID,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.secrets); err != nil {
+		return nil, kerrors.Wrap(err, "invalid secrets", kerrors.InvalidData)
+	}
+
+	req.keys = make([]string, 0, len(req.secrets))
+	for k := range req.secrets {
+		if k == "" {
+			return nil, kerrors.InvalidDataf("secret key must not be empty")
+		}
+		req.keys = append(req.keys, k)
+	}
+	sort.Strings(req.keys)
+
+	return req, nil
+}
+
+// handleDeleteSecrets is the HTTP handler for the POST /api/v2/orgs/:id/secrets/delete route.
+func (h *OrgHandler) handleDeleteSecrets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeDeleteSecretsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.SecretService.DeleteSecret(ctx, req.orgID, req.Secrets...); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type deleteSecretsRequest struct {
+	orgID   platform.ID
+	Secrets []string `json:"secrets"`
+}
+
+func decodeDeleteSecretsRequest(ctx context.Context, r *http.Request) (*deleteSecretsRequest, error) {
+	orgID, err := decodeOrgIDParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &deleteSecretsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, kerrors.Wrap(err, "invalid secrets", kerrors.InvalidData)
+	}
+	req.orgID = orgID
+
+	return req, nil
+}
+
+// handleGetLimits is the HTTP handler for the GET /api/v2/orgs/:id/limits route.
+func (h *OrgHandler) handleGetLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := decodeOrgIDParam(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	l, err := h.LimitService.FindLimits(ctx, orgID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newLimitsResponse(l)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handlePatchLimits is the HTTP handler for the PATCH /api/v2/orgs/:id/limits route.
+func (h *OrgHandler) handlePatchLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePatchLimitsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	l, err := h.LimitService.UpdateLimits(ctx, req.orgID, req.Update)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newLimitsResponse(l)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+type limitsResponse struct {
+	Links map[string]string `json:"links"`
+	platform.Limits
+}
+
+func newLimitsResponse(l *platform.Limits) *limitsResponse {
+	return &limitsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/orgs/%s/limits", l.OrganizationID),
+			"org":  fmt.Sprintf("/api/v2/orgs/%s", l.OrganizationID),
+		},
+		Limits: *l,
+	}
+}
+
+type patchLimitsRequest struct {
+	orgID  platform.ID
+	Update platform.LimitsUpdate
+}
+
+func decodePatchLimitsRequest(ctx context.Context, r *http.Request) (*patchLimitsRequest, error) {
+	orgID, err := decodeOrgIDParam(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &patchLimitsRequest{
+		orgID: orgID,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req.Update); err != nil {
+		return nil, kerrors.Wrap(err, "invalid limits", kerrors.InvalidData)
+	}
+
+	return req, nil
+}
+
+// handleGetOrgUsage is the HTTP handler for the GET /api/v2/orgs/:id/usage route.
+// It takes the same parameters as GET /api/v2/usage, except for the organization.
+func (h *OrgHandler) handleGetOrgUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orgID, err := decodeOrgIDParam(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req, err := decodeGetUsageRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	req.filter.OrgID = &orgID
+
+	u, err := h.UsageService.GetUsage(ctx, req.filter)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, u); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// decodeOrgIDParam returns the organization ID of the :id url parameter.
+func decodeOrgIDParam(ctx context.Context) (platform.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return platform.InvalidID(), kerrors.InvalidDataf("url missing id")
+	}
+
+	var i platform.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return platform.InvalidID(), err
+	}
+	return i, nil
+}
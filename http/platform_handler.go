@@ -27,10 +27,15 @@ func NewPlatformHandler(b *APIBackend) *PlatformHandler {
 	h.Handler = NewAPIHandler(b)
 	h.AuthorizationService = b.AuthorizationService
 	h.SessionService = b.SessionService
+	h.AuthorizationUsageRecorder = b.AuthorizationUsageRecorder
+	h.CertificateAuthorizations = b.CertificateAuthorizations
 
 	h.RegisterNoAuthRoute("GET", "/api/v2")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signin")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signout")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth/:provider/login")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth/:provider/callback")
 	h.RegisterNoAuthRoute("POST", "/api/v2/setup")
 	h.RegisterNoAuthRoute("GET", "/api/v2/setup")
 
@@ -39,6 +39,13 @@ type FluxHandler struct {
 	AuthorizationService platform.AuthorizationService
 	OrganizationService  platform.OrganizationService
 	ProxyQueryService    query.ProxyQueryService
+
+	// LimitEnforcer enforces the query limits of organizations. Queries are
+	// not limited when it is nil.
+	LimitEnforcer platform.LimitEnforcer
+	// UsageRecorder records the usage of the queries of organizations.
+	// Usage is not recorded when it is nil.
+	UsageRecorder platform.UsageRecorder
 }
 
 // NewFluxHandler returns a new handler at /api/v2/query for flux queries.
@@ -83,6 +90,17 @@ func (h *FluxHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	orgID := req.Request.OrganizationID
+	if h.LimitEnforcer != nil {
+		release, err := h.LimitEnforcer.AcquireQuery(ctx, orgID)
+		if err != nil {
+			w.Header().Set("Retry-After", "1")
+			EncodeError(ctx, err, w)
+			return
+		}
+		defer release()
+	}
+
 	hd, ok := req.Dialect.(HTTPDialect)
 	if !ok {
 		EncodeError(ctx, fmt.Errorf("unsupported dialect over HTTP %T", req.Dialect), w)
@@ -91,6 +109,11 @@ func (h *FluxHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	hd.SetHeaders(w)
 
 	n, err := h.ProxyQueryService.Query(ctx, w, req)
+	if h.UsageRecorder != nil {
+		now := h.Now()
+		h.UsageRecorder.RecordUsage(ctx, orgID, platform.InvalidID(), platform.UsageQueryRequestCount, 1, now)
+		h.UsageRecorder.RecordUsage(ctx, orgID, platform.InvalidID(), platform.UsageQueryRequestBytes, float64(n), now)
+	}
 	if err != nil {
 		if n == 0 {
 			// Only record the error headers IFF nothing has been written to w.
@@ -16,27 +16,43 @@ import (
 // ScraperHandler represents an HTTP API handler for scraper targets.
 type ScraperHandler struct {
 	*httprouter.Router
-	ScraperStorageService platform.ScraperTargetStoreService
+	ScraperStorageService      platform.ScraperTargetStoreService
+	UserResourceMappingService platform.UserResourceMappingService
+	BucketService              platform.BucketService
 }
 
 const (
-	targetPath = "/api/v2/scrapertargets"
+	targetPath             = "/api/v2/scrapers"
+	targetsIDPath          = "/api/v2/scrapers/:id"
+	targetsIDMembersPath   = "/api/v2/scrapers/:id/members"
+	targetsIDMembersIDPath = "/api/v2/scrapers/:id/members/:userID"
+	targetsIDOwnersPath    = "/api/v2/scrapers/:id/owners"
+	targetsIDOwnersIDPath  = "/api/v2/scrapers/:id/owners/:userID"
 )
 
 // NewScraperHandler returns a new instance of ScraperHandler.
-func NewScraperHandler() *ScraperHandler {
+func NewScraperHandler(mappingService platform.UserResourceMappingService) *ScraperHandler {
 	h := &ScraperHandler{
-		Router: httprouter.New(),
+		Router:                     httprouter.New(),
+		UserResourceMappingService: mappingService,
 	}
 	h.HandlerFunc("POST", targetPath, h.handlePostScraperTarget)
 	h.HandlerFunc("GET", targetPath, h.handleGetScraperTargets)
-	h.HandlerFunc("GET", targetPath+"/:id", h.handleGetScraperTarget)
-	h.HandlerFunc("PATCH", targetPath+"/:id", h.handlePatchScraperTarget)
-	h.HandlerFunc("DELETE", targetPath+"/:id", h.handleDeleteScraperTarget)
+	h.HandlerFunc("GET", targetsIDPath, h.handleGetScraperTarget)
+	h.HandlerFunc("PATCH", targetsIDPath, h.handlePatchScraperTarget)
+	h.HandlerFunc("DELETE", targetsIDPath, h.handleDeleteScraperTarget)
+
+	h.HandlerFunc("POST", targetsIDMembersPath, newPostMemberHandler(h.UserResourceMappingService, platform.ScraperResourceType, platform.Member))
+	h.HandlerFunc("GET", targetsIDMembersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Member))
+	h.HandlerFunc("DELETE", targetsIDMembersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Member))
+
+	h.HandlerFunc("POST", targetsIDOwnersPath, newPostMemberHandler(h.UserResourceMappingService, platform.ScraperResourceType, platform.Owner))
+	h.HandlerFunc("GET", targetsIDOwnersPath, newGetMembersHandler(h.UserResourceMappingService, platform.Owner))
+	h.HandlerFunc("DELETE", targetsIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 	return h
 }
 
-// handlePostScraperTarget is HTTP handler for the POST /api/v2/scrapertargets route.
+// handlePostScraperTarget is HTTP handler for the POST /api/v2/scrapers route.
 func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -46,6 +62,11 @@ func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if err := h.findTargetBucket(ctx, req); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	if err := h.ScraperStorageService.AddTarget(ctx, req); err != nil {
 		EncodeError(ctx, err, w)
 		return
@@ -56,7 +77,7 @@ func (h *ScraperHandler) handlePostScraperTarget(w http.ResponseWriter, r *http.
 	}
 }
 
-// handleDeleteScraperTarget is the HTTP handler for the DELETE /api/v2/scrapertargets/:id route.
+// handleDeleteScraperTarget is the HTTP handler for the DELETE /api/v2/scrapers/:id route.
 func (h *ScraperHandler) handleDeleteScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -74,7 +95,7 @@ func (h *ScraperHandler) handleDeleteScraperTarget(w http.ResponseWriter, r *htt
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// handlePatchScraperTarget is the HTTP handler for the PATCH /api/v2/scrapertargets/:id route.
+// handlePatchScraperTarget is the HTTP handler for the PATCH /api/v2/scrapers/:id route.
 func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -84,6 +105,20 @@ func (h *ScraperHandler) handlePatchScraperTarget(w http.ResponseWriter, r *http
 		return
 	}
 
+	if !update.BucketID.Valid() && update.BucketName == "" {
+		// The target keeps writing to its bucket.
+		prev, err := h.ScraperStorageService.GetTargetByID(ctx, update.ID)
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+		update.OrgID, update.OrgName = prev.OrgID, prev.OrgName
+		update.BucketID, update.BucketName = prev.BucketID, prev.BucketName
+	} else if err := h.findTargetBucket(ctx, update); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
 	target, err := h.ScraperStorageService.UpdateTarget(ctx, update)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -116,7 +151,7 @@ func (h *ScraperHandler) handleGetScraperTarget(w http.ResponseWriter, r *http.R
 	}
 }
 
-// handleGetScraperTargets is the HTTP handler for the GET /api/v2/scrapertargets route.
+// handleGetScraperTargets is the HTTP handler for the GET /api/v2/scrapers route.
 func (h *ScraperHandler) handleGetScraperTargets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -132,6 +167,36 @@ func (h *ScraperHandler) handleGetScraperTargets(w http.ResponseWriter, r *http.
 	}
 }
 
+// findTargetBucket sets the organization and bucket of the target from its
+// bucket ID or, without one, from the names of its organization and bucket.
+func (h *ScraperHandler) findTargetBucket(ctx context.Context, t *platform.ScraperTarget) error {
+	var (
+		b   *platform.Bucket
+		err error
+	)
+	switch {
+	case t.BucketID.Valid():
+		b, err = h.BucketService.FindBucketByID(ctx, t.BucketID)
+	case t.OrgName != "" && t.BucketName != "":
+		b, err = h.BucketService.FindBucket(ctx, platform.BucketFilter{
+			Organization: &t.OrgName,
+			Name:         &t.BucketName,
+		})
+	default:
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "scraper target requires a bucket id, or an org and bucket name",
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	t.OrgID, t.OrgName = b.OrganizationID, b.Organization
+	t.BucketID, t.BucketName = b.ID, b.Name
+	return nil
+}
+
 func decodeScraperTargetUpdateRequest(ctx context.Context, r *http.Request) (
 	*platform.ScraperTarget, error) {
 	update := &platform.ScraperTarget{}
@@ -143,6 +208,9 @@ func decodeScraperTargetUpdateRequest(ctx context.Context, r *http.Request) (
 		return nil, err
 	}
 	update.ID = *id
+	if err := update.Validate(); err != nil {
+		return nil, kerrors.Wrap(err, "invalid scraper target", kerrors.InvalidData)
+	}
 	return update, nil
 }
 
@@ -151,6 +219,9 @@ func decodeScraperTargetAddRequest(ctx context.Context, r *http.Request) (*platf
 	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
 		return nil, err
 	}
+	if err := req.Validate(); err != nil {
+		return nil, kerrors.Wrap(err, "invalid scraper target", kerrors.InvalidData)
+	}
 	return req, nil
 }
 
@@ -292,6 +363,7 @@ func (s *ScraperService) AddTarget(ctx context.Context, target *platform.Scraper
 	if err := json.NewDecoder(resp.Body).Decode(targetResp); err != nil {
 		return err
 	}
+	*target = targetResp.ScraperTarget
 
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/inmem"
+	"github.com/influxdata/platform/mock"
 	platformtesting "github.com/influxdata/platform/testing"
 )
 
@@ -22,8 +23,13 @@ func initScraperService(f platformtesting.TargetFields, t *testing.T) (platform.
 		}
 	}
 
-	handler := NewScraperHandler()
+	handler := NewScraperHandler(mock.NewUserResourceMappingService())
 	handler.ScraperStorageService = svc
+	handler.BucketService = &mock.BucketService{
+		FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+			return &platform.Bucket{Organization: *filter.Organization, Name: *filter.Name}, nil
+		},
+	}
 	server := httptest.NewServer(handler)
 	client := ScraperService{
 		Addr: server.URL,
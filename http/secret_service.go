@@ -0,0 +1,126 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/influxdata/platform"
+)
+
+// SecretService connects to Influx via HTTP using tokens to manage the
+// secrets of organizations. Secret values are never returned by the API,
+// so they can be stored and deleted, but not loaded.
+type SecretService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.SecretService = (*SecretService)(nil)
+
+// LoadSecret always returns an error, as secret values can not be read over HTTP.
+func (s *SecretService) LoadSecret(ctx context.Context, orgID platform.ID, k string) (string, error) {
+	return "", fmt.Errorf("secret values can not be loaded over HTTP")
+}
+
+// GetSecretKeys returns the keys of the secrets of organization orgID.
+func (s *SecretService) GetSecretKeys(ctx context.Context, orgID platform.ID) ([]string, error) {
+	u, err := newURL(s.Addr, organizationSecretsPath(orgID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var sr secretsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&sr); err != nil {
+		return nil, err
+	}
+	return sr.Secrets, nil
+}
+
+// PutSecret stores the secret pair (k,v) for organization orgID.
+func (s *SecretService) PutSecret(ctx context.Context, orgID platform.ID, k, v string) error {
+	return s.PatchSecrets(ctx, orgID, map[string]string{k: v})
+}
+
+// PatchSecrets stores all the secret pairs of m for organization orgID.
+func (s *SecretService) PatchSecrets(ctx context.Context, orgID platform.ID, m map[string]string) error {
+	u, err := newURL(s.Addr, organizationSecretsPath(orgID))
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("PATCH", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckErrorStatus(http.StatusNoContent, resp)
+}
+
+// DeleteSecret removes the secrets with keys ks for organization orgID.
+func (s *SecretService) DeleteSecret(ctx context.Context, orgID platform.ID, ks ...string) error {
+	u, err := newURL(s.Addr, path.Join(organizationSecretsPath(orgID), "delete"))
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(deleteSecretsRequest{Secrets: ks})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckErrorStatus(http.StatusNoContent, resp)
+}
+
+func organizationSecretsPath(orgID platform.ID) string {
+	return path.Join(organizationIDPath(orgID), "secrets")
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+)
+
+// newInmemSecretService returns a mock SecretService storing secrets in m.
+func newInmemSecretService(m map[platform.ID]map[string]string) *mock.SecretService {
+	svc := mock.NewSecretService()
+	svc.GetSecretKeysFn = func(ctx context.Context, orgID platform.ID) ([]string, error) {
+		keys := []string{}
+		for k := range m[orgID] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys, nil
+	}
+	svc.PutSecretFn = func(ctx context.Context, orgID platform.ID, k, v string) error {
+		if m[orgID] == nil {
+			m[orgID] = make(map[string]string)
+		}
+		m[orgID][k] = v
+		return nil
+	}
+	svc.DeleteSecretFn = func(ctx context.Context, orgID platform.ID, ks ...string) error {
+		for _, k := range ks {
+			delete(m[orgID], k)
+		}
+		return nil
+	}
+	return svc
+}
+
+func TestSecretService(t *testing.T) {
+	secrets := map[platform.ID]map[string]string{
+		1: {"api_key": "abc123xyz"},
+		2: {"api_key": "zyx321cba"},
+	}
+
+	h := NewOrgHandler(mock.NewUserResourceMappingService())
+	h.SecretService = newInmemSecretService(secrets)
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	s := &SecretService{Addr: server.URL}
+	ctx := context.Background()
+
+	if err := s.PatchSecrets(ctx, 1, map[string]string{"password": "hunter2", "user": "admin"}); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := s.GetSecretKeys(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(keys, []string{"api_key", "password", "user"}); diff != "" {
+		t.Errorf("keys are different -got/+want\ndiff %s", diff)
+	}
+	if got, want := secrets[1]["password"], "hunter2"; got != want {
+		t.Errorf("unexpected secret value: got %q, want %q", got, want)
+	}
+
+	if err := s.DeleteSecret(ctx, 1, "api_key", "user"); err != nil {
+		t.Fatal(err)
+	}
+	keys, err = s.GetSecretKeys(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(keys, []string{"password"}); diff != "" {
+		t.Errorf("keys are different -got/+want\ndiff %s", diff)
+	}
+	if _, ok := secrets[2]["api_key"]; !ok {
+		t.Error("expected secrets of other organizations to be kept")
+	}
+
+	if _, err := s.LoadSecret(ctx, 1, "password"); err == nil {
+		t.Error("expected loading a secret value over HTTP to fail")
+	}
+}
+
+func TestOrgHandler_handleGetSecrets(t *testing.T) {
+	h := NewOrgHandler(mock.NewUserResourceMappingService())
+	h.SecretService = newInmemSecretService(map[platform.ID]map[string]string{
+		1: {"api_key": "abc123xyz"},
+	})
+
+	r := httptest.NewRequest("GET", "http://any.url/api/v2/orgs/0000000000000001/secrets", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Fatalf("unexpected status code: got %d, want %d", got, want)
+	}
+	body, _ := ioutil.ReadAll(w.Body)
+	if !strings.Contains(string(body), `"api_key"`) {
+		t.Errorf("expected secret key in response, got %s", body)
+	}
+	if strings.Contains(string(body), "abc123xyz") {
+		t.Errorf("secret value must not be returned, got %s", body)
+	}
+}
+
+func TestOrgHandler_handlePatchSecrets_invalidBody(t *testing.T) {
+	h := NewOrgHandler(mock.NewUserResourceMappingService())
+	h.SecretService = newInmemSecretService(map[platform.ID]map[string]string{})
+
+	r := httptest.NewRequest("PATCH", "http://any.url/api/v2/orgs/0000000000000001/secrets", strings.NewReader(`{"api_key": 1}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code < 400 || w.Code >= 500 {
+		t.Errorf("expected a client error, got status code %d", w.Code)
+	}
+}
@@ -43,6 +43,11 @@ const (
 	tasksIDRunsIDPath      = "/api/v2/tasks/:tid/runs/:rid"
 	tasksIDRunsIDLogsPath  = "/api/v2/tasks/:tid/runs/:rid/logs"
 	tasksIDRunsIDRetryPath = "/api/v2/tasks/:tid/runs/:rid/retry"
+	tasksIDManualRunsPath  = "/api/v2/tasks/:tid/manualruns"
+	tasksIDVersionsPath    = "/api/v2/tasks/:tid/versions"
+	tasksIDRollbackPath    = "/api/v2/tasks/:tid/versions/:version/rollback"
+	tasksIDNotifyPath      = "/api/v2/tasks/:tid/notifications"
+	tasksIDNotifyIDPath    = "/api/v2/tasks/:tid/notifications/:nid"
 )
 
 // NewTaskHandler returns a new instance of TaskHandler.
@@ -73,10 +78,21 @@ func NewTaskHandler(mappingService platform.UserResourceMappingService, logger *
 	h.HandlerFunc("DELETE", tasksIDOwnersIDPath, newDeleteMemberHandler(h.UserResourceMappingService, platform.Owner))
 
 	h.HandlerFunc("GET", tasksIDRunsPath, h.handleGetRuns)
+	h.HandlerFunc("POST", tasksIDRunsPath, h.handlePostRun)
 	h.HandlerFunc("GET", tasksIDRunsIDPath, h.handleGetRun)
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
+	h.HandlerFunc("GET", tasksIDManualRunsPath, h.handleGetManualRuns)
+	h.HandlerFunc("DELETE", tasksIDManualRunsPath, h.handleCancelManualRun)
+
+	h.HandlerFunc("GET", tasksIDVersionsPath, h.handleGetTaskVersions)
+	h.HandlerFunc("POST", tasksIDRollbackPath, h.handleRollbackTask)
+
+	h.HandlerFunc("GET", tasksIDNotifyPath, h.handleGetNotificationRules)
+	h.HandlerFunc("POST", tasksIDNotifyPath, h.handlePostNotificationRule)
+	h.HandlerFunc("DELETE", tasksIDNotifyIDPath, h.handleDeleteNotificationRule)
+
 	return h
 }
 
@@ -88,10 +104,13 @@ type taskResponse struct {
 func newTaskResponse(t platform.Task) taskResponse {
 	return taskResponse{
 		Links: map[string]string{
-			"self":    fmt.Sprintf("/api/v2/tasks/%s", t.ID),
-			"members": fmt.Sprintf("/api/v2/tasks/%s/members", t.ID),
-			"owners":  fmt.Sprintf("/api/v2/tasks/%s/owners", t.ID),
-			"runs":    fmt.Sprintf("/api/v2/tasks/%s/runs", t.ID),
+			"self":          fmt.Sprintf("/api/v2/tasks/%s", t.ID),
+			"members":       fmt.Sprintf("/api/v2/tasks/%s/members", t.ID),
+			"owners":        fmt.Sprintf("/api/v2/tasks/%s/owners", t.ID),
+			"runs":          fmt.Sprintf("/api/v2/tasks/%s/runs", t.ID),
+			"manualRuns":    fmt.Sprintf("/api/v2/tasks/%s/manualruns", t.ID),
+			"versions":      fmt.Sprintf("/api/v2/tasks/%s/versions", t.ID),
+			"notifications": fmt.Sprintf("/api/v2/tasks/%s/notifications", t.ID),
 		},
 		Task: t,
 	}
@@ -143,6 +162,86 @@ func newRunsResponse(rs []*platform.Run, taskID platform.ID) runsResponse {
 	}
 }
 
+type manualRunResponse struct {
+	Links     map[string]string  `json:"links"`
+	ManualRun platform.ManualRun `json:"manualRun"`
+}
+
+func newManualRunResponse(mr platform.ManualRun) manualRunResponse {
+	return manualRunResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/manualruns", mr.TaskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", mr.TaskID),
+			"runs": fmt.Sprintf("/api/v2/tasks/%s/runs", mr.TaskID),
+		},
+		ManualRun: mr,
+	}
+}
+
+type manualRunsResponse struct {
+	Links      map[string]string     `json:"links"`
+	ManualRuns []*platform.ManualRun `json:"manualRuns"`
+}
+
+func newManualRunsResponse(mrs []*platform.ManualRun, taskID platform.ID) manualRunsResponse {
+	return manualRunsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/manualruns", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		ManualRuns: mrs,
+	}
+}
+
+type taskVersionsResponse struct {
+	Links    map[string]string       `json:"links"`
+	Versions []*platform.TaskVersion `json:"versions"`
+}
+
+func newTaskVersionsResponse(tvs []*platform.TaskVersion, taskID platform.ID) taskVersionsResponse {
+	return taskVersionsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/versions", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Versions: tvs,
+	}
+}
+
+type notificationRuleResponse struct {
+	Links map[string]string `json:"links"`
+	platform.TaskNotificationRule
+}
+
+func newNotificationRuleResponse(r platform.TaskNotificationRule) notificationRuleResponse {
+	return notificationRuleResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/notifications/%s", r.TaskID, r.ID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", r.TaskID),
+		},
+		TaskNotificationRule: r,
+	}
+}
+
+type notificationRulesResponse struct {
+	Links map[string]string          `json:"links"`
+	Rules []notificationRuleResponse `json:"notifications"`
+}
+
+func newNotificationRulesResponse(rs []*platform.TaskNotificationRule, taskID platform.ID) notificationRulesResponse {
+	res := notificationRulesResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/notifications", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Rules: make([]notificationRuleResponse, 0, len(rs)),
+	}
+	for _, r := range rs {
+		res.Rules = append(res.Rules, newNotificationRuleResponse(*r))
+	}
+	return res
+}
+
 func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -223,7 +322,7 @@ func (h *TaskHandler) handlePostTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.TaskService.CreateTask(ctx, req.Task); err != nil {
-		if e, ok := err.(AuthzError); ok {
+		if e, ok := asAuthzError(err); ok {
 			h.logger.Error("failed authentication", zap.Errors("error messages", []error{err, e.AuthzError()}))
 		}
 		EncodeError(ctx, err, w)
@@ -697,211 +796,559 @@ func decodeRetryRunRequest(ctx context.Context, r *http.Request) (*retryRunReque
 	}, nil
 }
 
-// TaskService connects to Influx via HTTP using tokens to manage tasks.
-type TaskService struct {
-	Addr               string
-	Token              string
-	InsecureSkipVerify bool
-}
+func (h *TaskHandler) handlePostRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// FindTaskByID returns a single task
-func (t TaskService) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
-	u, err := newURL(t.Addr, taskIDPath(id))
+	req, err := decodePostRunRequest(ctx, r)
 	if err != nil {
-		return nil, err
+		EncodeError(ctx, err, w)
+		return
 	}
-
-	req, err := http.NewRequest("GET", u.String(), nil)
-	if err != nil {
-		return nil, err
+	if req.RequestedAt == nil {
+		now := time.Now().Unix()
+		req.RequestedAt = &now
 	}
-	SetToken(t.Token, req)
 
-	hc := newClient(u.Scheme, t.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	mr, err := h.TaskService.ManuallyRunTimeRange(ctx, req.TaskID, req.Start, req.End, *req.RequestedAt)
 	if err != nil {
-		return nil, err
+		EncodeError(ctx, err, w)
+		return
 	}
-	defer resp.Body.Close()
 
-	if err := CheckError(resp); err != nil {
-		if err.Error() == backend.ErrTaskNotFound.Error() {
-			// ErrTaskNotFound is expected as part of the FindTaskByID contract,
-			// so return that actual error instead of a different error that looks like it.
-			return nil, backend.ErrTaskNotFound
-		}
-		return nil, err
+	if err := encodeResponse(ctx, w, http.StatusCreated, newManualRunResponse(*mr)); err != nil {
+		EncodeError(ctx, err, w)
+		return
 	}
+}
 
-	var tr taskResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
-		return nil, err
-	}
+// manualRunRequest is the body of a request to run a task, either for every
+// schedule from Start to End, or for the single schedule ScheduledFor.
+type manualRunRequest struct {
+	Start        string `json:"start,omitempty"`
+	End          string `json:"end,omitempty"`
+	ScheduledFor string `json:"scheduledFor,omitempty"`
+}
 
-	return &tr.Task, nil
+type postRunRequest struct {
+	TaskID      platform.ID
+	Start, End  int64
+	RequestedAt *int64
 }
 
-// FindTasks returns a list of tasks that match a filter (limit 100) and the total count
-// of matching tasks.
-func (t TaskService) FindTasks(ctx context.Context, filter platform.TaskFilter) ([]*platform.Task, int, error) {
-	u, err := newURL(t.Addr, tasksPath)
-	if err != nil {
-		return nil, 0, err
+func decodePostRunRequest(ctx context.Context, r *http.Request) (*postRunRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
 	}
 
-	val := url.Values{}
-	if filter.After != nil {
-		val.Add("after", filter.After.String())
-	}
-	if filter.Organization != nil {
-		val.Add("organization", filter.Organization.String())
-	}
-	if filter.User != nil {
-		val.Add("user", filter.User.String())
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
 	}
 
-	u.RawQuery = val.Encode()
+	var mr manualRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&mr); err != nil {
+		return nil, err
+	}
 
-	req, err := http.NewRequest("GET", u.String(), nil)
-	if err != nil {
-		return nil, 0, err
+	start, end := mr.Start, mr.End
+	if mr.ScheduledFor != "" {
+		if start != "" || end != "" {
+			return nil, kerrors.InvalidDataf("you must provide either a start and end, or scheduledFor")
+		}
+		start, end = mr.ScheduledFor, mr.ScheduledFor
+	} else if start == "" || end == "" {
+		return nil, kerrors.InvalidDataf("you must provide either a start and end, or scheduledFor")
 	}
-	SetToken(t.Token, req)
 
-	hc := newClient(u.Scheme, t.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	st, err := time.Parse(time.RFC3339, start)
 	if err != nil {
-		return nil, 0, err
+		return nil, kerrors.InvalidDataf("invalid start time %q: %v", start, err)
 	}
-	defer resp.Body.Close()
-
-	if err := CheckError(resp); err != nil {
-		return nil, 0, err
+	et, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return nil, kerrors.InvalidDataf("invalid end time %q: %v", end, err)
 	}
 
-	var tr tasksResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
-		return nil, 0, err
+	var t *int64
+	if ra := r.URL.Query().Get("requestedAt"); ra != "" {
+		tu, err := strconv.ParseInt(ra, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		t = &tu
 	}
 
-	tasks := tr.Tasks
-	return tasks, len(tasks), nil
+	return &postRunRequest{
+		TaskID:      ti,
+		Start:       st.Unix(),
+		End:         et.Unix(),
+		RequestedAt: t,
+	}, nil
 }
 
-// CreateTask creates a new task.
-func (t TaskService) CreateTask(ctx context.Context, tsk *platform.Task) error {
-	u, err := newURL(t.Addr, tasksPath)
-	if err != nil {
-		return err
-	}
+func (h *TaskHandler) handleGetManualRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	taskBytes, err := json.Marshal(tsk)
+	params := httprouter.ParamsFromContext(ctx)
+	taskID, err := platform.IDFromString(params.ByName("tid"))
 	if err != nil {
-		return err
+		EncodeError(ctx, err, w)
+		return
 	}
 
-	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(taskBytes))
+	mrs, err := h.TaskService.FindManualRuns(ctx, *taskID)
 	if err != nil {
-		return err
+		EncodeError(ctx, err, w)
+		return
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	SetToken(t.Token, req)
+	if err := encodeResponse(ctx, w, http.StatusOK, newManualRunsResponse(mrs, *taskID)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
 
-	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+func (h *TaskHandler) handleCancelManualRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	resp, err := hc.Do(req)
+	req, err := decodeCancelManualRunRequest(ctx, r)
 	if err != nil {
-		return err
+		EncodeError(ctx, err, w)
+		return
 	}
-	defer resp.Body.Close()
 
-	if err := CheckError(resp); err != nil {
-		return err
+	if err := h.TaskService.CancelManualRun(ctx, req.TaskID, req.Start, req.End); err != nil {
+		EncodeError(ctx, err, w)
+		return
 	}
 
-	var tr taskResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
-		return err
-	}
-	*tsk = tr.Task
+	w.WriteHeader(http.StatusNoContent)
+}
 
-	return nil
+type cancelManualRunRequest struct {
+	TaskID     platform.ID
+	Start, End int64
 }
 
-// UpdateTask updates a single task with changeset.
-func (t TaskService) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
-	u, err := newURL(t.Addr, taskIDPath(id))
-	if err != nil {
-		return nil, err
+func decodeCancelManualRunRequest(ctx context.Context, r *http.Request) (*cancelManualRunRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
 	}
 
-	taskBytes, err := json.Marshal(upd)
-	if err != nil {
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", u.String(), bytes.NewReader(taskBytes))
+	qp := r.URL.Query()
+	st, err := time.Parse(time.RFC3339, qp.Get("start"))
 	if err != nil {
-		return nil, err
+		return nil, kerrors.InvalidDataf("you must provide the start of the manual run as RFC3339: %v", err)
+	}
+	et, err := time.Parse(time.RFC3339, qp.Get("end"))
+	if err != nil {
+		return nil, kerrors.InvalidDataf("you must provide the end of the manual run as RFC3339: %v", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	SetToken(t.Token, req)
+	return &cancelManualRunRequest{
+		TaskID: ti,
+		Start:  st.Unix(),
+		End:    et.Unix(),
+	}, nil
+}
 
-	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+func (h *TaskHandler) handleGetTaskVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	resp, err := hc.Do(req)
+	params := httprouter.ParamsFromContext(ctx)
+	taskID, err := platform.IDFromString(params.ByName("tid"))
 	if err != nil {
-		return nil, err
+		EncodeError(ctx, err, w)
+		return
 	}
-	defer resp.Body.Close()
 
-	if err := CheckError(resp); err != nil {
-		return nil, err
+	tvs, err := h.TaskService.FindTaskVersions(ctx, *taskID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
 	}
 
-	var tr taskResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
-		return nil, err
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskVersionsResponse(tvs, *taskID)); err != nil {
+		EncodeError(ctx, err, w)
+		return
 	}
-
-	return &tr.Task, nil
 }
 
-// DeleteTask removes a task by ID and purges all associated data and scheduled runs.
-func (t TaskService) DeleteTask(ctx context.Context, id platform.ID) error {
-	u, err := newURL(t.Addr, taskIDPath(id))
+func (h *TaskHandler) handleRollbackTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeRollbackTaskRequest(ctx, r)
 	if err != nil {
-		return err
+		EncodeError(ctx, err, w)
+		return
 	}
 
-	req, err := http.NewRequest("DELETE", u.String(), nil)
+	task, err := h.TaskService.RollbackTask(ctx, req.TaskID, req.Version)
 	if err != nil {
-		return err
+		EncodeError(ctx, err, w)
+		return
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	SetToken(t.Token, req)
-
-	hc := newClient(u.Scheme, t.InsecureSkipVerify)
-
-	resp, err := hc.Do(req)
-	if err != nil {
-		return err
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskResponse(*task)); err != nil {
+		EncodeError(ctx, err, w)
+		return
 	}
-	defer resp.Body.Close()
+}
 
-	return CheckErrorStatus(http.StatusNoContent, resp)
+type rollbackTaskRequest struct {
+	TaskID  platform.ID
+	Version int
 }
 
-// FindLogs returns logs for a run.
-func (t TaskService) FindLogs(ctx context.Context, filter platform.LogFilter) ([]*platform.Log, int, error) {
-	if filter.Task == nil {
-		return nil, 0, errors.New("task ID required")
+func decodeRollbackTaskRequest(ctx context.Context, r *http.Request) (*rollbackTaskRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
 	}
 
-	var urlPath string
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	v, err := strconv.ParseUint(params.ByName("version"), 10, 32)
+	if err != nil || v == 0 {
+		return nil, kerrors.InvalidDataf("you must provide a positive task version")
+	}
+
+	return &rollbackTaskRequest{
+		TaskID:  ti,
+		Version: int(v),
+	}, nil
+}
+
+func (h *TaskHandler) handleGetNotificationRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	taskID, err := platform.IDFromString(params.ByName("tid"))
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	rs, err := h.TaskService.FindTaskNotificationRules(ctx, *taskID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationRulesResponse(rs, *taskID)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func (h *TaskHandler) handlePostNotificationRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rule, err := decodePostNotificationRuleRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskService.CreateTaskNotificationRule(ctx, rule); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newNotificationRuleResponse(*rule)); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func decodePostNotificationRuleRequest(ctx context.Context, r *http.Request) (*platform.TaskNotificationRule, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
+
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	rule := &platform.TaskNotificationRule{}
+	if err := json.NewDecoder(r.Body).Decode(rule); err != nil {
+		return nil, err
+	}
+	// The task of the rule is the one of the path.
+	rule.TaskID = ti
+
+	return rule, nil
+}
+
+func (h *TaskHandler) handleDeleteNotificationRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeDeleteNotificationRuleRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskService.DeleteTaskNotificationRule(ctx, req.TaskID, req.RuleID); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type deleteNotificationRuleRequest struct {
+	TaskID platform.ID
+	RuleID platform.ID
+}
+
+func decodeDeleteNotificationRuleRequest(ctx context.Context, r *http.Request) (*deleteNotificationRuleRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("tid")
+	if tid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a task ID")
+	}
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	nid := params.ByName("nid")
+	if nid == "" {
+		return nil, kerrors.InvalidDataf("you must provide a notification rule ID")
+	}
+	var ni platform.ID
+	if err := ni.DecodeFromString(nid); err != nil {
+		return nil, err
+	}
+
+	return &deleteNotificationRuleRequest{
+		TaskID: ti,
+		RuleID: ni,
+	}, nil
+}
+
+// TaskService connects to Influx via HTTP using tokens to manage tasks.
+type TaskService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// FindTaskByID returns a single task
+func (t TaskService) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
+	u, err := newURL(t.Addr, taskIDPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		if err.Error() == backend.ErrTaskNotFound.Error() {
+			// ErrTaskNotFound is expected as part of the FindTaskByID contract,
+			// so return that actual error instead of a different error that looks like it.
+			return nil, backend.ErrTaskNotFound
+		}
+		return nil, err
+	}
+
+	var tr taskResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+
+	return &tr.Task, nil
+}
+
+// FindTasks returns a list of tasks that match a filter (limit 100) and the total count
+// of matching tasks.
+func (t TaskService) FindTasks(ctx context.Context, filter platform.TaskFilter) ([]*platform.Task, int, error) {
+	u, err := newURL(t.Addr, tasksPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	val := url.Values{}
+	if filter.After != nil {
+		val.Add("after", filter.After.String())
+	}
+	if filter.Organization != nil {
+		val.Add("organization", filter.Organization.String())
+	}
+	if filter.User != nil {
+		val.Add("user", filter.User.String())
+	}
+
+	u.RawQuery = val.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, 0, err
+	}
+
+	var tr tasksResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, 0, err
+	}
+
+	tasks := tr.Tasks
+	return tasks, len(tasks), nil
+}
+
+// CreateTask creates a new task.
+func (t TaskService) CreateTask(ctx context.Context, tsk *platform.Task) error {
+	u, err := newURL(t.Addr, tasksPath)
+	if err != nil {
+		return err
+	}
+
+	taskBytes, err := json.Marshal(tsk)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(taskBytes))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	var tr taskResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return err
+	}
+	*tsk = tr.Task
+
+	return nil
+}
+
+// UpdateTask updates a single task with changeset.
+func (t TaskService) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+	u, err := newURL(t.Addr, taskIDPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	taskBytes, err := json.Marshal(upd)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", u.String(), bytes.NewReader(taskBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var tr taskResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+
+	return &tr.Task, nil
+}
+
+// DeleteTask removes a task by ID and purges all associated data and scheduled runs.
+func (t TaskService) DeleteTask(ctx context.Context, id platform.ID) error {
+	u, err := newURL(t.Addr, taskIDPath(id))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckErrorStatus(http.StatusNoContent, resp)
+}
+
+// FindLogs returns logs for a run.
+func (t TaskService) FindLogs(ctx context.Context, filter platform.LogFilter) ([]*platform.Log, int, error) {
+	if filter.Task == nil {
+		return nil, 0, errors.New("task ID required")
+	}
+
+	var urlPath string
 	if filter.Run == nil {
 		urlPath = path.Join(taskIDPath(*filter.Task), "logs")
 	} else {
@@ -1108,6 +1555,317 @@ func (t TaskService) CancelRun(ctx context.Context, taskID, runID platform.ID) e
 	return nil
 }
 
+// ManuallyRunTimeRange queues runs of a task for every schedule no earlier than start and no later than end (Unix timestamps).
+func (t TaskService) ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*platform.ManualRun, error) {
+	u, err := newURL(t.Addr, taskIDRunsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	val := url.Values{}
+	val.Set("requestedAt", strconv.FormatInt(requestedAt, 10))
+	u.RawQuery = val.Encode()
+
+	b, err := json.Marshal(manualRunRequest{
+		Start: time.Unix(start, 0).UTC().Format(time.RFC3339),
+		End:   time.Unix(end, 0).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		// RetryAlreadyQueuedError is part of the contract.
+		if e := backend.ParseRetryAlreadyQueuedError(err.Error()); e != nil {
+			return nil, *e
+		}
+
+		return nil, err
+	}
+
+	var mr manualRunResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return nil, err
+	}
+	return &mr.ManualRun, nil
+}
+
+// FindManualRuns returns the manual runs of a task that still have runs to be scheduled.
+func (t TaskService) FindManualRuns(ctx context.Context, taskID platform.ID) ([]*platform.ManualRun, error) {
+	u, err := newURL(t.Addr, taskIDManualRunsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var mrs manualRunsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&mrs); err != nil {
+		return nil, err
+	}
+	return mrs.ManualRuns, nil
+}
+
+// CancelManualRun cancels the remaining runs of the manual run of a task from start to end (Unix timestamps).
+func (t TaskService) CancelManualRun(ctx context.Context, taskID platform.ID, start, end int64) error {
+	u, err := newURL(t.Addr, taskIDManualRunsPath(taskID))
+	if err != nil {
+		return err
+	}
+
+	val := url.Values{}
+	val.Set("start", time.Unix(start, 0).UTC().Format(time.RFC3339))
+	val.Set("end", time.Unix(end, 0).UTC().Format(time.RFC3339))
+	u.RawQuery = val.Encode()
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		if err.Error() == backend.ErrManualRunNotFound.Error() {
+			// ErrManualRunNotFound is expected as part of the CancelManualRun contract,
+			// so return that actual error instead of a different error that looks like it.
+			return backend.ErrManualRunNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// FindTaskVersions returns the versions of a task's script, oldest first.
+func (t TaskService) FindTaskVersions(ctx context.Context, taskID platform.ID) ([]*platform.TaskVersion, error) {
+	u, err := newURL(t.Addr, taskIDVersionsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var tvs taskVersionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tvs); err != nil {
+		return nil, err
+	}
+	return tvs.Versions, nil
+}
+
+// RollbackTask updates a task to the script of one of its previous versions, recorded as a new version.
+func (t TaskService) RollbackTask(ctx context.Context, taskID platform.ID, version int) (*platform.Task, error) {
+	p := path.Join(taskIDVersionsPath(taskID), strconv.Itoa(version), "rollback")
+	u, err := newURL(t.Addr, p)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		if err.Error() == backend.ErrTaskVersionNotFound.Error() {
+			// ErrTaskVersionNotFound is expected as part of the RollbackTask contract,
+			// so return that actual error instead of a different error that looks like it.
+			return nil, backend.ErrTaskVersionNotFound
+		}
+
+		return nil, err
+	}
+
+	var tr taskResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return nil, err
+	}
+	return &tr.Task, nil
+}
+
+// FindTaskNotificationRules returns the notification rules of a task.
+func (t TaskService) FindTaskNotificationRules(ctx context.Context, taskID platform.ID) ([]*platform.TaskNotificationRule, error) {
+	u, err := newURL(t.Addr, taskIDNotificationsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var nrs notificationRulesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&nrs); err != nil {
+		return nil, err
+	}
+
+	rs := make([]*platform.TaskNotificationRule, len(nrs.Rules))
+	for i := range nrs.Rules {
+		rs[i] = &nrs.Rules[i].TaskNotificationRule
+	}
+	return rs, nil
+}
+
+// CreateTaskNotificationRule adds a notification rule to its task, and sets its ID.
+func (t TaskService) CreateTaskNotificationRule(ctx context.Context, r *platform.TaskNotificationRule) error {
+	u, err := newURL(t.Addr, taskIDNotificationsPath(r.TaskID))
+	if err != nil {
+		return err
+	}
+
+	ruleBytes, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(ruleBytes))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	var nr notificationRuleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&nr); err != nil {
+		return err
+	}
+	*r = nr.TaskNotificationRule
+
+	return nil
+}
+
+// DeleteTaskNotificationRule removes a notification rule from a task.
+func (t TaskService) DeleteTaskNotificationRule(ctx context.Context, taskID, ruleID platform.ID) error {
+	u, err := newURL(t.Addr, path.Join(taskIDNotificationsPath(taskID), ruleID.String()))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckErrorStatus(http.StatusNoContent, resp); err != nil {
+		if err.Error() == backend.ErrNotificationRuleNotFound.Error() {
+			// ErrNotificationRuleNotFound is expected as part of the DeleteTaskNotificationRule contract,
+			// so return that actual error instead of a different error that looks like it.
+			return backend.ErrNotificationRuleNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 func taskIDPath(id platform.ID) string {
 	return path.Join(tasksPath, id.String())
 }
@@ -1119,3 +1877,15 @@ func taskIDRunsPath(id platform.ID) string {
 func taskIDRunIDPath(taskID, runID platform.ID) string {
 	return path.Join(tasksPath, taskID.String(), "runs", runID.String())
 }
+
+func taskIDManualRunsPath(id platform.ID) string {
+	return path.Join(tasksPath, id.String(), "manualruns")
+}
+
+func taskIDVersionsPath(id platform.ID) string {
+	return path.Join(tasksPath, id.String(), "versions")
+}
+
+func taskIDNotificationsPath(id platform.ID) string {
+	return path.Join(tasksPath, id.String(), "notifications")
+}
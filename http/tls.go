@@ -0,0 +1,184 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+)
+
+// DefaultCertificateCheckInterval is the default interval a CertificateLoader
+// checks its files for changes at.
+const DefaultCertificateCheckInterval = 10 * time.Second
+
+// CertificateLoader loads the TLS certificate of a server from its
+// certificate and key files, and reloads it once the files change, so that
+// a renewed certificate is served without restarting the server.
+type CertificateLoader struct {
+	Logger *zap.Logger
+
+	// CheckInterval is the least time between checks of the files.
+	CheckInterval time.Duration
+
+	certFile string
+	keyFile  string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	loadedAt  [2]time.Time
+	checkedAt time.Time
+}
+
+// NewCertificateLoader returns a loader of the certificate in certFile and
+// its private key in keyFile. It fails if they can't be loaded.
+func NewCertificateLoader(certFile, keyFile string) (*CertificateLoader, error) {
+	l := &CertificateLoader{
+		Logger:        zap.NewNop(),
+		CheckInterval: DefaultCertificateCheckInterval,
+		certFile:      certFile,
+		keyFile:       keyFile,
+	}
+
+	modTimes, err := l.statFiles()
+	if err != nil {
+		return nil, err
+	}
+	if err := l.load(modTimes); err != nil {
+		return nil, err
+	}
+	l.checkedAt = time.Now()
+	return l, nil
+}
+
+// GetCertificate returns the current certificate. It satisfies the
+// GetCertificate field of tls.Config.
+func (l *CertificateLoader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now := time.Now(); now.Sub(l.checkedAt) >= l.CheckInterval {
+		l.checkedAt = now
+		l.reload()
+	}
+	return l.cert, nil
+}
+
+// reload loads the certificate again if its files changed. The previous
+// certificate is kept when the new one can't be loaded, as it may only be
+// partially written.
+func (l *CertificateLoader) reload() {
+	modTimes, err := l.statFiles()
+	if err != nil {
+		l.Logger.Info("Failed to check tls certificate", zap.Error(err))
+		return
+	}
+	if modTimes == l.loadedAt {
+		return
+	}
+
+	if err := l.load(modTimes); err != nil {
+		l.Logger.Info("Failed to reload tls certificate", zap.Error(err))
+		return
+	}
+	l.Logger.Info("Reloaded tls certificate", zap.String("cert", l.certFile))
+}
+
+func (l *CertificateLoader) load(modTimes [2]time.Time) error {
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		return err
+	}
+	l.cert, l.loadedAt = &cert, modTimes
+	return nil
+}
+
+// statFiles returns the modification times of the certificate and key files.
+func (l *CertificateLoader) statFiles() ([2]time.Time, error) {
+	var modTimes [2]time.Time
+	for i, name := range []string{l.certFile, l.keyFile} {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return modTimes, err
+		}
+		modTimes[i] = fi.ModTime()
+	}
+	return modTimes, nil
+}
+
+// LoadCertPool returns a pool of the PEM encoded certificates in the file
+// name, such as a bundle of certificate authorities.
+func LoadCertPool(name string) (*x509.CertPool, error) {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificates found in %s", name)
+	}
+	return pool, nil
+}
+
+// ClientTLSConfig is the TLS configuration of the clients of this package.
+type ClientTLSConfig struct {
+	// CAFile is a bundle of the certificate authorities verifying servers;
+	// the certificate authorities of the system are used when empty.
+	CAFile string
+	// CertFile and KeyFile are the certificate and key the clients
+	// authenticate with, for servers requiring client certificates.
+	CertFile string
+	KeyFile  string
+	// InsecureSkipVerify skips the verification of the certificates of
+	// servers.
+	InsecureSkipVerify bool
+}
+
+// SetClientTLSConfig configures the TLS of every client of this package,
+// replacing the transports they share.
+func SetClientTLSConfig(c ClientTLSConfig) error {
+	config := &tls.Config{}
+	if c.CAFile != "" {
+		pool, err := LoadCertPool(c.CAFile)
+		if err != nil {
+			return err
+		}
+		config.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	skipVerifyConfig := config.Clone()
+	skipVerifyConfig.InsecureSkipVerify = true
+	if c.InsecureSkipVerify {
+		config = skipVerifyConfig
+	}
+
+	defaultTransport = newTransport(config)
+	skipVerifyTransport = newTransport(skipVerifyConfig)
+	return nil
+}
+
+// newTransport returns a transport with the TLS configuration config that
+// speaks HTTP/2 with the servers supporting it.
+func newTransport(config *tls.Config) *http.Transport {
+	t := &http.Transport{TLSClientConfig: config}
+	// A transport with its own TLS configuration only speaks HTTP/1.1
+	// unless configured for HTTP/2.
+	if err := http2.ConfigureTransport(t); err != nil {
+		panic(err)
+	}
+	return t
+}
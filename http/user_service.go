@@ -21,6 +21,7 @@ type UserHandler struct {
 	UserService             platform.UserService
 	UserOperationLogService platform.UserOperationLogService
 	BasicAuthService        platform.BasicAuthService
+	OAuth2IdentityService   platform.OAuth2IdentityService
 }
 
 const (
@@ -30,6 +31,8 @@ const (
 	usersIDPath       = "/api/v2/users/:id"
 	usersPasswordPath = "/api/v2/users/:id/password"
 	usersLogPath      = "/api/v2/users/:id/log"
+
+	usersOAuth2IdentitiesPath = "/api/v2/users/:id/oauth2identities"
 )
 
 // NewUserHandler returns a new instance of UserHandler.
@@ -45,6 +48,7 @@ func NewUserHandler() *UserHandler {
 	h.HandlerFunc("PATCH", usersIDPath, h.handlePatchUser)
 	h.HandlerFunc("DELETE", usersIDPath, h.handleDeleteUser)
 	h.HandlerFunc("PUT", usersPasswordPath, h.handlePutUserPassword)
+	h.HandlerFunc("POST", usersOAuth2IdentitiesPath, h.handlePostUserOAuth2Identity)
 
 	h.HandlerFunc("GET", mePath, h.handleGetMe)
 	h.HandlerFunc("PUT", mePasswordPath, h.handlePutUserPassword)
@@ -89,6 +93,49 @@ func (h *UserHandler) handlePutUserPassword(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// handlePostUserOAuth2Identity is the HTTP handler for the POST /api/v2/users/:id/oauth2identities route.
+// It links an identity of an OAuth2 provider to the user, so that the user may sign in with the provider.
+func (h *UserHandler) handlePostUserOAuth2Identity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	i, err := decodePostUserOAuth2IdentityRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.OAuth2IdentityService.CreateOAuth2Identity(ctx, i); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, i); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+func decodePostUserOAuth2IdentityRequest(ctx context.Context, r *http.Request) (*platform.OAuth2Identity, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, kerrors.InvalidDataf("url missing id")
+	}
+
+	var userID platform.ID
+	if err := userID.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+
+	i := &platform.OAuth2Identity{}
+	if err := json.NewDecoder(r.Body).Decode(i); err != nil {
+		return nil, err
+	}
+	i.UserID = userID
+
+	return i, nil
+}
+
 type passwordResetRequest struct {
 	Username    string
 	PasswordOld string
@@ -1,12 +1,18 @@
 package http
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/influxdata/platform"
@@ -21,6 +27,10 @@ import (
 
 // WriteHandler receives line protocol and sends to a publish function.
 type WriteHandler struct {
+	// writes is the number of requests being written. It is accessed
+	// atomically, so it comes first to be 64-bit aligned.
+	writes int64
+
 	*httprouter.Router
 
 	Logger *zap.Logger
@@ -30,10 +40,32 @@ type WriteHandler struct {
 	OrganizationService  platform.OrganizationService
 
 	PointsWriter storage.PointsWriter
+
+	// LimitEnforcer enforces the write limits of organizations. Writes are
+	// not limited when it is nil.
+	LimitEnforcer platform.LimitEnforcer
+	// UsageRecorder records the usage of the writes of organizations. Usage
+	// is not recorded when it is nil.
+	UsageRecorder platform.UsageRecorder
+
+	// MaxBodySize is the maximum size in bytes of a decompressed request
+	// body. Zero means no limit.
+	MaxBodySize int64
+	// MaxPointsPerRequest is the maximum number of points in a request.
+	// Zero means no limit.
+	MaxPointsPerRequest int
+	// MaxConcurrentWrites is the maximum number of requests written at
+	// once. Requests over the limit are rejected with 429 Too Many Requests.
+	// Zero means no limit.
+	MaxConcurrentWrites int
 }
 
 const (
 	writePath = "/api/v2/write"
+
+	// writeChunkSize is the size of the chunks of line protocol parsed
+	// and written at once.
+	writeChunkSize = 1 << 20
 )
 
 // NewWriteHandler creates a new handler at /api/v2/write to receive line protocol.
@@ -52,6 +84,17 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	defer r.Body.Close()
 
+	if !h.acquireWrite() {
+		w.Header().Set("Retry-After", "1")
+		EncodeError(ctx, errors.Error{
+			Reference: errors.InternalError,
+			Code:      http.StatusTooManyRequests,
+			Err:       "too many concurrent writes",
+		}, w)
+		return
+	}
+	defer h.releaseWrite()
+
 	in := r.Body
 	if r.Header.Get("Content-Encoding") == "gzip" {
 		var err error
@@ -83,92 +126,463 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 
 	logger := h.Logger.With(zap.String("org", req.Org), zap.String("bucket", req.Bucket))
 
+	org, bucket, err := findOrgBucket(ctx, h.OrganizationService, h.BucketService, logger, req.Org, req.Bucket)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if !auth.Allowed(platform.NewPermission(platform.WriteAction, platform.BucketResourceType, bucket.OrganizationID, bucket.ID)) {
+		EncodeError(ctx, errors.Forbiddenf("insufficient permissions for write"), w)
+		return
+	}
+
+	charge := func(int) {}
+	if h.LimitEnforcer != nil {
+		charge, err = h.LimitEnforcer.AllowWrite(ctx, org.ID)
+		if err != nil {
+			w.Header().Set("Retry-After", "1")
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
+	var body io.Reader = in
+	if h.MaxBodySize > 0 {
+		if r.Header.Get("Content-Encoding") != "gzip" && r.ContentLength > h.MaxBodySize {
+			EncodeError(ctx, bodyTooLargeError(h.MaxBodySize), w)
+			return
+		}
+		body = &maxBytesReader{r: in, max: h.MaxBodySize}
+	}
+
+	// TODO(jeff): we should be publishing with the org and bucket instead of
+	// parsing, rewriting, and publishing, but the interface isn't quite there yet.
+	// be sure to remove this when it is there!
+	//
+	// The whole body is parsed, and its limits enforced, before any point is
+	// written, so that a request is either rejected or written as a whole.
+	// Only a chunk of the body is held in memory at once: bodies of more than
+	// one chunk are spooled to a temporary file as they are validated, and
+	// are then parsed again from it and written chunk by chunk.
+	var (
+		now    = time.Now()
+		chunks = newLineChunkReader(body, writeChunkSize)
+		first  []byte
+		spool  *os.File
+		points int
+		values int
+		size   int
+	)
+	defer func() {
+		h.recordUsage(ctx, org.ID, bucket.ID, size, values)
+	}()
+	defer func() {
+		if spool != nil {
+			spool.Close()
+			os.Remove(spool.Name())
+		}
+	}()
+	for {
+		chunk, err := chunks.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			logger.Info("Error reading body", zap.Error(err))
+			EncodeError(ctx, err, w)
+			return
+		}
+		size += len(chunk)
+		charge(len(chunk))
+
+		n, err := validateChunk(org.ID, bucket.ID, chunk, now, req)
+		if err != nil {
+			logger.Info("Error parsing points", zap.Error(err))
+			EncodeError(ctx, err, w)
+			return
+		}
+		points += n
+		if h.MaxPointsPerRequest > 0 && points > h.MaxPointsPerRequest {
+			EncodeError(ctx, tooManyPointsError(h.MaxPointsPerRequest), w)
+			return
+		}
+
+		if first == nil && spool == nil {
+			first = chunk
+			continue
+		}
+		if spool == nil {
+			if spool, err = newWriteSpool(first); err != nil {
+				logger.Info("Error spooling body", zap.Error(err))
+				EncodeError(ctx, err, w)
+				return
+			}
+			first = nil
+		}
+		if _, err := spool.Write(chunk); err != nil {
+			logger.Info("Error spooling body", zap.Error(err))
+			EncodeError(ctx, err, w)
+			return
+		}
+	}
+
+	var validated io.Reader = bytes.NewReader(first)
+	if spool != nil {
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			logger.Info("Error reading spooled body", zap.Error(err))
+			EncodeError(ctx, err, w)
+			return
+		}
+		validated = spool
+	}
+
+	var (
+		report = &platform.WriteReport{Rejected: []platform.RejectedLine{}}
+		lines  int
+	)
+	chunks = newLineChunkReader(validated, writeChunkSize)
+	for {
+		chunk, err := chunks.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			logger.Info("Error reading spooled body", zap.Error(err))
+			EncodeError(ctx, err, w)
+			return
+		}
+
+		if req.Partial {
+			ls := parseLines(org.ID, bucket.ID, chunk, req.Precision, now, lines, report)
+			lines += bytes.Count(chunk, []byte{'\n'})
+			h.writeLines(ls, report)
+			for _, l := range ls {
+				values += len(l.points)
+			}
+			continue
+		}
+
+		pts, err := models.ParsePointsWithPrecision(chunk, now, req.Precision)
+		if err == nil {
+			pts, err = tsdb.ExplodePoints(org.ID, bucket.ID, pts)
+		}
+		if err != nil {
+			// The chunk was validated when it was read, so this is not
+			// the fault of the request.
+			logger.Info("Error parsing validated points", zap.Error(err))
+			EncodeError(ctx, err, w)
+			return
+		}
+		if err := h.PointsWriter.WritePoints(pts); err != nil {
+			EncodeError(ctx, errors.BadRequestError(err.Error()), w)
+			return
+		}
+		values += len(pts)
+	}
+
+	if req.Partial {
+		sortRejectedLines(report.Rejected)
+		if len(report.Rejected) > 0 {
+			logger.Info("Rejected lines in partial write", zap.Int("accepted", report.Accepted), zap.Int("rejected", len(report.Rejected)))
+		}
+		if err := encodeResponse(ctx, w, http.StatusOK, report); err != nil {
+			logger.Info("Failed to encode response", zap.Error(err))
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// recordUsage records the usage of a write request of size bytes, which
+// wrote values field values. The series of organizations are not usage of
+// their writes, and are limited by their series cardinality instead.
+func (h *WriteHandler) recordUsage(ctx context.Context, orgID, bucketID platform.ID, size, values int) {
+	if h.UsageRecorder == nil {
+		return
+	}
+	now := time.Now()
+	h.UsageRecorder.RecordUsage(ctx, orgID, bucketID, platform.UsageWriteRequestCount, 1, now)
+	h.UsageRecorder.RecordUsage(ctx, orgID, bucketID, platform.UsageWriteRequestBytes, float64(size), now)
+	h.UsageRecorder.RecordUsage(ctx, orgID, bucketID, platform.UsageValues, float64(values), now)
+}
+
+// acquireWrite reserves one of the concurrent writes allowed by
+// MaxConcurrentWrites. It returns false if all of them are in use.
+func (h *WriteHandler) acquireWrite() bool {
+	if h.MaxConcurrentWrites <= 0 {
+		return true
+	}
+	if atomic.AddInt64(&h.writes, 1) > int64(h.MaxConcurrentWrites) {
+		atomic.AddInt64(&h.writes, -1)
+		return false
+	}
+	return true
+}
+
+func (h *WriteHandler) releaseWrite() {
+	if h.MaxConcurrentWrites > 0 {
+		atomic.AddInt64(&h.writes, -1)
+	}
+}
+
+func bodyTooLargeError(max int64) error {
+	return errors.Error{
+		Reference: errors.InvalidData,
+		Code:      http.StatusRequestEntityTooLarge,
+		Err:       fmt.Sprintf("request body exceeds the maximum size of %d bytes", max),
+	}
+}
+
+func tooManyPointsError(max int) error {
+	return errors.Error{
+		Reference: errors.InvalidData,
+		Code:      http.StatusRequestEntityTooLarge,
+		Err:       fmt.Sprintf("request exceeds the maximum of %d points", max),
+	}
+}
+
+// maxBytesReader returns an error once more than max bytes have been read.
+type maxBytesReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+func (r *maxBytesReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if r.n > r.max {
+		return n, bodyTooLargeError(r.max)
+	}
+	return n, err
+}
+
+// validateChunk parses the chunk of line protocol of a write request, and
+// returns the number of points it holds. Lines that can't be parsed only
+// fail requests that are not partial writes.
+func validateChunk(orgID, bucketID platform.ID, chunk []byte, now time.Time, req *postWriteRequest) (int, error) {
+	if req.Partial {
+		report := &platform.WriteReport{}
+		return len(parseLines(orgID, bucketID, chunk, req.Precision, now, 0, report)), nil
+	}
+
+	pts, err := models.ParsePointsWithPrecision(chunk, now, req.Precision)
+	if err != nil {
+		return 0, &platform.Error{Code: platform.EInvalid, Err: err}
+	}
+	if _, err := tsdb.ExplodePoints(orgID, bucketID, pts); err != nil {
+		return 0, &platform.Error{Code: platform.EInvalid, Err: err}
+	}
+	return len(pts), nil
+}
+
+// newWriteSpool returns a temporary file holding data, to which the rest
+// of a request body is spooled. The caller must remove the file.
+func newWriteSpool(data []byte) (*os.File, error) {
+	f, err := ioutil.TempFile("", "influxd-write-")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
+// lineChunkReader reads line protocol in chunks of complete lines, so that
+// a request body is parsed a chunk at a time instead of all at once.
+type lineChunkReader struct {
+	r    io.Reader
+	buf  []byte
+	size int
+	eof  bool
+}
+
+func newLineChunkReader(r io.Reader, size int) *lineChunkReader {
+	return &lineChunkReader{
+		r:    r,
+		buf:  make([]byte, 0, size),
+		size: size,
+	}
+}
+
+// Next returns the next chunk of complete lines, which is at least size
+// bytes long unless it is the last chunk. It returns io.EOF once the
+// reader has been consumed.
+func (c *lineChunkReader) Next() ([]byte, error) {
+	for {
+		if c.eof || len(c.buf) >= c.size {
+			n := len(c.buf)
+			if !c.eof {
+				n = models.CompleteLines(c.buf)
+			}
+			if n > 0 {
+				// Parsed points refer to the chunk, so the rest of the
+				// buffer is moved to a new one instead of being reused.
+				chunk := c.buf[:n:n]
+				c.buf = append(make([]byte, 0, c.size), c.buf[n:]...)
+				return chunk, nil
+			}
+			if c.eof {
+				return nil, io.EOF
+			}
+		}
+
+		if len(c.buf) == cap(c.buf) {
+			// a single line is larger than the chunk size.
+			buf := make([]byte, len(c.buf), 2*cap(c.buf))
+			copy(buf, c.buf)
+			c.buf = buf
+		}
+		n, err := c.r.Read(c.buf[len(c.buf):cap(c.buf)])
+		c.buf = c.buf[:len(c.buf)+n]
+		if err == io.EOF {
+			c.eof = true
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parsedLine holds the points parsed from a line of a partial write.
+type parsedLine struct {
+	line   int
+	points []models.Point
+}
+
+// parseLines parses every line of data and adds the lines that could not
+// be parsed to report. Points without a timestamp are written at now. Line
+// numbers are offset by the number of lines before data in the request body.
+func parseLines(orgID, bucketID platform.ID, data []byte, precision string, now time.Time, offset int, report *platform.WriteReport) []parsedLine {
+	var lines []parsedLine
+	models.ParsePointsByLine(data, now, precision, func(line int, pt models.Point, err error) {
+		line += offset
+		if err != nil {
+			rejectLine(report, line, parseErrorCode(err), err)
+			return
+		}
+		exploded, err := tsdb.ExplodePoints(orgID, bucketID, []models.Point{pt})
+		if err != nil {
+			rejectLine(report, line, platform.WriteCodeParseError, err)
+			return
+		}
+		lines = append(lines, parsedLine{line: line, points: exploded})
+	})
+	return lines
+}
+
+// writeLines writes every line it can and adds the lines that could not
+// be written to report.
+func (h *WriteHandler) writeLines(lines []parsedLine, report *platform.WriteReport) {
+	var points []models.Point
+	for _, l := range lines {
+		points = append(points, l.points...)
+	}
+
+	if len(points) == 0 || h.PointsWriter.WritePoints(points) == nil {
+		report.Accepted += len(lines)
+		return
+	}
+
+	// Some points could not be written. Writes are idempotent, so write
+	// each line on its own to find out which ones were rejected.
+	for _, l := range lines {
+		if err := h.PointsWriter.WritePoints(l.points); err != nil {
+			rejectLine(report, l.line, writeErrorCode(err), err)
+			continue
+		}
+		report.Accepted++
+	}
+}
+
+func rejectLine(report *platform.WriteReport, line int, code string, err error) {
+	report.Rejected = append(report.Rejected, platform.RejectedLine{
+		Line:   line,
+		Code:   code,
+		Reason: err.Error(),
+	})
+}
+
+func sortRejectedLines(rejected []platform.RejectedLine) {
+	sort.SliceStable(rejected, func(i, j int) bool {
+		return rejected[i].Line < rejected[j].Line
+	})
+}
+
+func parseErrorCode(err error) string {
+	switch err {
+	case models.ErrBadTimestamp, models.ErrTimeOutOfRange:
+		return platform.WriteCodeBadTimestamp
+	}
+	return platform.WriteCodeParseError
+}
+
+func writeErrorCode(err error) string {
+	switch err.(type) {
+	case tsdb.FieldTypeConflictError:
+		return platform.WriteCodeFieldTypeConflict
+	case tsdb.SeriesLimitError:
+		return platform.WriteCodeSeriesLimit
+	}
+	if err == tsdb.ErrFieldTypeConflict {
+		return platform.WriteCodeFieldTypeConflict
+	}
+	return platform.WriteCodeWriteError
+}
+
+// findOrgBucket returns the organization and bucket named by orgRef and bucketRef,
+// which may each be either an ID or a name.
+func findOrgBucket(ctx context.Context, orgs platform.OrganizationService, buckets platform.BucketService, logger *zap.Logger, orgRef, bucketRef string) (*platform.Organization, *platform.Bucket, error) {
 	var org *platform.Organization
-	if id, err := platform.IDFromString(req.Org); err == nil {
+	if id, err := platform.IDFromString(orgRef); err == nil {
 		// Decoded ID successfully. Make sure it's a real org.
-		o, err := h.OrganizationService.FindOrganizationByID(ctx, *id)
+		o, err := orgs.FindOrganizationByID(ctx, *id)
 		if err == nil {
 			org = o
 		} else if err != ErrNotFound {
-			EncodeError(ctx, err, w)
-			return
+			return nil, nil, err
 		}
 	}
 	if org == nil {
-		o, err := h.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &req.Org})
+		o, err := orgs.FindOrganization(ctx, platform.OrganizationFilter{Name: &orgRef})
 		if err != nil {
 			logger.Info("Failed to find organization", zap.Error(err))
-			EncodeError(ctx, fmt.Errorf("organization %q not found", req.Org), w)
-			return
+			return nil, nil, fmt.Errorf("organization %q not found", orgRef)
 		}
 
 		org = o
 	}
 
 	var bucket *platform.Bucket
-	if id, err := platform.IDFromString(req.Bucket); err == nil {
+	if id, err := platform.IDFromString(bucketRef); err == nil {
 		// Decoded ID successfully. Make sure it's a real bucket.
-		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+		b, err := buckets.FindBucket(ctx, platform.BucketFilter{
 			OrganizationID: &org.ID,
 			ID:             id,
 		})
 		if err == nil {
 			bucket = b
 		} else if err != ErrNotFound {
-			EncodeError(ctx, err, w)
-			return
+			return nil, nil, err
 		}
 	}
 
 	if bucket == nil {
-		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
+		b, err := buckets.FindBucket(ctx, platform.BucketFilter{
 			OrganizationID: &org.ID,
-			Name:           &req.Bucket,
+			Name:           &bucketRef,
 		})
 		if err != nil {
 			logger.Info("Failed to find bucket", zap.Stringer("org_id", org.ID), zap.Error(err))
-			EncodeError(ctx, fmt.Errorf("bucket %q not found", req.Bucket), w)
-			return
+			return nil, nil, fmt.Errorf("bucket %q not found", bucketRef)
 		}
 
 		bucket = b
 	}
 
-	if !auth.Allowed(platform.WriteBucketPermission(bucket.ID)) {
-		EncodeError(ctx, errors.Forbiddenf("insufficient permissions for write"), w)
-		return
-	}
-
-	// TODO(jeff): we should be publishing with the org and bucket instead of
-	// parsing, rewriting, and publishing, but the interface isn't quite there yet.
-	// be sure to remove this when it is there!
-	data, err := ioutil.ReadAll(in)
-	if err != nil {
-		logger.Info("Error reading body", zap.Error(err))
-		EncodeError(ctx, err, w)
-		return
-	}
-
-	points, err := models.ParsePointsWithPrecision(data, time.Now(), req.Precision)
-	if err != nil {
-		logger.Info("Error parsing points", zap.Error(err))
-		EncodeError(ctx, err, w)
-		return
-	}
-
-	exploded, err := tsdb.ExplodePoints(org.ID, bucket.ID, points)
-	if err != nil {
-		logger.Info("Error exploding points", zap.Error(err))
-		EncodeError(ctx, err, w)
-		return
-	}
-
-	if err := h.PointsWriter.WritePoints(exploded); err != nil {
-		EncodeError(ctx, errors.BadRequestError(err.Error()), w)
-		return
-	}
-
-	w.WriteHeader(http.StatusNoContent)
+	return org, bucket, nil
 }
 
 func decodeWriteRequest(ctx context.Context, r *http.Request) (*postWriteRequest, error) {
@@ -182,10 +596,20 @@ func decodeWriteRequest(ctx context.Context, r *http.Request) (*postWriteRequest
 		return nil, errors.InvalidDataf("invalid precision")
 	}
 
+	var partial bool
+	if s := qp.Get("partial"); s != "" {
+		var err error
+		partial, err = strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.InvalidDataf("invalid partial")
+		}
+	}
+
 	return &postWriteRequest{
 		Bucket:    qp.Get("bucket"),
 		Org:       qp.Get("org"),
 		Precision: p,
+		Partial:   partial,
 	}, nil
 }
 
@@ -193,6 +617,7 @@ type postWriteRequest struct {
 	Org       string
 	Bucket    string
 	Precision string
+	Partial   bool
 }
 
 // WriteService sends data over HTTP to influxdb via line protocol.
@@ -201,6 +626,10 @@ type WriteService struct {
 	Token              string
 	Precision          string
 	InsecureSkipVerify bool
+
+	// Partial requests a partial write. Valid lines are written, and a
+	// *platform.PartialWriteError is returned if any lines were rejected.
+	Partial bool
 }
 
 var _ platform.WriteService = (*WriteService)(nil)
@@ -248,6 +677,9 @@ func (s *WriteService) Write(ctx context.Context, orgID, bucketID platform.ID, r
 	params.Set("org", string(org))
 	params.Set("bucket", string(bucket))
 	params.Set("precision", string(precision))
+	if s.Partial {
+		params.Set("partial", "true")
+	}
 	req.URL.RawQuery = params.Encode()
 
 	hc := newClient(u.Scheme, s.InsecureSkipVerify)
@@ -256,8 +688,24 @@ func (s *WriteService) Write(ctx context.Context, orgID, bucketID platform.ID, r
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	if !s.Partial {
+		return nil
+	}
 
-	return CheckError(resp)
+	var report platform.WriteReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return err
+	}
+	if len(report.Rejected) > 0 {
+		return &platform.PartialWriteError{Report: report}
+	}
+	return nil
 }
 
 func compressWithGzip(data io.Reader) (io.Reader, error) {
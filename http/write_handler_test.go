@@ -1,16 +1,24 @@
 package http
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/platform"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
 )
 
 func TestWriteService_Write(t *testing.T) {
@@ -69,3 +77,227 @@ func TestWriteService_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteService_WritePartial(t *testing.T) {
+	report := platform.WriteReport{
+		Accepted: 1,
+		Rejected: []platform.RejectedLine{
+			{Line: 2, Code: platform.WriteCodeParseError, Reason: "missing fields"},
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("partial"); got != "true" {
+			t.Errorf("expected partial query parameter true, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(report)
+	}))
+	defer ts.Close()
+
+	s := &WriteService{
+		Addr:    ts.URL,
+		Partial: true,
+	}
+	err := s.Write(context.Background(), 1, 2, strings.NewReader("m f=1\nm"))
+	perr, ok := err.(*platform.PartialWriteError)
+	if !ok {
+		t.Fatalf("expected a partial write error, got %v", err)
+	}
+	if diff := cmp.Diff(report, perr.Report); diff != "" {
+		t.Errorf("unexpected report -want/+got:\n%s", diff)
+	}
+}
+
+// conflictWriter rejects points whose series key contains "conflict".
+type conflictWriter struct {
+	mock.PointsWriter
+}
+
+func (w *conflictWriter) WritePoints(points []models.Point) error {
+	for _, p := range points {
+		if bytes.Contains(p.Key(), []byte("conflict")) {
+			return tsdb.ErrFieldTypeConflict
+		}
+	}
+	return w.PointsWriter.WritePoints(points)
+}
+
+func TestWriteHandler_writeLines(t *testing.T) {
+	lines := strings.Join([]string{
+		"# comment",
+		"cpu,host=a usage=1 1000",
+		"cpu,host=a",
+		"cpu,host=b usage=2 10a0",
+		"",
+		"conflict,host=c usage=3 1000",
+		"cpu,host=c usage=4 1000",
+	}, "\n")
+
+	pw := &conflictWriter{}
+	h := NewWriteHandler(pw)
+	report := &platform.WriteReport{}
+	parsed := parseLines(1, 2, []byte(lines), "ns", time.Now(), 10, report)
+	h.writeLines(parsed, report)
+	sortRejectedLines(report.Rejected)
+
+	if got, want := report.Accepted, 2; got != want {
+		t.Errorf("expected %d accepted lines, got %d", want, got)
+	}
+
+	var got []platform.RejectedLine
+	for _, l := range report.Rejected {
+		got = append(got, platform.RejectedLine{Line: l.Line, Code: l.Code})
+	}
+	want := []platform.RejectedLine{
+		{Line: 13, Code: platform.WriteCodeParseError},
+		{Line: 14, Code: platform.WriteCodeBadTimestamp},
+		{Line: 16, Code: platform.WriteCodeFieldTypeConflict},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected rejected lines -want/+got:\n%s", diff)
+	}
+
+	// the valid lines are written despite the rejected ones.
+	if got, want := len(pw.Points), 2; got != want {
+		t.Errorf("expected %d points written, got %d", want, got)
+	}
+}
+
+func TestLineChunkReader(t *testing.T) {
+	input := "m f=1\nm s=\"a\nb\"\nm f=2\nm f=3"
+	chunks := newLineChunkReader(strings.NewReader(input), 3)
+
+	var got []string
+	for {
+		chunk, err := chunks.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(chunk))
+	}
+
+	// the newline within the quoted string field does not end a chunk.
+	want := []string{"m f=1\n", "m s=\"a\nb\"\n", "m f=2\n", "m f=3"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected chunks -want/+got:\n%s", diff)
+	}
+}
+
+func TestWriteHandler_handleWriteLimits(t *testing.T) {
+	newHandler := func() (*WriteHandler, *mock.PointsWriter) {
+		pw := &mock.PointsWriter{}
+		h := NewWriteHandler(pw)
+		h.AuthorizationService = &mock.AuthorizationService{
+			FindAuthorizationByIDFn: func(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
+				return &platform.Authorization{
+					ID:          id,
+					Status:      platform.Active,
+					Permissions: []platform.Permission{platform.WriteBucketPermission(2)},
+				}, nil
+			},
+		}
+		h.OrganizationService = &mock.OrganizationService{
+			FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+				return &platform.Organization{ID: id}, nil
+			},
+		}
+		h.BucketService = &mock.BucketService{
+			FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+				return &platform.Bucket{ID: *filter.ID, OrganizationID: *filter.OrganizationID}, nil
+			},
+		}
+		return h, pw
+	}
+
+	tests := []struct {
+		name                string
+		maxBodySize         int64
+		maxPoints           int
+		maxConcurrentWrites int
+		inflight            int64
+		limitErr            error
+		body                string
+		wantStatus          int
+		wantPoints          int
+	}{
+		{
+			name:       "no limits",
+			body:       "m f=1\nm f=2\nm f=3",
+			wantStatus: http.StatusNoContent,
+			wantPoints: 3,
+		},
+		{
+			name:        "body too large",
+			maxBodySize: 8,
+			body:        "m f=1\nm f=2\nm f=3",
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "too many points",
+			maxPoints:  2,
+			body:       "m f=1\nm f=2\nm f=3",
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "body of several chunks",
+			body:       strings.Repeat("m f=1\n", writeChunkSize/3),
+			wantStatus: http.StatusNoContent,
+			wantPoints: writeChunkSize / 3,
+		},
+		{
+			name:       "malformed line after a chunk of valid lines",
+			body:       strings.Repeat("m f=1\n", writeChunkSize/6+1) + "m f=",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "too many points after a chunk of points",
+			maxPoints:  writeChunkSize / 6,
+			body:       strings.Repeat("m f=1\n", writeChunkSize/6+1),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:                "too many concurrent writes",
+			maxConcurrentWrites: 1,
+			inflight:            1,
+			body:                "m f=1",
+			wantStatus:          http.StatusTooManyRequests,
+		},
+		{
+			name:       "organization over quota",
+			limitErr:   &platform.Error{Code: platform.ETooManyRequests, Msg: "over quota"},
+			body:       "m f=1",
+			wantStatus: http.StatusTooManyRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, pw := newHandler()
+			h.MaxBodySize = tt.maxBodySize
+			h.MaxPointsPerRequest = tt.maxPoints
+			h.MaxConcurrentWrites = tt.maxConcurrentWrites
+			h.writes = tt.inflight
+			h.LimitEnforcer = &mock.LimitEnforcer{
+				AllowWriteF: func(ctx context.Context, orgID platform.ID) (func(int), error) {
+					if tt.limitErr != nil {
+						return nil, tt.limitErr
+					}
+					return func(int) {}, nil
+				},
+			}
+
+			r := httptest.NewRequest("POST", writePath+"?org=0000000000000001&bucket=0000000000000002", strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{ID: 3}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got := w.Code; got != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, got, w.Header().Get(ErrorHeader))
+			}
+			if got := len(pw.Points); got != tt.wantPoints {
+				t.Errorf("expected %d points written, got %d", tt.wantPoints, got)
+			}
+		})
+	}
+}
@@ -57,13 +57,14 @@ func (s *Service) PutAuthorization(ctx context.Context, a *platform.Authorizatio
 
 // FindAuthorizationByID returns an authorization given an ID.
 func (s *Service) FindAuthorizationByID(ctx context.Context, id platform.ID) (*platform.Authorization, error) {
-	var err error
 	a, pe := s.loadAuthorization(ctx, id)
 	if pe != nil {
 		pe.Op = OpPrefix + platform.OpFindAuthorizationByID
-		err = pe
+		return nil, pe
 	}
-	return a, err
+	// The token is only returned when the authorization is created.
+	a.Token = ""
+	return a, nil
 }
 
 // FindAuthorizationByToken returns an authorization given a token.
@@ -157,6 +158,7 @@ func (s *Service) FindAuthorizations(ctx context.Context, filter platform.Author
 		}
 
 		if filterF(&a) {
+			a.Token = ""
 			as = append(as, &a)
 		}
 
@@ -193,6 +195,8 @@ func (s *Service) CreateAuthorization(ctx context.Context, a *platform.Authoriza
 	}
 	a.ID = s.IDGenerator.ID()
 	a.Status = platform.Active
+	now := s.time()
+	a.CreatedAt = &now
 	return s.PutAuthorization(ctx, a)
 }
 
@@ -212,9 +216,10 @@ func (s *Service) DeleteAuthorization(ctx context.Context, id platform.ID) error
 // SetAuthorizationStatus updates the status of an authorization associated with id.
 func (s *Service) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
 	op := OpPrefix + platform.OpSetAuthorizationStatus
-	a, err := s.FindAuthorizationByID(ctx, id)
-	if err != nil {
-		return err
+	a, pe := s.loadAuthorization(ctx, id)
+	if pe != nil {
+		pe.Op = op
+		return pe
 	}
 
 	switch status {
@@ -0,0 +1,59 @@
+package inmem
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.OAuth2IdentityService = (*Service)(nil)
+
+func oauth2IdentityKey(issuer, subject string) string {
+	return issuer + "\x00" + subject
+}
+
+// FindOAuth2Identity returns the identity of subject at the issuer.
+func (s *Service) FindOAuth2Identity(ctx context.Context, issuer, subject string) (*platform.OAuth2Identity, error) {
+	i, ok := s.oauth2IdentityKV.Load(oauth2IdentityKey(issuer, subject))
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  "oauth2 identity not found",
+		}
+	}
+
+	id := i.(platform.OAuth2Identity)
+	return &id, nil
+}
+
+// CreateOAuth2Identity links the identity i to its user.
+func (s *Service) CreateOAuth2Identity(ctx context.Context, i *platform.OAuth2Identity) error {
+	if i.Issuer == "" || i.Subject == "" {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "oauth2 identity requires an issuer and a subject",
+		}
+	}
+
+	if _, err := s.FindUserByID(ctx, i.UserID); err != nil {
+		return err
+	}
+
+	if _, loaded := s.oauth2IdentityKV.LoadOrStore(oauth2IdentityKey(i.Issuer, i.Subject), *i); loaded {
+		return &platform.Error{
+			Code: platform.EConflict,
+			Msg:  "oauth2 identity is already linked to a user",
+		}
+	}
+	return nil
+}
+
+// deleteUserOAuth2Identities removes the identities linked to the user id.
+func (s *Service) deleteUserOAuth2Identities(id platform.ID) {
+	s.oauth2IdentityKV.Range(func(k, v interface{}) bool {
+		if v.(platform.OAuth2Identity).UserID == id {
+			s.oauth2IdentityKV.Delete(k)
+		}
+		return true
+	})
+}
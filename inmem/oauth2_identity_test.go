@@ -0,0 +1,30 @@
+package inmem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/platform"
+	platformtesting "github.com/influxdata/platform/testing"
+)
+
+func initOAuth2IdentityService(f platformtesting.OAuth2IdentityFields, t *testing.T) (platform.OAuth2IdentityService, func()) {
+	s := NewService()
+	ctx := context.Background()
+	for _, u := range f.Users {
+		if err := s.PutUser(ctx, u); err != nil {
+			t.Fatalf("failed to populate users")
+		}
+	}
+	for _, i := range f.Identities {
+		if err := s.CreateOAuth2Identity(ctx, i); err != nil {
+			t.Fatalf("failed to populate oauth2 identities: %v", err)
+		}
+	}
+	return s, func() {}
+}
+
+func TestOAuth2IdentityService(t *testing.T) {
+	t.Parallel()
+	platformtesting.OAuth2IdentityService(initOAuth2IdentityService, t)
+}
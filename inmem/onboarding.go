@@ -92,17 +92,9 @@ func (s *Service) Generate(ctx context.Context, req *platform.OnboardingRequest)
 		return nil, err
 	}
 	auth := &platform.Authorization{
-		User:   u.Name,
-		UserID: u.ID,
-		Permissions: []platform.Permission{
-			platform.CreateUserPermission,
-			platform.DeleteUserPermission,
-			platform.Permission{
-				Resource: platform.OrganizationResource,
-				Action:   platform.WriteAction,
-			},
-			platform.WriteBucketPermission(bucket.ID),
-		},
+		User:        u.Name,
+		UserID:      u.ID,
+		Permissions: platform.OperPermissions(),
 	}
 	if err = s.CreateAuthorization(ctx, auth); err != nil {
 		return nil, err
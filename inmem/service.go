@@ -27,6 +27,7 @@ type Service struct {
 	telegrafConfigKV      sync.Map
 	onboardingKV          sync.Map
 	basicAuthKV           sync.Map
+	oauth2IdentityKV      sync.Map
 
 	TokenGenerator platform.TokenGenerator
 	IDGenerator    platform.IDGenerator
@@ -148,6 +148,7 @@ func (s *Service) UpdateTelegrafConfig(ctx context.Context, id platform.ID, tc *
 	}
 	tc.Created = oldTc.Created
 	tc.ID = id
+	tc.OrganizationID = oldTc.OrganizationID
 	tc.LastMod = now
 	tc.LastModBy = userID
 	pErr = s.putTelegrafConfig(ctx, tc)
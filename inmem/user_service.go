@@ -151,5 +151,6 @@ func (s *Service) DeleteUser(ctx context.Context, id platform.ID) error {
 		return err
 	}
 	s.userKV.Delete(id.String())
+	s.deleteUserOAuth2Identities(id)
 	return nil
 }
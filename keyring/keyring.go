@@ -0,0 +1,201 @@
+// Package keyring encrypts values with AES-GCM under a set of named keys.
+//
+// Values are sealed with the primary key of the keyring and prefixed with
+// its ID, so a sealed value can be opened by any keyring that still holds
+// the key it was sealed with. Keys are rotated by making a new key primary
+// while keeping the old keys in the keyring until every value is sealed
+// again with the new key.
+package keyring
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// KeySize is the size in bytes of a key. Keys are AES-256 keys.
+const KeySize = 32
+
+// sealedPrefix begins every sealed value. It can not appear in base64
+// encoded data, so sealed values can be told apart from legacy values.
+const sealedPrefix = "$aesgcm$"
+
+// ErrUnknownKey is returned when opening a value sealed with a key that
+// is not in the keyring.
+var ErrUnknownKey = errors.New("value is sealed with a key that is not in the keyring")
+
+// Key is a named encryption key.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// Keyring seals values with its primary key and opens values sealed with
+// any of its keys.
+type Keyring struct {
+	primary string
+	aeads   map[string]cipher.AEAD
+}
+
+// New returns a keyring holding keys. The first key is the primary key.
+func New(keys ...Key) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keyring must have at least one key")
+	}
+
+	r := &Keyring{
+		primary: keys[0].ID,
+		aeads:   make(map[string]cipher.AEAD, len(keys)),
+	}
+	for _, k := range keys {
+		if k.ID == "" || strings.ContainsAny(k.ID, "$:, \t\r\n") {
+			return nil, fmt.Errorf("invalid key id %q", k.ID)
+		}
+		if len(k.Secret) != KeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes, got %d", k.ID, KeySize, len(k.Secret))
+		}
+		if _, ok := r.aeads[k.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", k.ID)
+		}
+
+		block, err := aes.NewCipher(k.Secret)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		r.aeads[k.ID] = aead
+	}
+	return r, nil
+}
+
+// Parse returns a keyring from its text form. Keys are separated by
+// newlines or commas, and each key is written as its ID, a colon, and its
+// base64 encoded secret:
+//
+//	2018-11:N2FlZDZmYjI3ZjBkMzRmNzQ4MGE2NzE1MmU5ODQ4ZGQ=
+//
+// The first key is the primary key. Blank lines and lines starting with #
+// are ignored.
+func Parse(s string) (*Keyring, error) {
+	var keys []Key
+	for n, line := range strings.FieldsFunc(s, func(r rune) bool { return r == '\n' || r == ',' }) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// The line is not part of errors, so secrets do not end up in logs.
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid key %d: expected <id>:<base64 secret>", n+1)
+		}
+		id := strings.TrimSpace(line[:i])
+		secret, err := base64.StdEncoding.DecodeString(strings.TrimSpace(line[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %v", id, err)
+		}
+		keys = append(keys, Key{ID: id, Secret: secret})
+	}
+	return New(keys...)
+}
+
+// Load returns the keyring written in its text form to the file at path.
+func Load(path string) (*Keyring, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(b))
+}
+
+// GenerateKey returns a new random key named id.
+func GenerateKey(id string) (Key, error) {
+	secret := make([]byte, KeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, err
+	}
+	return Key{ID: id, Secret: secret}, nil
+}
+
+// String returns the text form of the key, as read by Parse.
+func (k Key) String() string {
+	return k.ID + ":" + base64.StdEncoding.EncodeToString(k.Secret)
+}
+
+// PrimaryID returns the ID of the key values are sealed with.
+func (r *Keyring) PrimaryID() string {
+	return r.primary
+}
+
+// Seal encrypts and authenticates plaintext and authenticates data with
+// the primary key. The same data must be given to Open.
+func (r *Keyring) Seal(plaintext, data []byte) ([]byte, error) {
+	aead := r.aeads[r.primary]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, data)
+
+	v := make([]byte, 0, len(sealedPrefix)+len(r.primary)+1+base64.RawStdEncoding.EncodedLen(len(sealed)))
+	v = append(v, sealedPrefix...)
+	v = append(v, r.primary...)
+	v = append(v, '$')
+	v = append(v, base64.RawStdEncoding.EncodeToString(sealed)...)
+	return v, nil
+}
+
+// Open decrypts and authenticates a value returned by Seal.
+func (r *Keyring) Open(v, data []byte) ([]byte, error) {
+	id, sealed, err := splitSealed(v)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, ok := r.aeads[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("sealed value is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, data)
+}
+
+// SealedWithPrimary returns true if v is sealed with the primary key.
+func (r *Keyring) SealedWithPrimary(v []byte) bool {
+	id, _, err := splitSealed(v)
+	return err == nil && id == r.primary
+}
+
+// IsSealed returns true if v was returned by Seal.
+func IsSealed(v []byte) bool {
+	return bytes.HasPrefix(v, []byte(sealedPrefix))
+}
+
+func splitSealed(v []byte) (string, []byte, error) {
+	if !IsSealed(v) {
+		return "", nil, errors.New("value is not sealed")
+	}
+	v = v[len(sealedPrefix):]
+
+	i := bytes.IndexByte(v, '$')
+	if i < 0 {
+		return "", nil, errors.New("sealed value is missing its key id")
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(string(v[i+1:]))
+	if err != nil {
+		return "", nil, err
+	}
+	return string(v[:i]), sealed, nil
+}
@@ -0,0 +1,113 @@
+package keyring_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/influxdata/platform/keyring"
+)
+
+func mustGenerateKey(t *testing.T, id string) keyring.Key {
+	t.Helper()
+	k, err := keyring.GenerateKey(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	r, err := keyring.New(mustGenerateKey(t, "k1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := r.Seal([]byte("hunter2"), []byte("org/key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !keyring.IsSealed(v) {
+		t.Fatalf("expected %q to be sealed", v)
+	}
+	if bytes.Contains(v, []byte("hunter2")) {
+		t.Fatalf("sealed value %q contains the plaintext", v)
+	}
+
+	got, err := r.Open(v, []byte("org/key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hunter2" {
+		t.Fatalf("got %q, exp %q", got, "hunter2")
+	}
+
+	if _, err := r.Open(v, []byte("org/other")); err == nil {
+		t.Fatal("expected opening with different data to fail")
+	}
+}
+
+func TestKeyring_Rotate(t *testing.T) {
+	k1, k2 := mustGenerateKey(t, "k1"), mustGenerateKey(t, "k2")
+
+	old, err := keyring.New(k1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := old.Seal([]byte("hunter2"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := keyring.Parse(k2.String() + "\n# previous key\n" + k1.String() + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.SealedWithPrimary(v) {
+		t.Fatal("expected value not to be sealed with the new primary key")
+	}
+	if got, err := rotated.Open(v, nil); err != nil {
+		t.Fatal(err)
+	} else if string(got) != "hunter2" {
+		t.Fatalf("got %q, exp %q", got, "hunter2")
+	}
+
+	v, err = rotated.Seal([]byte("hunter2"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rotated.SealedWithPrimary(v) {
+		t.Fatal("expected value to be sealed with the new primary key")
+	}
+	if _, err := old.Open(v, nil); err != keyring.ErrUnknownKey {
+		t.Fatalf("got error %v, exp %v", err, keyring.ErrUnknownKey)
+	}
+}
+
+func TestParse(t *testing.T) {
+	k1, k2 := mustGenerateKey(t, "k1"), mustGenerateKey(t, "k2")
+
+	tests := []struct {
+		name    string
+		s       string
+		primary string
+		wantErr bool
+	}{
+		{name: "comma separated", s: k1.String() + "," + k2.String(), primary: "k1"},
+		{name: "newline separated", s: k2.String() + "\n\n" + k1.String(), primary: "k2"},
+		{name: "empty", s: "# no keys\n", wantErr: true},
+		{name: "missing id", s: "Zm9v", wantErr: true},
+		{name: "short key", s: "k1:Zm9v", wantErr: true},
+		{name: "duplicate id", s: k1.String() + "," + k1.String(), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := keyring.Parse(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && r.PrimaryID() != tt.primary {
+				t.Fatalf("got primary key %q, exp %q", r.PrimaryID(), tt.primary)
+			}
+		})
+	}
+}
@@ -2,11 +2,15 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"reflect"
 	"strings"
+	"time"
 
+	"github.com/BurntSushi/toml"
+	itoml "github.com/influxdata/platform/toml"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"time"
 )
 
 // Opt is a single command-line option
@@ -35,6 +39,12 @@ type Program struct {
 	Name string
 	// Opts are the command line/env var options to the program
 	Opts []Opt
+	// Config is an optional pointer to a struct with toml tags, read from the
+	// TOML file of the --config flag before the program runs. Environment
+	// variables named after the toml keys override the file, and options set
+	// with flags or environment variables override both. Opts may point into
+	// Config.
+	Config interface{}
 }
 
 // NewCommand creates a new cobra command to be executed that respects env vars.
@@ -57,6 +67,16 @@ func NewCommand(p *Program) *cobra.Command {
 	// This normalizes "-" to an underscore in env names.
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 
+	if p.Config != nil {
+		// The config is loaded for subcommands as well, as their own
+		// settings may come from the file.
+		cmd.PersistentFlags().String("config", "", "path to the TOML configuration file")
+		viper.BindPFlag("config", cmd.PersistentFlags().Lookup("config"))
+		cmd.PersistentPreRunE = func(c *cobra.Command, _ []string) error {
+			return p.loadConfig(c, viper.GetString("config"))
+		}
+	}
+
 	for _, o := range p.Opts {
 		switch o.DestP.(type) {
 		case *string:
@@ -103,3 +123,38 @@ func NewCommand(p *Program) *cobra.Command {
 
 	return cmd
 }
+
+// loadConfig reads the TOML file at path into the config of the program and
+// applies the environment variable overrides of the config. The options set
+// with the flags of cmd or with environment variables keep their values.
+func (p *Program) loadConfig(cmd *cobra.Command, path string) error {
+	prefix := strings.ToUpper(p.Name)
+
+	var restore []func()
+	for _, o := range p.Opts {
+		f := cmd.Flags().Lookup(o.Flag)
+		_, env := os.LookupEnv(prefix + "_" + strings.ToUpper(strings.Replace(o.Flag, "-", "_", -1)))
+		if (f == nil || !f.Changed) && !env {
+			continue
+		}
+
+		v := reflect.ValueOf(o.DestP).Elem()
+		saved := reflect.New(v.Type()).Elem()
+		saved.Set(v)
+		restore = append(restore, func() { v.Set(saved) })
+	}
+
+	if path != "" {
+		if _, err := toml.DecodeFile(path, p.Config); err != nil {
+			return fmt.Errorf("failed to read config file %q: %v", path, err)
+		}
+	}
+	if err := itoml.ApplyEnvOverrides(os.Getenv, prefix, p.Config); err != nil {
+		return err
+	}
+
+	for _, r := range restore {
+		r()
+	}
+	return nil
+}
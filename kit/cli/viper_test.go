@@ -2,7 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"testing"
 	"time"
 )
 
@@ -69,3 +71,76 @@ func ExampleNewCommand() {
 	// 1m0s
 	// [foo bar]
 }
+
+func TestNewCommand_Config(t *testing.T) {
+	var config struct {
+		Host    string `toml:"host"`
+		Number  int    `toml:"number"`
+		Verbose bool   `toml:"verbose"`
+		Nested  struct {
+			Path string `toml:"path"`
+		} `toml:"nested"`
+	}
+
+	f, err := ioutil.TempFile("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(`
+host = "file"
+number = 1
+verbose = true
+
+[nested]
+  path = "file"
+`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	os.Setenv("CONFIGPROGRAM_NUMBER", "2")
+	defer os.Unsetenv("CONFIGPROGRAM_NUMBER")
+	os.Setenv("CONFIGPROGRAM_NESTED_PATH", "env")
+	defer os.Unsetenv("CONFIGPROGRAM_NESTED_PATH")
+
+	cmd := NewCommand(&Program{
+		Run:    func() error { return nil },
+		Name:   "configprogram",
+		Config: &config,
+		Opts: []Opt{
+			{
+				DestP:   &config.Host,
+				Flag:    "host",
+				Default: "default",
+			},
+			{
+				DestP:   &config.Number,
+				Flag:    "number",
+				Default: 0,
+			},
+			{
+				DestP:   &config.Verbose,
+				Flag:    "verbose",
+				Default: false,
+			},
+		},
+	})
+	cmd.SetArgs([]string{"--config", f.Name(), "--host", "flag"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := config.Host, "flag"; got != exp {
+		t.Errorf("unexpected host from flag: got %q, exp %q", got, exp)
+	}
+	if got, exp := config.Number, 2; got != exp {
+		t.Errorf("unexpected number from env: got %d, exp %d", got, exp)
+	}
+	if got, exp := config.Verbose, true; got != exp {
+		t.Errorf("unexpected verbose from file: got %v, exp %v", got, exp)
+	}
+	if got, exp := config.Nested.Path, "env"; got != exp {
+		t.Errorf("unexpected nested path from env: got %q, exp %q", got, exp)
+	}
+}
@@ -0,0 +1,91 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+)
+
+// Limits are the quotas of an organization. Zero means no limit.
+type Limits struct {
+	OrganizationID ID `json:"orgID"`
+
+	// WriteBytesPerSecond is the rate of line protocol the organization
+	// may write.
+	WriteBytesPerSecond int `json:"writeBytesPerSecond"`
+	// MaxSeries is the number of series the buckets of the organization
+	// may hold before writes are rejected.
+	MaxSeries int `json:"maxSeries"`
+	// MaxConcurrentQueries is the number of queries of the organization
+	// that may run at once.
+	MaxConcurrentQueries int `json:"maxConcurrentQueries"`
+	// MaxBuckets is the number of buckets the organization may have.
+	MaxBuckets int `json:"maxBuckets"`
+}
+
+// limit service op
+const (
+	OpFindLimits   = "FindLimits"
+	OpUpdateLimits = "UpdateLimits"
+)
+
+// LimitService manages the limits of organizations.
+type LimitService interface {
+	// FindLimits returns the limits of the organization orgID. An
+	// organization without limits set has zero Limits.
+	FindLimits(ctx context.Context, orgID ID) (*Limits, error)
+
+	// UpdateLimits updates the limits of the organization orgID.
+	UpdateLimits(ctx context.Context, orgID ID, upd LimitsUpdate) (*Limits, error)
+}
+
+// LimitsUpdate represents updates to the limits of an organization.
+// Only fields which are set are updated.
+type LimitsUpdate struct {
+	WriteBytesPerSecond  *int `json:"writeBytesPerSecond,omitempty"`
+	MaxSeries            *int `json:"maxSeries,omitempty"`
+	MaxConcurrentQueries *int `json:"maxConcurrentQueries,omitempty"`
+	MaxBuckets           *int `json:"maxBuckets,omitempty"`
+}
+
+// Valid returns an error if the limits update is invalid.
+func (u LimitsUpdate) Valid() error {
+	for name, v := range map[string]*int{
+		"writeBytesPerSecond":  u.WriteBytesPerSecond,
+		"maxSeries":            u.MaxSeries,
+		"maxConcurrentQueries": u.MaxConcurrentQueries,
+		"maxBuckets":           u.MaxBuckets,
+	} {
+		if v != nil && *v < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// Apply applies the update to the limits l.
+func (u LimitsUpdate) Apply(l *Limits) {
+	if u.WriteBytesPerSecond != nil {
+		l.WriteBytesPerSecond = *u.WriteBytesPerSecond
+	}
+	if u.MaxSeries != nil {
+		l.MaxSeries = *u.MaxSeries
+	}
+	if u.MaxConcurrentQueries != nil {
+		l.MaxConcurrentQueries = *u.MaxConcurrentQueries
+	}
+	if u.MaxBuckets != nil {
+		l.MaxBuckets = *u.MaxBuckets
+	}
+}
+
+// LimitEnforcer enforces the limits of organizations on their writes and
+// queries. Exceeding a limit returns an error with code ETooManyRequests.
+type LimitEnforcer interface {
+	// AllowWrite returns an error if the organization orgID may not write
+	// now. The bytes of line protocol written are then passed to charge.
+	AllowWrite(ctx context.Context, orgID ID) (charge func(n int), err error)
+
+	// AcquireQuery reserves one of the concurrent queries of the
+	// organization orgID. release is called once the query is done.
+	AcquireQuery(ctx context.Context, orgID ID) (release func(), err error)
+}
@@ -30,10 +30,11 @@ type MacroService interface {
 // A Macro describes a keyword that can be expanded into several possible
 // values when used in an InfluxQL or Flux query
 type Macro struct {
-	ID        ID              `json:"id,omitempty"`
-	Name      string          `json:"name"`
-	Selected  []string        `json:"selected"`
-	Arguments *MacroArguments `json:"arguments"`
+	ID             ID              `json:"id,omitempty"`
+	OrganizationID ID              `json:"orgID,omitempty"`
+	Name           string          `json:"name"`
+	Selected       []string        `json:"selected"`
+	Arguments      *MacroArguments `json:"arguments"`
 }
 
 // A MacroUpdate describes a set of changes that can be applied to a Macro
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.BackupService = (*BackupService)(nil)
+
+// BackupService backs up an instance.
+type BackupService struct {
+	BackupF func(ctx context.Context, w io.Writer, since time.Time) error
+}
+
+// Backup calls the mocked BackupF function with arguments.
+func (s *BackupService) Backup(ctx context.Context, w io.Writer, since time.Time) error {
+	return s.BackupF(ctx, w, since)
+}
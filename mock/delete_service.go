@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+// DeleteService deletes data from buckets.
+type DeleteService struct {
+	DeleteBucketRangePredicateF func(ctx context.Context, orgID, bucketID platform.ID, start, stop int64, predicate string) error
+}
+
+// DeleteBucketRangePredicate calls the mocked DeleteBucketRangePredicateF function with arguments.
+func (s *DeleteService) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID platform.ID, start, stop int64, predicate string) error {
+	return s.DeleteBucketRangePredicateF(ctx, orgID, bucketID, start, stop, predicate)
+}
+
+// StoragePurger removes the stored data of buckets and organizations.
+type StoragePurger struct {
+	PurgeBucketF       func(ctx context.Context, orgID, bucketID platform.ID) error
+	PurgeOrganizationF func(ctx context.Context, orgID platform.ID) error
+}
+
+// PurgeBucket calls the mocked PurgeBucketF function with arguments.
+func (s *StoragePurger) PurgeBucket(ctx context.Context, orgID, bucketID platform.ID) error {
+	return s.PurgeBucketF(ctx, orgID, bucketID)
+}
+
+// PurgeOrganization calls the mocked PurgeOrganizationF function with arguments.
+func (s *StoragePurger) PurgeOrganization(ctx context.Context, orgID platform.ID) error {
+	return s.PurgeOrganizationF(ctx, orgID)
+}
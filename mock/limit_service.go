@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.LimitService = (*LimitService)(nil)
+var _ platform.LimitEnforcer = (*LimitEnforcer)(nil)
+
+// LimitService manages the limits of organizations.
+type LimitService struct {
+	FindLimitsF   func(ctx context.Context, orgID platform.ID) (*platform.Limits, error)
+	UpdateLimitsF func(ctx context.Context, orgID platform.ID, upd platform.LimitsUpdate) (*platform.Limits, error)
+}
+
+// FindLimits calls the mocked FindLimitsF function with arguments.
+func (s *LimitService) FindLimits(ctx context.Context, orgID platform.ID) (*platform.Limits, error) {
+	return s.FindLimitsF(ctx, orgID)
+}
+
+// UpdateLimits calls the mocked UpdateLimitsF function with arguments.
+func (s *LimitService) UpdateLimits(ctx context.Context, orgID platform.ID, upd platform.LimitsUpdate) (*platform.Limits, error) {
+	return s.UpdateLimitsF(ctx, orgID, upd)
+}
+
+// LimitEnforcer enforces the limits of organizations.
+type LimitEnforcer struct {
+	AllowWriteF   func(ctx context.Context, orgID platform.ID) (func(n int), error)
+	AcquireQueryF func(ctx context.Context, orgID platform.ID) (func(), error)
+}
+
+// AllowWrite calls the mocked AllowWriteF function with arguments.
+func (e *LimitEnforcer) AllowWrite(ctx context.Context, orgID platform.ID) (func(n int), error) {
+	return e.AllowWriteF(ctx, orgID)
+}
+
+// AcquireQuery calls the mocked AcquireQueryF function with arguments.
+func (e *LimitEnforcer) AcquireQuery(ctx context.Context, orgID platform.ID) (func(), error) {
+	return e.AcquireQueryF(ctx, orgID)
+}
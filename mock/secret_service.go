@@ -0,0 +1,56 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.SecretService = (*SecretService)(nil)
+
+// SecretService is a mock implementation of platform.SecretService.
+type SecretService struct {
+	LoadSecretFn    func(ctx context.Context, orgID platform.ID, k string) (string, error)
+	GetSecretKeysFn func(ctx context.Context, orgID platform.ID) ([]string, error)
+	PutSecretFn     func(ctx context.Context, orgID platform.ID, k string, v string) error
+	DeleteSecretFn  func(ctx context.Context, orgID platform.ID, ks ...string) error
+}
+
+// NewSecretService returns a mock SecretService where its methods will return
+// zero values.
+func NewSecretService() *SecretService {
+	return &SecretService{
+		LoadSecretFn: func(ctx context.Context, orgID platform.ID, k string) (string, error) {
+			return "", nil
+		},
+		GetSecretKeysFn: func(ctx context.Context, orgID platform.ID) ([]string, error) {
+			return nil, nil
+		},
+		PutSecretFn: func(ctx context.Context, orgID platform.ID, k string, v string) error {
+			return nil
+		},
+		DeleteSecretFn: func(ctx context.Context, orgID platform.ID, ks ...string) error {
+			return nil
+		},
+	}
+}
+
+// LoadSecret retrieves the secret value v found at key k for organization orgID.
+func (s *SecretService) LoadSecret(ctx context.Context, orgID platform.ID, k string) (string, error) {
+	return s.LoadSecretFn(ctx, orgID, k)
+}
+
+// GetSecretKeys retrieves all secret keys that are stored for the organization orgID.
+func (s *SecretService) GetSecretKeys(ctx context.Context, orgID platform.ID) ([]string, error) {
+	return s.GetSecretKeysFn(ctx, orgID)
+}
+
+// PutSecret stores the secret pair (k,v) for the organization orgID.
+func (s *SecretService) PutSecret(ctx context.Context, orgID platform.ID, k string, v string) error {
+	return s.PutSecretFn(ctx, orgID, k, v)
+}
+
+// DeleteSecret removes the secrets with keys ks for the organization orgID.
+func (s *SecretService) DeleteSecret(ctx context.Context, orgID platform.ID, ks ...string) error {
+	return s.DeleteSecretFn(ctx, orgID, ks...)
+}
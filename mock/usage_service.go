@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/platform"
+)
+
+var _ platform.UsageService = (*UsageService)(nil)
+var _ platform.UsageRecorder = (*UsageRecorder)(nil)
+
+// UsageService returns the usage of organizations.
+type UsageService struct {
+	GetUsageF func(ctx context.Context, filter platform.UsageFilter) (map[platform.UsageMetric]*platform.Usage, error)
+}
+
+// GetUsage calls the mocked GetUsageF function with arguments.
+func (s *UsageService) GetUsage(ctx context.Context, filter platform.UsageFilter) (map[platform.UsageMetric]*platform.Usage, error) {
+	return s.GetUsageF(ctx, filter)
+}
+
+// UsageRecorder records the usage of organizations.
+type UsageRecorder struct {
+	RecordUsageF func(ctx context.Context, orgID, bucketID platform.ID, m platform.UsageMetric, value float64, t time.Time)
+}
+
+// RecordUsage calls the mocked RecordUsageF function with arguments.
+func (r *UsageRecorder) RecordUsage(ctx context.Context, orgID, bucketID platform.ID, m platform.UsageMetric, value float64, t time.Time) {
+	r.RecordUsageF(ctx, orgID, bucketID, m, value, t)
+}
@@ -43,6 +43,9 @@ var (
 
 	// ErrInvalidPoint is returned when a point cannot be parsed correctly.
 	ErrInvalidPoint = errors.New("point is invalid")
+
+	// ErrBadTimestamp is returned when the timestamp of a point is not an integer.
+	ErrBadTimestamp = errors.New("bad timestamp")
 )
 
 const (
@@ -172,6 +175,8 @@ func (t FieldType) String() string {
 		return "Boolean"
 	case String:
 		return "String"
+	case Unsigned:
+		return "Unsigned"
 	case Empty:
 		return "Empty"
 	default:
@@ -394,6 +399,62 @@ func ParsePointsWithPrecision(buf []byte, defaultTime time.Time, precision strin
 
 }
 
+// ParsePointsByLine is similar to ParsePointsWithPrecision, but parses each
+// line independently. fn is called with the line number, starting at 1, of
+// every line holding a point, along with the parsed point or the error
+// encountered while parsing it. Unlike ParsePointsWithPrecision, the error
+// is passed as is, so callers can tell why the point is invalid.
+func ParsePointsByLine(buf []byte, defaultTime time.Time, precision string, fn func(line int, pt Point, err error)) {
+	var (
+		pos   int
+		block []byte
+		line  = 1
+	)
+	for pos < len(buf) {
+		pos, block = scanLine(buf, pos)
+		pos++
+
+		// quoted string fields may span several lines
+		n := line
+		line += bytes.Count(block, []byte{'\n'}) + 1
+
+		if len(block) == 0 {
+			continue
+		}
+
+		// lines which start with '#' are comments
+		start := skipWhitespace(block, 0)
+
+		// If line is all whitespace, just skip it
+		if start >= len(block) {
+			continue
+		}
+
+		if block[start] == '#' {
+			continue
+		}
+
+		pt, err := parsePoint(block[start:], defaultTime, precision)
+		fn(n, pt, err)
+	}
+}
+
+// CompleteLines returns the length of the longest prefix of buf made of
+// complete lines, that is lines ending with a newline outside of a quoted
+// string field. It allows line protocol to be parsed in chunks.
+func CompleteLines(buf []byte) int {
+	var n, pos int
+	for pos < len(buf) {
+		pos, _ = scanLine(buf, pos)
+		if pos >= len(buf) {
+			break
+		}
+		pos++
+		n = pos
+	}
+	return n
+}
+
 func parsePoint(buf []byte, defaultTime time.Time, precision string) (Point, error) {
 	// scan the first block which is measurement[,tag1=value1,tag2=value=2...]
 	pos, key, err := scanKey(buf, 0)
@@ -880,7 +941,7 @@ func scanTime(buf []byte, i int) (int, []byte, error) {
 		// Timestamps should be integers, make sure they are so we don't need
 		// to actually  parse the timestamp until needed.
 		if buf[i] < '0' || buf[i] > '9' {
-			return i, buf[start:i], fmt.Errorf("bad timestamp")
+			return i, buf[start:i], ErrBadTimestamp
 		}
 		i++
 	}
@@ -1834,6 +1834,67 @@ func TestParsePointsWithPrecision(t *testing.T) {
 	}
 }
 
+func TestCompleteLines(t *testing.T) {
+	tests := []struct {
+		buf  string
+		want int
+	}{
+		{buf: "", want: 0},
+		{buf: "cpu value=1", want: 0},
+		{buf: "cpu value=1\n", want: 12},
+		{buf: "cpu value=1\ncpu value=2", want: 12},
+		{buf: "cpu value=1\ncpu text=\"a\nb", want: 12},
+		{buf: "cpu value=1\ncpu text=\"a\nb\"\n", want: 27},
+	}
+	for _, tt := range tests {
+		if got := models.CompleteLines([]byte(tt.buf)); got != tt.want {
+			t.Errorf("CompleteLines(%q) = %d, want %d", tt.buf, got, tt.want)
+		}
+	}
+}
+
+func TestParsePointsByLine(t *testing.T) {
+	buf := []byte("# comment\ncpu value=1 1000\n\ncpu,host=a\ncpu text=\"a\nb\" 2000\ncpu value=2 10a0\ncpu value=3 1000")
+
+	type result struct {
+		line int
+		err  error
+	}
+	var got []result
+	models.ParsePointsByLine(buf, time.Now(), "ns", func(line int, pt models.Point, err error) {
+		if err == nil && pt == nil {
+			t.Fatalf("line %d: expected a point or an error", line)
+		}
+		got = append(got, result{line: line, err: err})
+	})
+
+	want := []struct {
+		line  int
+		isErr bool
+		err   error
+	}{
+		{line: 2},
+		{line: 4, isErr: true},
+		{line: 5},
+		{line: 7, isErr: true, err: models.ErrBadTimestamp},
+		{line: 8},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %v", len(want), len(got), got)
+	}
+	for i, w := range want {
+		if got[i].line != w.line {
+			t.Errorf("%d. expected line %d, got %d", i, w.line, got[i].line)
+		}
+		if (got[i].err != nil) != w.isErr {
+			t.Errorf("line %d: unexpected error %v", w.line, got[i].err)
+		}
+		if w.err != nil && got[i].err != w.err {
+			t.Errorf("line %d: expected error %v, got %v", w.line, w.err, got[i].err)
+		}
+	}
+}
+
 func TestParsePointsWithPrecisionNoTime(t *testing.T) {
 	line := `cpu,host=serverA,region=us-east value=1.0`
 	tm, _ := time.Parse(time.RFC3339Nano, "2000-01-01T12:34:56.789012345Z")
@@ -0,0 +1,23 @@
+package platform
+
+import "context"
+
+// OAuth2Identity links the identity of a user at an OAuth2 provider to a
+// user, so that the user may sign in with the provider. Identities are
+// keyed by the provider that issued them and the subject the provider
+// knows the user by, as subjects are only unique within their provider.
+type OAuth2Identity struct {
+	Issuer  string `json:"issuer"`
+	Subject string `json:"subject"`
+	UserID  ID     `json:"userID"`
+}
+
+// OAuth2IdentityService links the identities of OAuth2 providers to users.
+type OAuth2IdentityService interface {
+	// FindOAuth2Identity returns the identity of subject at the issuer.
+	FindOAuth2Identity(ctx context.Context, issuer, subject string) (*OAuth2Identity, error)
+
+	// CreateOAuth2Identity links the identity i to its user. An identity
+	// may only be linked to a single user.
+	CreateOAuth2Identity(ctx context.Context, i *OAuth2Identity) error
+}
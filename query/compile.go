@@ -0,0 +1,56 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/query/options"
+)
+
+// Compile compiles the Flux query q into a spec like flux.Compile does, and
+// resolves the secrets read by the query with secrets.get from the secrets
+// of organization orgID with svc. Secrets are loaded with ctx, so an
+// authorizing svc only loads them if the authorizer on ctx may read them.
+// If svc is nil, reading a secret fails.
+func Compile(ctx context.Context, q string, now time.Time, svc platform.SecretService, orgID platform.ID) (*flux.Spec, error) {
+	astProg, err := parser.NewAST(q)
+	if err != nil {
+		return nil, err
+	}
+	semProg, err := semantic.New(astProg)
+	if err != nil {
+		return nil, err
+	}
+
+	itrp := flux.NewInterpreter()
+	itrp.SetOption("now", nowFunc(now))
+	var load func(string) (string, error)
+	if svc != nil {
+		load = func(k string) (string, error) {
+			return svc.LoadSecret(ctx, orgID, k)
+		}
+	}
+	itrp.SetOption(options.SecretsOption, options.SecretsObject(load))
+
+	if err := itrp.Eval(semProg); err != nil {
+		return nil, err
+	}
+	return flux.ToSpec(itrp, itrp.SideEffects()...), nil
+}
+
+func nowFunc(now time.Time) values.Function {
+	timeVal := values.NewTime(values.ConvertTime(now))
+	ftype := semantic.NewFunctionType(semantic.FunctionSignature{
+		Return: semantic.Time,
+	})
+	call := func(args values.Object) (values.Value, error) {
+		return timeVal, nil
+	}
+	sideEffect := false
+	return values.NewFunction("now", ftype, call, sideEffect)
+}
@@ -0,0 +1,87 @@
+package query_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux/functions/inputs"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
+	pcontext "github.com/influxdata/platform/context"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/query"
+	_ "github.com/influxdata/platform/query/builtin"
+)
+
+func TestCompile_Secrets(t *testing.T) {
+	secrets := mock.NewSecretService()
+	secrets.LoadSecretFn = func(ctx context.Context, orgID platform.ID, k string) (string, error) {
+		if orgID != 1 || k != "bucket" {
+			return "", &platform.Error{Code: platform.ENotFound, Msg: "secret not found"}
+		}
+		return "my_bucket", nil
+	}
+
+	tests := []struct {
+		name        string
+		svc         platform.SecretService
+		permissions []platform.Permission
+		wantErr     bool
+	}{
+		{
+			name: "secret of the organization",
+			svc:  secrets,
+		},
+		{
+			name:    "no secret service",
+			wantErr: true,
+		},
+		{
+			name: "authorized to read the secrets of the organization",
+			svc:  authorizer.NewSecretService(secrets),
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.SecretResourceType, 1)},
+			},
+		},
+		{
+			name: "unauthorized to read the secrets of the organization",
+			svc:  authorizer.NewSecretService(secrets),
+			permissions: []platform.Permission{
+				{Action: platform.ReadAction, Resource: platform.ResourceInOrg(platform.BucketResourceType, 1)},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := pcontext.SetAuthorizer(context.Background(), &platform.Authorization{
+				ID:          1,
+				UserID:      2,
+				Status:      platform.Active,
+				Permissions: tt.permissions,
+			})
+
+			q := `from(bucket: secrets.get(key: "bucket")) |> range(start: -1h)`
+			spec, err := query.Compile(ctx, q, time.Now(), tt.svc, 1)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error reading the secret")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			from, ok := spec.Operations[0].Spec.(*inputs.FromOpSpec)
+			if !ok {
+				t.Fatalf("unexpected first operation %T", spec.Operations[0].Spec)
+			}
+			if from.Bucket != "my_bucket" {
+				t.Errorf("expected bucket %q, got %q", "my_bucket", from.Bucket)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/values"
+)
+
+// SecretsOption is the name of the option holding the secrets of the
+// organization running a query. Secrets are read with secrets.get(key: "name").
+const SecretsOption = "secrets"
+
+func init() {
+	flux.RegisterBuiltInOption(SecretsOption, SecretsObject(nil))
+}
+
+// SecretsObject returns the value of the secrets option. Its get function
+// loads the value of a secret key with load. If load is nil, get returns
+// an error, as no secrets are available.
+func SecretsObject(load func(key string) (string, error)) values.Object {
+	ftype := semantic.NewFunctionType(semantic.FunctionSignature{
+		Parameters: map[string]semantic.Type{
+			"key": semantic.String,
+		},
+		Required: semantic.LabelSet{"key"},
+		Return:   semantic.String,
+	})
+	call := func(args values.Object) (values.Value, error) {
+		v, ok := args.Get("key")
+		if !ok {
+			return nil, fmt.Errorf("missing required keyword argument %q", "key")
+		}
+		if v.Type() != semantic.String {
+			return nil, fmt.Errorf("keyword argument %q must be a string", "key")
+		}
+		if load == nil {
+			return nil, fmt.Errorf("secrets are not available")
+		}
+
+		s, err := load(v.Str())
+		if err != nil {
+			return nil, fmt.Errorf("unable to load secret %q: %v", v.Str(), err)
+		}
+		return values.NewString(s), nil
+	}
+	sideEffect := false
+
+	obj := values.NewObject()
+	obj.Set("get", values.NewFunction("get", ftype, call, sideEffect))
+	return obj
+}
@@ -43,7 +43,7 @@ func (a *preAuthorizer) PreAuthorize(ctx context.Context, spec *flux.Spec, auth
 			return errors.New("Bucket service returned nil bucket")
 		}
 
-		reqPerm := platform.ReadBucketPermission(bucket.ID)
+		reqPerm := platform.NewPermission(platform.ReadAction, platform.BucketResourceType, bucket.OrganizationID, bucket.ID)
 		if !auth.Allowed(reqPerm) {
 			return errors.New("No read permission for bucket: \"" + bucket.Name + "\"")
 		}
@@ -55,7 +55,7 @@ func (a *preAuthorizer) PreAuthorize(ctx context.Context, spec *flux.Spec, auth
 			return errors.Wrapf(err, "Could not find bucket %v", writeBucketFilter)
 		}
 
-		reqPerm := platform.WriteBucketPermission(bucket.ID)
+		reqPerm := platform.NewPermission(platform.WriteAction, platform.BucketResourceType, bucket.OrganizationID, bucket.ID)
 		if !auth.Allowed(reqPerm) {
 			return errors.New("No write permission for bucket: \"" + bucket.Name + "\"")
 		}
@@ -0,0 +1,242 @@
+// Package quota enforces the limits of organizations on their writes and
+// queries.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/pkg/limiter"
+	"go.uber.org/zap"
+)
+
+// DefaultRefreshInterval is the default interval the limits and series
+// counts of organizations are refreshed at.
+const DefaultRefreshInterval = 10 * time.Second
+
+// SeriesCounter counts the series of organizations.
+type SeriesCounter interface {
+	OrganizationSeriesCardinality(orgID platform.ID) int64
+}
+
+var _ platform.LimitEnforcer = (*Enforcer)(nil)
+var _ platform.LimitService = (*Enforcer)(nil)
+
+// Enforcer enforces the limits of organizations stored by its LimitService.
+// It also serves as the LimitService, so that updated limits are enforced
+// at once instead of once they are refreshed.
+type Enforcer struct {
+	Logger *zap.Logger
+
+	LimitService platform.LimitService
+	// SeriesCounter counts the series of organizations with a MaxSeries
+	// limit. The limit is not enforced when it is nil.
+	SeriesCounter SeriesCounter
+
+	// RefreshInterval is how long the limits and series counts of an
+	// organization are cached for.
+	RefreshInterval time.Duration
+	Now             func() time.Time
+
+	mu   sync.Mutex
+	orgs map[platform.ID]*orgState
+}
+
+// orgState holds the limits of an organization being enforced.
+type orgState struct {
+	limits   platform.Limits
+	loadedAt time.Time
+
+	writes  *byteRate
+	queries limiter.Fixed
+
+	series    int64
+	countedAt time.Time
+}
+
+// NewEnforcer returns an enforcer of the limits of ls.
+func NewEnforcer(ls platform.LimitService, sc SeriesCounter) *Enforcer {
+	return &Enforcer{
+		Logger:          zap.NewNop(),
+		LimitService:    ls,
+		SeriesCounter:   sc,
+		RefreshInterval: DefaultRefreshInterval,
+		Now:             time.Now,
+		orgs:            make(map[platform.ID]*orgState),
+	}
+}
+
+// FindLimits returns the limits of the organization orgID.
+func (e *Enforcer) FindLimits(ctx context.Context, orgID platform.ID) (*platform.Limits, error) {
+	return e.LimitService.FindLimits(ctx, orgID)
+}
+
+// UpdateLimits updates the limits of the organization orgID, and enforces
+// them from the next request.
+func (e *Enforcer) UpdateLimits(ctx context.Context, orgID platform.ID, upd platform.LimitsUpdate) (*platform.Limits, error) {
+	l, err := e.LimitService.UpdateLimits(ctx, orgID, upd)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	if st, ok := e.orgs[orgID]; ok {
+		st.loadedAt = time.Time{}
+	}
+	e.mu.Unlock()
+	return l, nil
+}
+
+// AllowWrite returns an error if the organization orgID wrote more than its
+// bytes per second, or has reached its maximum number of series. The bytes
+// written are passed to charge, so that the writes following a large write
+// wait until the organization is back under its rate.
+func (e *Enforcer) AllowWrite(ctx context.Context, orgID platform.ID) (charge func(n int), err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	st, err := e.state(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	now := e.Now()
+
+	if max := st.limits.MaxSeries; max > 0 && e.SeriesCounter != nil {
+		if now.Sub(st.countedAt) >= e.RefreshInterval {
+			st.series = e.SeriesCounter.OrganizationSeriesCardinality(orgID)
+			st.countedAt = now
+		}
+		if st.series >= int64(max) {
+			return nil, &platform.Error{
+				Code: platform.ETooManyRequests,
+				Op:   "quota/AllowWrite",
+				Msg:  fmt.Sprintf("organization has reached its limit of %d series", max),
+			}
+		}
+	}
+
+	writes := st.writes
+	if writes == nil {
+		return func(int) {}, nil
+	}
+	if !writes.allow(now) {
+		return nil, &platform.Error{
+			Code: platform.ETooManyRequests,
+			Op:   "quota/AllowWrite",
+			Msg:  fmt.Sprintf("organization exceeded its write limit of %d bytes per second", st.limits.WriteBytesPerSecond),
+		}
+	}
+	return func(n int) {
+		e.mu.Lock()
+		writes.take(n)
+		e.mu.Unlock()
+	}, nil
+}
+
+// AcquireQuery reserves one of the concurrent queries of the organization
+// orgID, or returns an error if all of them are running.
+func (e *Enforcer) AcquireQuery(ctx context.Context, orgID platform.ID) (release func(), err error) {
+	e.mu.Lock()
+	st, err := e.state(ctx, orgID)
+	e.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	queries := st.queries
+	if queries == nil {
+		return func() {}, nil
+	}
+	if !queries.TryTake() {
+		return nil, &platform.Error{
+			Code: platform.ETooManyRequests,
+			Op:   "quota/AcquireQuery",
+			Msg:  fmt.Sprintf("organization has reached its limit of %d concurrent queries", st.limits.MaxConcurrentQueries),
+		}
+	}
+	return queries.Release, nil
+}
+
+// state returns the state of the organization orgID, loading its limits if
+// they were not loaded during the refresh interval. The limiters of
+// unchanged limits are kept, as queries hold tokens of theirs.
+func (e *Enforcer) state(ctx context.Context, orgID platform.ID) (*orgState, error) {
+	st, ok := e.orgs[orgID]
+	now := e.Now()
+	if ok && now.Sub(st.loadedAt) < e.RefreshInterval {
+		return st, nil
+	}
+
+	l, err := e.LimitService.FindLimits(ctx, orgID)
+	if err != nil {
+		if ok {
+			// Keep enforcing the previous limits.
+			e.Logger.Info("Failed to refresh organization limits", zap.String("org_id", orgID.String()), zap.Error(err))
+			return st, nil
+		}
+		return nil, err
+	}
+
+	if !ok {
+		st = &orgState{}
+		e.orgs[orgID] = st
+	}
+	if l.WriteBytesPerSecond != st.limits.WriteBytesPerSecond {
+		st.writes = nil
+		if l.WriteBytesPerSecond > 0 {
+			st.writes = newByteRate(l.WriteBytesPerSecond, now)
+		}
+	}
+	if l.MaxConcurrentQueries != st.limits.MaxConcurrentQueries {
+		st.queries = nil
+		if l.MaxConcurrentQueries > 0 {
+			st.queries = limiter.NewFixed(l.MaxConcurrentQueries)
+		}
+	}
+	if l.MaxSeries != st.limits.MaxSeries {
+		st.countedAt = time.Time{}
+	}
+	st.limits = *l
+	st.loadedAt = now
+	return st, nil
+}
+
+// byteRate is a token bucket of bytes refilled at a rate per second. It
+// holds at most a second of bytes. Unlike golang.org/x/time/rate, bytes are
+// taken once they are written, even more than the bucket holds, so that a
+// write larger than a second of bytes is allowed while the bucket holds any
+// bytes, and the following writes wait until the bytes are paid back.
+type byteRate struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newByteRate(bytesPerSec int, now time.Time) *byteRate {
+	return &byteRate{
+		rate:   float64(bytesPerSec),
+		tokens: float64(bytesPerSec),
+		last:   now,
+	}
+}
+
+// allow refills the bucket up to now, and returns true if it holds any
+// bytes.
+func (r *byteRate) allow(now time.Time) bool {
+	if elapsed := now.Sub(r.last); elapsed > 0 {
+		r.tokens += elapsed.Seconds() * r.rate
+		if r.tokens > r.rate {
+			r.tokens = r.rate
+		}
+		r.last = now
+	}
+	return r.tokens > 0
+}
+
+// take takes n bytes from the bucket.
+func (r *byteRate) take(n int) {
+	r.tokens -= float64(n)
+}
@@ -0,0 +1,105 @@
+package quota_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/quota"
+)
+
+type seriesCounter func(orgID platform.ID) int64
+
+func (fn seriesCounter) OrganizationSeriesCardinality(orgID platform.ID) int64 {
+	return fn(orgID)
+}
+
+func newEnforcer(limits *platform.Limits, series int64) (*quota.Enforcer, *time.Time) {
+	ls := &mock.LimitService{
+		FindLimitsF: func(ctx context.Context, orgID platform.ID) (*platform.Limits, error) {
+			l := *limits
+			return &l, nil
+		},
+		UpdateLimitsF: func(ctx context.Context, orgID platform.ID, upd platform.LimitsUpdate) (*platform.Limits, error) {
+			upd.Apply(limits)
+			l := *limits
+			return &l, nil
+		},
+	}
+
+	now := time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)
+	e := quota.NewEnforcer(ls, seriesCounter(func(platform.ID) int64 { return series }))
+	e.Now = func() time.Time { return now }
+	return e, &now
+}
+
+func TestEnforcer_AllowWrite(t *testing.T) {
+	ctx := context.Background()
+	e, now := newEnforcer(&platform.Limits{OrganizationID: 1, WriteBytesPerSecond: 100}, 0)
+
+	// A write larger than the rate is allowed, and the next ones wait for
+	// its bytes to be paid back.
+	charge, err := e.AllowWrite(ctx, 1)
+	if err != nil {
+		t.Fatalf("expected first write to be allowed: %v", err)
+	}
+	charge(350)
+
+	*now = now.Add(2 * time.Second)
+	if _, err := e.AllowWrite(ctx, 1); platform.ErrorCode(err) != platform.ETooManyRequests {
+		t.Fatalf("expected write over the rate to be rejected, got %v", err)
+	}
+
+	*now = now.Add(time.Second)
+	if _, err := e.AllowWrite(ctx, 1); err != nil {
+		t.Fatalf("expected write to be allowed once under the rate: %v", err)
+	}
+
+	// Other organizations have their own rate.
+	if _, err := e.AllowWrite(ctx, 2); err != nil {
+		t.Fatalf("expected write of another organization to be allowed: %v", err)
+	}
+}
+
+func TestEnforcer_AllowWrite_MaxSeries(t *testing.T) {
+	ctx := context.Background()
+	e, _ := newEnforcer(&platform.Limits{OrganizationID: 1, MaxSeries: 10}, 10)
+
+	if _, err := e.AllowWrite(ctx, 1); platform.ErrorCode(err) != platform.ETooManyRequests {
+		t.Fatalf("expected write over the series limit to be rejected, got %v", err)
+	}
+
+	// Updated limits are enforced at once.
+	maxSeries := 0
+	if _, err := e.UpdateLimits(ctx, 1, platform.LimitsUpdate{MaxSeries: &maxSeries}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.AllowWrite(ctx, 1); err != nil {
+		t.Fatalf("expected write to be allowed without a series limit: %v", err)
+	}
+}
+
+func TestEnforcer_AcquireQuery(t *testing.T) {
+	ctx := context.Background()
+	e, _ := newEnforcer(&platform.Limits{OrganizationID: 1, MaxConcurrentQueries: 2}, 0)
+
+	var releases []func()
+	for i := 0; i < 2; i++ {
+		release, err := e.AcquireQuery(ctx, 1)
+		if err != nil {
+			t.Fatalf("expected query %d to be allowed: %v", i, err)
+		}
+		releases = append(releases, release)
+	}
+
+	if _, err := e.AcquireQuery(ctx, 1); platform.ErrorCode(err) != platform.ETooManyRequests {
+		t.Fatalf("expected query over the limit to be rejected, got %v", err)
+	}
+
+	releases[0]()
+	if _, err := e.AcquireQuery(ctx, 1); err != nil {
+		t.Fatalf("expected query to be allowed once another finished: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package platform
+
+import "context"
+
+// Field types of a bucket schema.
+const (
+	FloatFieldType    = "float"
+	IntegerFieldType  = "integer"
+	UnsignedFieldType = "unsigned"
+	StringFieldType   = "string"
+	BooleanFieldType  = "boolean"
+)
+
+// BucketSchema is the schema of the data written to a bucket.
+type BucketSchema struct {
+	BucketID     ID                  `json:"bucketID"`
+	Measurements []MeasurementSchema `json:"measurements"`
+}
+
+// MeasurementSchema is the schema of a measurement. Fields and tag keys
+// are sorted by name.
+type MeasurementSchema struct {
+	Name    string        `json:"name"`
+	Fields  []FieldSchema `json:"fields"`
+	TagKeys []string      `json:"tagKeys"`
+}
+
+// FieldSchema is a field key and its type.
+type FieldSchema struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// BucketSchemaService is a service for inspecting the schema of buckets.
+type BucketSchemaService interface {
+	// FindBucketSchema returns the schema of the bucket bucketID owned by orgID.
+	// Measurements are sorted by name.
+	FindBucketSchema(ctx context.Context, orgID, bucketID ID) (*BucketSchema, error)
+}
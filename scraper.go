@@ -2,6 +2,9 @@ package platform
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"time"
 )
 
 // ScraperTarget is a target to scrape
@@ -12,6 +15,166 @@ type ScraperTarget struct {
 	URL        string      `json:"url"`
 	OrgName    string      `json:"org"`
 	BucketName string      `json:"bucket"`
+
+	// OrgID and BucketID are the organization and bucket the metrics of the
+	// target are written to. The target belongs to the organization, and its
+	// credentials are loaded from the secrets of the organization.
+	OrgID    ID `json:"orgID,omitempty"`
+	BucketID ID `json:"bucketID,omitempty"`
+
+	// Interval is the time between scrapes of the target.
+	// If zero, the scheduler's default interval is used.
+	Interval time.Duration `json:"interval,omitempty"`
+	// Timeout is the maximum duration of a single scrape.
+	// If zero, the scheduler's default timeout is used.
+	Timeout time.Duration `json:"timeout,omitempty"`
+
+	// BearerTokenSecret is the key of the org secret holding the bearer token
+	// sent with each scrape request.
+	BearerTokenSecret string `json:"bearerTokenSecret,omitempty"`
+	// BasicAuth is the basic auth credentials sent with each scrape request.
+	BasicAuth *ScraperBasicAuth `json:"basicAuth,omitempty"`
+	// TLS configures the client used to scrape https targets.
+	TLS *ScraperTLSConfig `json:"tls,omitempty"`
+
+	// Tags are added to every metric gathered from the target.
+	Tags map[string]string `json:"tags,omitempty"`
+	// RelabelRules are applied in order to the gathered metrics before they are stored.
+	RelabelRules []RelabelRule `json:"relabelRules,omitempty"`
+
+	// JSON configures how metrics are read from a JSON scraper target.
+	JSON *JSONScraperConfig `json:"json,omitempty"`
+}
+
+// JSONScraperConfig describes where the metrics are found in the document
+// returned by a JSON scraper target.
+//
+// Paths are dot separated object keys or array indexes, e.g. "stats.cpu.0.usage".
+type JSONScraperConfig struct {
+	// MetricsPath is the path of the object or array of objects to read metrics from.
+	// If empty, the document root is used.
+	MetricsPath string `json:"metricsPath,omitempty"`
+	// Measurement is the name of the gathered metrics.
+	Measurement string `json:"measurement"`
+	// TimePath is the path of the timestamp within each object.
+	// If empty, the time of the scrape is used.
+	TimePath string `json:"timePath,omitempty"`
+	// TimeFormat is one of "unix", "unix_ms", "unix_us", "unix_ns" or a Go time layout.
+	// It defaults to RFC3339.
+	TimeFormat string `json:"timeFormat,omitempty"`
+	// TagPaths maps tag keys to the path of their values within each object.
+	TagPaths map[string]string `json:"tagPaths,omitempty"`
+	// FieldPaths maps field keys to the path of their values within each object.
+	FieldPaths map[string]string `json:"fieldPaths"`
+}
+
+// Validate reports any validation errors for the JSON scraper config.
+func (c *JSONScraperConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("json scraper target requires a json config")
+	}
+	if c.Measurement == "" {
+		return fmt.Errorf("json scraper target requires a measurement")
+	}
+	if len(c.FieldPaths) == 0 {
+		return fmt.Errorf("json scraper target requires at least one field path")
+	}
+	return nil
+}
+
+// ScraperBasicAuth is the basic auth credentials for a scraper target.
+// The password is never stored on the target; it is loaded from the org's secrets.
+type ScraperBasicAuth struct {
+	Username       string `json:"username"`
+	PasswordSecret string `json:"passwordSecret,omitempty"`
+}
+
+// ScraperTLSConfig is the TLS configuration for a scraper target.
+// Files are read from the host running the scraper.
+type ScraperTLSConfig struct {
+	CAFile             string `json:"caFile,omitempty"`
+	CertFile           string `json:"certFile,omitempty"`
+	KeyFile            string `json:"keyFile,omitempty"`
+	ServerName         string `json:"serverName,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+// RelabelAction is the action a relabel rule takes on a metric.
+type RelabelAction string
+
+// Relabel actions, following prometheus relabel_config semantics.
+const (
+	// RelabelReplace sets the target label to the replacement, expanded with the regex matches.
+	RelabelReplace RelabelAction = "replace"
+	// RelabelKeep drops metrics whose source labels do not match the regex.
+	RelabelKeep RelabelAction = "keep"
+	// RelabelDrop drops metrics whose source labels match the regex.
+	RelabelDrop RelabelAction = "drop"
+	// RelabelLabelDrop removes all labels whose names match the regex.
+	RelabelLabelDrop RelabelAction = "labeldrop"
+	// RelabelLabelKeep removes all labels whose names do not match the regex.
+	RelabelLabelKeep RelabelAction = "labelkeep"
+)
+
+// MetricNameLabel is the label name used by relabel rules to refer to the metric name.
+const MetricNameLabel = "__name__"
+
+// RelabelRule is a prometheus style rule to filter metrics or rewrite their labels.
+type RelabelRule struct {
+	Action       RelabelAction `json:"action"`
+	SourceLabels []string      `json:"sourceLabels,omitempty"`
+	// Separator joins the values of the source labels; defaults to ";".
+	Separator string `json:"separator,omitempty"`
+	// Regex is matched against the joined source labels; defaults to "(.*)".
+	// It is anchored at both ends.
+	Regex       string `json:"regex,omitempty"`
+	TargetLabel string `json:"targetLabel,omitempty"`
+	// Replacement is the value written to the target label; defaults to "$1".
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// Validate reports any validation errors for the relabel rule.
+func (r RelabelRule) Validate() error {
+	switch r.Action {
+	case RelabelReplace:
+		if r.TargetLabel == "" {
+			return fmt.Errorf("relabel action %q requires a target label", r.Action)
+		}
+	case RelabelKeep, RelabelDrop, RelabelLabelDrop, RelabelLabelKeep:
+	default:
+		return fmt.Errorf("unknown relabel action %q", r.Action)
+	}
+	if _, err := regexp.Compile(r.Regex); err != nil {
+		return fmt.Errorf("invalid relabel regex %q: %v", r.Regex, err)
+	}
+	return nil
+}
+
+// Validate reports any validation errors for the scraper target.
+func (t ScraperTarget) Validate() error {
+	if t.Interval < 0 {
+		return fmt.Errorf("scraper interval must not be negative")
+	}
+	if t.Timeout < 0 {
+		return fmt.Errorf("scraper timeout must not be negative")
+	}
+	if t.Interval > 0 && t.Timeout > t.Interval {
+		return fmt.Errorf("scraper timeout must not be greater than its interval")
+	}
+	if t.Type == JSONScraperType {
+		if err := t.JSON.Validate(); err != nil {
+			return err
+		}
+	}
+	if t.BasicAuth != nil && t.BasicAuth.Username == "" {
+		return fmt.Errorf("scraper basic auth requires a username")
+	}
+	for _, r := range t.RelabelRules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ScraperTargetStoreService defines the crud service for ScraperTarget.
@@ -36,12 +199,18 @@ type ScraperType string
 const (
 	// PrometheusScraperType parses metrics from a prometheus endpoint.
 	PrometheusScraperType = "prometheus"
+	// InfluxScraperType parses line protocol from an http endpoint.
+	InfluxScraperType = "influx"
+	// JSONScraperType parses metrics from a JSON document at the configured paths.
+	JSONScraperType = "json"
+	// OpenMetricsScraperType parses metrics and exemplars from an OpenMetrics text endpoint.
+	OpenMetricsScraperType = "openmetrics"
 )
 
 // ValidScraperType returns true is the type string is valid
 func ValidScraperType(s string) bool {
 	switch s {
-	case PrometheusScraperType:
+	case PrometheusScraperType, InfluxScraperType, JSONScraperType, OpenMetricsScraperType:
 		return true
 	default:
 		return false
@@ -12,4 +12,8 @@ type SecretService interface {
 
 	// PutSecret stores the secret pair (k,v) for the organization orgID.
 	PutSecret(ctx context.Context, orgID ID, k string, v string) error
+
+	// DeleteSecret removes the secrets with keys ks for the organization orgID.
+	// Keys that do not exist are ignored.
+	DeleteSecret(ctx context.Context, orgID ID, ks ...string) error
 }
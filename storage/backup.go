@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/platform/tsdb/tsi1"
+)
+
+// Backup writes the files of the engine to the tar archive tw, under the
+// directory dir. The cache is written to TSM files first, and the TSM and
+// tombstone files modified after since are written to dir/data. All index
+// and series files are written to dir/index and dir/_series. Backup returns
+// the names of every TSM and tombstone file of the engine, including those
+// older than since.
+//
+// The files are staged before they are written, so the engine is not locked
+// and compactions are not disabled while tw is written to.
+func (e *Engine) Backup(ctx context.Context, tw *tar.Writer, dir string, since time.Time) ([]string, error) {
+	snapshot, staged, err := e.stageBackup()
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(snapshot)
+	defer os.RemoveAll(staged)
+
+	fis, err := ioutil.ReadDir(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(fis))
+	for _, fi := range fis {
+		names = append(names, fi.Name())
+		if !fi.ModTime().After(since) {
+			continue
+		}
+
+		name := path.Join(dir, DefaultEngineDirectoryName, fi.Name())
+		if err := writeTarFile(tw, name, filepath.Join(snapshot, fi.Name())); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeTarDir(tw, path.Join(dir, DefaultIndexDirectoryName), filepath.Join(staged, DefaultIndexDirectoryName)); err != nil {
+		return nil, err
+	}
+	if err := writeTarDir(tw, path.Join(dir, DefaultSeriesFileDirectoryName), filepath.Join(staged, DefaultSeriesFileDirectoryName)); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// stageBackup stages the files of a backup. The TSM and tombstone files are
+// hard linked into the snapshot directory, so that compactions can't remove
+// them. The index and series files are staged into the staged directory
+// with their compactions disabled, which is only as long as staging them
+// takes. The caller must remove both directories.
+func (e *Engine) stageBackup() (snapshot, staged string, err error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return "", "", ErrEngineClosed
+	}
+
+	snapshot, err = e.engine.CreateSnapshot()
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(snapshot)
+		}
+	}()
+
+	staged, err = ioutil.TempDir(e.path, "backup")
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(staged)
+		}
+	}()
+
+	// Index and series files are rewritten by compactions.
+	e.index.DisableCompactions()
+	e.index.Wait()
+	defer e.index.EnableCompactions()
+	e.sfile.DisableCompactions()
+	defer e.sfile.EnableCompactions()
+
+	if err := stageDir(filepath.Join(staged, DefaultIndexDirectoryName), e.config.GetIndexPath(e.path)); err != nil {
+		return "", "", err
+	}
+	if err := stageDir(filepath.Join(staged, DefaultSeriesFileDirectoryName), e.config.GetSeriesFilePath(e.path)); err != nil {
+		return "", "", err
+	}
+	return snapshot, staged, nil
+}
+
+// stageDir stages the files of the directory root and its subdirectories in
+// the directory dir. Index files are never modified once written, so they
+// are hard linked. Everything else, such as logs, manifests and series
+// segments, may be modified in place and is copied.
+func stageDir(dir, root string) error {
+	return filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(dir, rel)
+
+		if fi.IsDir() {
+			return os.MkdirAll(dst, 0777)
+		} else if filepath.Ext(p) == tsi1.IndexFileExt {
+			// Links fail across file systems, such as with a configured
+			// index path, in which case the file is copied instead.
+			if err := os.Link(p, dst); err == nil {
+				return nil
+			}
+		}
+		return copyFile(dst, p)
+	})
+}
+
+// copyFile copies the file at src to dst.
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// writeTarDir writes the files of the directory root and its subdirectories
+// to tw, under the directory dir.
+func writeTarDir(tw *tar.Writer, dir, root string) error {
+	return filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if fi.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		return writeTarFile(tw, path.Join(dir, filepath.ToSlash(rel)), p)
+	})
+}
+
+// writeTarFile writes the file at p to tw as name.
+func writeTarFile(tw *tar.Writer, name, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	hdr, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	_, err = io.CopyN(tw, f, fi.Size())
+	return err
+}
@@ -31,6 +31,14 @@ type Config struct {
 	// Enables trace logging for the engine.
 	TraceLoggingEnabled bool `toml:"trace-logging-enabled"`
 
+	// Maximum number of series of a bucket. New series of a bucket over the
+	// limit are dropped. Zero means no limit.
+	MaxSeriesPerBucket int `toml:"max-series-per-bucket"`
+
+	// Maximum number of values of a tag key of a bucket. New series with a
+	// new value of a tag key over the limit are dropped. Zero means no limit.
+	MaxValuesPerTag int `toml:"max-values-per-tag"`
+
 	// Series file config.
 	SeriesFilePath string `toml:"series-file-path"` // Overrides the default path.
 
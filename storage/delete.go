@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage/reads"
+	"github.com/influxdata/platform/tsdb"
+)
+
+var _ platform.DeleteService = (*Engine)(nil)
+
+// DeleteBucketRangePredicate deletes the data of the bucket with a time in
+// the range [start, stop) of the series matching predicate.
+func (e *Engine) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID platform.ID, start, stop int64, predicate string) error {
+	if stop <= start {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("stop %d must be after start %d", stop, start),
+		}
+	}
+
+	pred, err := reads.ParsePredicate(predicate)
+	if err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid predicate",
+			Err:  err,
+		}
+	}
+
+	var cond influxql.Expr
+	if root := pred.GetRoot(); root != nil {
+		if cond, err = reads.NodeToExpr(root, nil); err != nil {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "invalid predicate",
+				Err:  err,
+			}
+		}
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	req := SeriesCursorRequest{
+		Measurements: tsdb.NewMeasurementSliceIterator([][]byte{name[:]}),
+	}
+	cur, err := e.CreateSeriesCursor(ctx, req, cond)
+	if err != nil {
+		return err
+	}
+	defer cur.Close()
+
+	return e.DeleteSeriesRangeWithPredicate(newSeriesIteratorAdapter(cur), func([]byte, models.Tags) (int64, int64, bool) {
+		return start, stop - 1, true
+	})
+}
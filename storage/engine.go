@@ -5,10 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/influxdata/influxql"
+	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/models"
 	"github.com/influxdata/platform/tsdb"
@@ -38,6 +40,17 @@ type Engine struct {
 	engine            *tsm1.Engine
 	wal               *tsm1.WAL
 	retentionEnforcer *retentionEnforcer
+	schema            *schemaRegistry
+	seriesLimiter     *seriesLimiter
+
+	// purges are the scheduled purges of deleted buckets and organizations.
+	purgeMu        sync.Mutex
+	purges         []purgeRequest
+	purgeScheduled chan struct{}
+	purgeMetrics   *purgeMetrics
+	// orphanFinder, when set, finds the existing buckets so that the data
+	// of the others is purged when the engine opens.
+	orphanFinder BucketFinder
 
 	// Tracks all goroutines started by the Engine.
 	wg sync.WaitGroup
@@ -92,6 +105,15 @@ func WithRetentionEnforcer(finder BucketFinder) Option {
 	}
 }
 
+// WithOrphanPurges makes the engine purge, when it opens, the data of the
+// buckets that finder does not find, such as the buckets deleted before the
+// purge of their data ran.
+func WithOrphanPurges(finder BucketFinder) Option {
+	return func(e *Engine) {
+		e.orphanFinder = finder
+	}
+}
+
 // WithFileStoreObserver makes the engine have the provided file store observer.
 func WithFileStoreObserver(obs tsm1.FileStoreObserver) Option {
 	return func(e *Engine) {
@@ -110,9 +132,14 @@ func WithCompactionPlanner(planner tsm1.CompactionPlanner) Option {
 // TSM engine.
 func NewEngine(path string, c Config, options ...Option) *Engine {
 	e := &Engine{
-		config: c,
-		path:   path,
-		logger: zap.NewNop(),
+		config:        c,
+		path:          path,
+		schema:        newSchemaRegistry(),
+		seriesLimiter: newSeriesLimiter(c),
+		logger:        zap.NewNop(),
+
+		purgeScheduled: make(chan struct{}, 1),
+		purgeMetrics:   newPurgeMetrics(),
 	}
 
 	// Initialize series file.
@@ -170,6 +197,7 @@ func (e *Engine) PrometheusCollectors() []prometheus.Collector {
 	// TODO(edd): Get prom metrics for index.
 	// TODO(edd): Get prom metrics for series file.
 	metrics = append(metrics, e.retentionEnforcer.PrometheusCollectors()...)
+	metrics = append(metrics, e.purgeMetrics.PrometheusCollectors()...)
 	return metrics
 }
 
@@ -201,6 +229,7 @@ func (e *Engine) Open() error {
 	// For now we will just run on an interval as we only have the retention
 	// policy enforcer.
 	e.runRetentionEnforcer()
+	e.runPurger()
 
 	return nil
 }
@@ -293,7 +322,8 @@ func (e *Engine) CreateCursorIterator(ctx context.Context) (tsdb.CursorIterator,
 //
 // The Engine expects all points to have been correctly validated by the caller.
 // WritePoints will however determine if there are any field type conflicts, and
-// return an appropriate error in that case.
+// return a tsdb.FieldTypeConflictError in that case. Points without conflicts are
+// still written.
 func (e *Engine) WritePoints(points []models.Point) error {
 	collection := tsdb.NewSeriesCollection(points)
 
@@ -343,6 +373,11 @@ func (e *Engine) WritePoints(points []models.Point) error {
 		return ErrEngineClosed
 	}
 
+	// Drop the new series over the series limits of their buckets.
+	if err := e.enforceSeriesLimits(collection); err != nil {
+		return err
+	}
+
 	// Add new series to the index and series file. Check for partial writes.
 	if err := e.index.CreateSeriesListIfNotExists(collection); err != nil {
 		// ignore PartialWriteErrors. The collection captures it.
@@ -351,6 +386,7 @@ func (e *Engine) WritePoints(points []models.Point) error {
 			return err
 		}
 	}
+	e.schema.Add(collection)
 
 	// Write the points to the cache and WAL.
 	if err := e.engine.WritePoints(collection.Points); err != nil {
@@ -367,7 +403,13 @@ func (e *Engine) DeleteSeriesRangeWithPredicate(itr tsdb.SeriesIterator, fn func
 	if e.closing == nil {
 		return ErrEngineClosed
 	}
-	return e.engine.DeleteSeriesRangeWithPredicate(itr, fn)
+	if err := e.engine.DeleteSeriesRangeWithPredicate(itr, fn); err != nil {
+		return err
+	}
+	// Deleted series may have removed measurements, fields or tag keys.
+	e.schema.Reset()
+	e.seriesLimiter.Reset()
+	return nil
 }
 
 // SeriesCardinality returns the number of series in the engine.
@@ -401,6 +443,28 @@ func (e *Engine) MeasurementCardinalityStats() tsi1.MeasurementCardinalityStats
 	return e.index.MeasurementCardinalityStats()
 }
 
+// OrganizationSeriesCardinality returns the number of series in the buckets
+// of the organization orgID.
+func (e *Engine) OrganizationSeriesCardinality(orgID platform.ID) int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return 0
+	}
+
+	// The names of the measurements of a bucket start with its organization.
+	name := tsdb.EncodeName(orgID, 0)
+	prefix := string(name[:8])
+
+	var n int64
+	for m, c := range e.index.MeasurementCardinalityStats() {
+		if strings.HasPrefix(m, prefix) {
+			n += int64(c)
+		}
+	}
+	return n
+}
+
 // MeasurementStats returns the current measurement stats for the engine.
 func (e *Engine) MeasurementStats() (tsm1.MeasurementStats, error) {
 	return e.engine.MeasurementStats()
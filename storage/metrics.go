@@ -63,3 +63,47 @@ func (rm *retentionMetrics) PrometheusCollectors() []prometheus.Collector {
 		rm.Series,
 	}
 }
+
+const purgeSubsystem = "purge" // sub-system associated with metrics for purging deleted buckets.
+
+// purgeMetrics is a set of metrics concerned with tracking the purges of the
+// data of deleted buckets and organizations.
+type purgeMetrics struct {
+	Purges  *prometheus.CounterVec
+	Pending prometheus.Gauge
+	Buckets prometheus.Counter
+}
+
+func newPurgeMetrics() *purgeMetrics {
+	return &purgeMetrics{
+		Purges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: purgeSubsystem,
+			Name:      "purges_total",
+			Help:      "Number of purges of deleted buckets and organizations performed.",
+		}, []string{"status"}),
+
+		Pending: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: purgeSubsystem,
+			Name:      "pending",
+			Help:      "Number of purges that are scheduled or running.",
+		}),
+
+		Buckets: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: purgeSubsystem,
+			Name:      "buckets_total",
+			Help:      "Number of buckets whose series were removed by purges.",
+		}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (pm *purgeMetrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		pm.Purges,
+		pm.Pending,
+		pm.Buckets,
+	}
+}
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/logger"
+	"github.com/influxdata/platform/tsdb"
+	"go.uber.org/zap"
+)
+
+var _ platform.StoragePurger = (*Engine)(nil)
+
+// purgeRequest is a purge of the data of a deleted bucket, or of every
+// bucket of a deleted organization when bucketID is invalid.
+type purgeRequest struct {
+	orgID    platform.ID
+	bucketID platform.ID
+}
+
+// PurgeBucket schedules the removal of every series of the bucket from the
+// engine, its index and its series file. Purges are run one at a time in the
+// order they are scheduled. Scheduled purges are not persisted; the data of
+// buckets deleted before their purge ran is purged once the engine is opened
+// again, if it was created WithOrphanPurges.
+func (e *Engine) PurgeBucket(ctx context.Context, orgID, bucketID platform.ID) error {
+	return e.schedulePurge(purgeRequest{orgID: orgID, bucketID: bucketID})
+}
+
+// PurgeOrganization schedules the removal of every series of every bucket of
+// the organization, like PurgeBucket.
+func (e *Engine) PurgeOrganization(ctx context.Context, orgID platform.ID) error {
+	return e.schedulePurge(purgeRequest{orgID: orgID, bucketID: platform.InvalidID()})
+}
+
+func (e *Engine) schedulePurge(r purgeRequest) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+
+	e.purgeMu.Lock()
+	e.purges = append(e.purges, r)
+	e.purgeMu.Unlock()
+	e.purgeMetrics.Pending.Inc()
+
+	select {
+	case e.purgeScheduled <- struct{}{}:
+	default: // The purger already has purges to run.
+	}
+	return nil
+}
+
+// runPurger runs the scheduled purges in a separate goroutine. When the
+// engine has an orphan finder, it first schedules the purges of the buckets
+// whose data is stored but that no longer exist.
+func (e *Engine) runPurger() {
+	l := e.logger.With(zap.String("component", "purger"))
+
+	// Run the purges left pending when the engine was last closed by this
+	// process.
+	select {
+	case e.purgeScheduled <- struct{}{}:
+	default:
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		if e.orphanFinder != nil {
+			e.purgeOrphans(l)
+		}
+
+		for {
+			// It's safe to read closing without a lock because it's never
+			// modified if this goroutine is active.
+			select {
+			case <-e.closing:
+				return
+			case <-e.purgeScheduled:
+			}
+
+			for r, ok := e.nextPurge(); ok; r, ok = e.nextPurge() {
+				e.purge(l, r)
+
+				select {
+				case <-e.closing:
+					return
+				default:
+				}
+			}
+		}
+	}()
+}
+
+// purgeOrphans schedules the purge of every bucket with stored data that the
+// orphan finder does not find, such as the buckets deleted while the engine
+// was closed or before their purge ran.
+func (e *Engine) purgeOrphans(l *zap.Logger) {
+	// The stored buckets are listed before the existing ones, so that the
+	// data of buckets created in the meantime is not purged.
+	names, err := e.purgeMeasurementNames(nil)
+	if err != nil {
+		l.Error("Unable to list stored buckets", zap.Error(err))
+		return
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bucketAPITimeout)
+	defer cancel()
+	buckets, _, err := e.orphanFinder.FindBuckets(ctx, platform.BucketFilter{})
+	if err != nil {
+		l.Error("Unable to find buckets to purge orphaned data", zap.Error(err))
+		return
+	}
+	exists := make(map[platform.ID]struct{}, len(buckets))
+	for _, b := range buckets {
+		exists[b.ID] = struct{}{}
+	}
+
+	for _, name := range names {
+		var n [16]byte
+		copy(n[:], name)
+		orgID, bucketID := tsdb.DecodeName(n)
+		if _, ok := exists[bucketID]; ok {
+			continue
+		}
+		l.Info("Purging orphaned bucket", zap.String("org_id", orgID.String()), zap.String("bucket_id", bucketID.String()))
+		if err := e.schedulePurge(purgeRequest{orgID: orgID, bucketID: bucketID}); err != nil {
+			l.Error("Unable to schedule purge", zap.Error(err))
+			return
+		}
+	}
+}
+
+// nextPurge removes the oldest scheduled purge from the queue and returns it.
+// It returns false if there are no scheduled purges.
+func (e *Engine) nextPurge() (purgeRequest, bool) {
+	e.purgeMu.Lock()
+	defer e.purgeMu.Unlock()
+
+	if len(e.purges) == 0 {
+		return purgeRequest{}, false
+	}
+	r := e.purges[0]
+	e.purges = e.purges[1:]
+	return r, true
+}
+
+// purge removes the series of the purge r.
+func (e *Engine) purge(l *zap.Logger, r purgeRequest) {
+	fields := []zap.Field{zap.String("org_id", r.orgID.String())}
+	if r.bucketID.Valid() {
+		fields = append(fields, zap.String("bucket_id", r.bucketID.String()))
+	}
+	log, logEnd := logger.NewOperation(l, "Purge data", "purge", fields...)
+	defer logEnd()
+
+	buckets, err := e.purgeMeasurements(log, r)
+	e.purgeMetrics.Pending.Dec()
+	if err != nil {
+		log.Error("Purge not successful", zap.Error(err))
+		e.purgeMetrics.Purges.WithLabelValues("error").Inc()
+		return
+	}
+	log.Info("Purged buckets", zap.Int("buckets", buckets))
+	e.purgeMetrics.Purges.WithLabelValues("ok").Inc()
+}
+
+// purgeMeasurements removes the measurements of the buckets of the purge r,
+// which removes their series from the engine, index and series file. It
+// returns the number of buckets purged.
+func (e *Engine) purgeMeasurements(log *zap.Logger, r purgeRequest) (int, error) {
+	name := tsdb.EncodeName(r.orgID, r.bucketID)
+	prefix := name[:]
+	if !r.bucketID.Valid() {
+		prefix = name[:8]
+	}
+
+	names, err := e.purgeMeasurementNames(prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, name := range names {
+		if err := e.deleteMeasurement(name); err != nil {
+			return i, err
+		}
+		e.purgeMetrics.Buckets.Inc()
+		log.Debug("Purged bucket", zap.Int("purged", i+1), zap.Int("buckets", len(names)))
+	}
+	return len(names), nil
+}
+
+// purgeMeasurementNames returns the names of the stored measurements of
+// buckets that start with prefix.
+func (e *Engine) purgeMeasurementNames(prefix []byte) ([][]byte, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+
+	var names [][]byte
+	err := e.engine.ForEachMeasurementName(func(name []byte) error {
+		if len(name) == platform.IDLength && bytes.HasPrefix(name, prefix) {
+			names = append(names, append([]byte(nil), name...))
+		}
+		return nil
+	})
+	return names, err
+}
+
+// deleteMeasurement removes all series of the measurement.
+func (e *Engine) deleteMeasurement(name []byte) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+
+	if err := e.engine.DeleteMeasurement(name); err != nil {
+		return err
+	}
+	// The measurement's fields and tag keys are gone.
+	e.schema.Reset()
+	e.seriesLimiter.Reset()
+	return nil
+}
@@ -0,0 +1,112 @@
+package storage_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestEngine_Purge(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	org, bucket1, bucket2 := platform.ID(0x3131313131313131), platform.ID(0x3232323232323232), platform.ID(0x3333333333333333)
+	writeBucketPoints(t, engine.Engine, org, bucket1, bucket2)
+
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %v series, exp %v series in index", got, exp)
+	}
+
+	ctx := context.Background()
+	if err := engine.PurgeBucket(ctx, org, bucket1); err != nil {
+		t.Fatal(err)
+	}
+	waitForSeriesCardinality(t, engine.Engine, 1)
+
+	if err := engine.PurgeOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	waitForSeriesCardinality(t, engine.Engine, 0)
+}
+
+func TestEngine_PurgeOrphans(t *testing.T) {
+	path, err := ioutil.TempDir("", "storage_engine_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	org, bucket1, bucket2 := platform.ID(0x3131313131313131), platform.ID(0x3232323232323232), platform.ID(0x3333333333333333)
+	engine := storage.NewEngine(path, storage.NewConfig())
+	if err := engine.Open(); err != nil {
+		t.Fatal(err)
+	}
+	writeBucketPoints(t, engine, org, bucket1, bucket2)
+	if err := engine.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// bucket1 was deleted while the engine was closed.
+	engine = storage.NewEngine(path, storage.NewConfig(), storage.WithOrphanPurges(bucketFinder{
+		{ID: bucket2, OrganizationID: org},
+	}))
+	defer engine.Close()
+	if err := engine.Open(); err != nil {
+		t.Fatal(err)
+	}
+	waitForSeriesCardinality(t, engine, 1)
+}
+
+// bucketFinder finds all of its buckets, regardless of the filter.
+type bucketFinder []*platform.Bucket
+
+func (f bucketFinder) FindBuckets(context.Context, platform.BucketFilter, ...platform.FindOptions) ([]*platform.Bucket, int, error) {
+	return f, len(f), nil
+}
+
+// writeBucketPoints writes a point to a series of each of the buckets.
+func writeBucketPoints(t *testing.T, e *storage.Engine, org platform.ID, buckets ...platform.ID) {
+	t.Helper()
+
+	pt := models.MustNewPoint(
+		"cpu",
+		models.Tags{{Key: []byte("host"), Value: []byte("server")}},
+		map[string]interface{}{"value": 1.0},
+		time.Unix(1, 2),
+	)
+	for _, bucket := range buckets {
+		points, err := tsdb.ExplodePoints(org, bucket, []models.Point{pt})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := e.WritePoints(points); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// waitForSeriesCardinality waits until the scheduled purges leave exp series
+// in the index.
+func waitForSeriesCardinality(t *testing.T, e *storage.Engine, exp int64) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		got := e.SeriesCardinality()
+		if got == exp {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for purges: got %v series, exp %v series in index", got, exp)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/semantic"
@@ -580,3 +581,126 @@ func HasFieldValueKey(expr influxql.Expr) bool {
 	influxql.Walk(&refs, expr)
 	return refs.found[0]
 }
+
+// ParsePredicate parses a predicate expression, such as
+//
+//	_measurement = 'cpu' AND (host = 'server01' OR region =~ /us-.*/)
+//
+// Tag keys are identifiers, and may be double quoted. Values are single
+// quoted strings or regular expressions. Comparisons may be combined
+// with AND, OR and parentheses. The _measurement and _field keys refer to
+// the measurement name and field key of a series. An empty expression
+// returns an empty predicate, which matches all series.
+func ParsePredicate(s string) (*datatypes.Predicate, error) {
+	if strings.TrimSpace(s) == "" {
+		return &datatypes.Predicate{}, nil
+	}
+
+	expr, err := influxql.ParseExpr(s)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := exprToNode(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &datatypes.Predicate{Root: root}, nil
+}
+
+func exprToNode(expr influxql.Expr) (*datatypes.Node, error) {
+	switch e := expr.(type) {
+	case *influxql.ParenExpr:
+		child, err := exprToNode(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &datatypes.Node{
+			NodeType: datatypes.NodeTypeParenExpression,
+			Children: []*datatypes.Node{child},
+		}, nil
+
+	case *influxql.BinaryExpr:
+		switch e.Op {
+		case influxql.AND, influxql.OR:
+			left, err := exprToNode(e.LHS)
+			if err != nil {
+				return nil, err
+			}
+			right, err := exprToNode(e.RHS)
+			if err != nil {
+				return nil, err
+			}
+			logical := datatypes.LogicalAnd
+			if e.Op == influxql.OR {
+				logical = datatypes.LogicalOr
+			}
+			return &datatypes.Node{
+				NodeType: datatypes.NodeTypeLogicalExpression,
+				Value:    &datatypes.Node_Logical_{Logical: logical},
+				Children: []*datatypes.Node{left, right},
+			}, nil
+
+		case influxql.EQ, influxql.NEQ, influxql.EQREGEX, influxql.NEQREGEX:
+			return comparisonToNode(e)
+		}
+		return nil, fmt.Errorf("unsupported operator %s", e.Op)
+	}
+	return nil, fmt.Errorf("unsupported expression %s", expr)
+}
+
+func comparisonToNode(e *influxql.BinaryExpr) (*datatypes.Node, error) {
+	ref, ok := e.LHS.(*influxql.VarRef)
+	if !ok {
+		return nil, fmt.Errorf("left hand side of %s must be a tag key", e)
+	}
+	key := ref.Val
+	switch key {
+	case measurementKey:
+		key = tsdb.MeasurementTagKey
+	case fieldKey:
+		key = tsdb.FieldKeyTagKey
+	}
+
+	var (
+		op    datatypes.Node_Comparison
+		value *datatypes.Node
+	)
+	switch e.Op {
+	case influxql.EQ, influxql.NEQ:
+		lit, ok := e.RHS.(*influxql.StringLiteral)
+		if !ok {
+			return nil, fmt.Errorf("right hand side of %s must be a string", e)
+		}
+		op = datatypes.ComparisonEqual
+		if e.Op == influxql.NEQ {
+			op = datatypes.ComparisonNotEqual
+		}
+		value = &datatypes.Node{
+			NodeType: datatypes.NodeTypeLiteral,
+			Value:    &datatypes.Node_StringValue{StringValue: lit.Val},
+		}
+	default:
+		lit, ok := e.RHS.(*influxql.RegexLiteral)
+		if !ok {
+			return nil, fmt.Errorf("right hand side of %s must be a regular expression", e)
+		}
+		op = datatypes.ComparisonRegex
+		if e.Op == influxql.NEQREGEX {
+			op = datatypes.ComparisonNotRegex
+		}
+		value = &datatypes.Node{
+			NodeType: datatypes.NodeTypeLiteral,
+			Value:    &datatypes.Node_RegexValue{RegexValue: lit.Val.String()},
+		}
+	}
+
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeComparisonExpression,
+		Value:    &datatypes.Node_Comparison_{Comparison: op},
+		Children: []*datatypes.Node{
+			{NodeType: datatypes.NodeTypeTagRef, Value: &datatypes.Node_TagRefValue{TagRefValue: key}},
+			value,
+		},
+	}, nil
+}
@@ -56,3 +56,71 @@ func TestPredicateToExprString(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePredicate(t *testing.T) {
+	cases := []struct {
+		n   string
+		s   string
+		e   string
+		err bool
+	}{
+		{
+			n: "measurement and tags",
+			s: `_measurement = 'cpu' AND (host = 'host1' OR region =~ /^us-west/)`,
+			e: `'_m' = "cpu" AND ( 'host' = "host1" OR 'region' =~ /^us-west/ )`,
+		},
+		{
+			n: "quoted tag key and field",
+			s: `"my tag" != 'a' OR _field !~ /^usage/`,
+			e: `'my tag' != "a" OR '_f' !~ /^usage/`,
+		},
+		{
+			n:   "ordering operator",
+			s:   `host > 'host1'`,
+			err: true,
+		},
+		{
+			n:   "number value",
+			s:   `host = 1`,
+			err: true,
+		},
+		{
+			n:   "value on left hand side",
+			s:   `'host1' = host`,
+			err: true,
+		},
+		{
+			n:   "syntax error",
+			s:   `host = 'host1' AND`,
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.n, func(t *testing.T) {
+			p, err := reads.ParsePredicate(tc.s)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, wanted := reads.PredicateToExprString(p), tc.e; got != wanted {
+				t.Fatal("got:", got, "wanted:", wanted)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		p, err := reads.ParsePredicate(" ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Root != nil {
+			t.Fatal("expected empty predicate")
+		}
+	})
+}
@@ -2,13 +2,17 @@ package readservice
 
 import (
 	"context"
+	"time"
 
 	"github.com/influxdata/platform/query/functions/outputs"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/control"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/authorizer"
+	pcontext "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/query/functions/inputs"
 	fstorage "github.com/influxdata/platform/query/functions/inputs/storage"
@@ -17,7 +21,9 @@ import (
 	"go.uber.org/zap"
 )
 
-func NewProxyQueryService(engine *storage.Engine, bucketSvc platform.BucketService, orgSvc platform.OrganizationService, logger *zap.Logger) (query.ProxyQueryService, error) {
+// NewProxyQueryService returns a query service that reads from engine.
+// Flux queries read the secrets of the organization running them from secretSvc.
+func NewProxyQueryService(engine *storage.Engine, bucketSvc platform.BucketService, orgSvc platform.OrganizationService, secretSvc platform.SecretService, logger *zap.Logger) (query.ProxyQueryService, error) {
 	var (
 		concurrencyQuota = 10
 		memoryBytesQuota = 1e6
@@ -57,18 +63,40 @@ func NewProxyQueryService(engine *storage.Engine, bucketSvc platform.BucketServi
 	return query.ProxyQueryServiceBridge{
 		QueryService: query.QueryServiceBridge{
 			AsyncQueryService: &queryAdapter{
-				Controller: control.New(cc),
+				Controller:    control.New(cc),
+				SecretService: secretSvc,
 			},
 		},
 	}, nil
 }
 
 type queryAdapter struct {
-	Controller *control.Controller
+	Controller    *control.Controller
+	SecretService platform.SecretService
 }
 
 func (q *queryAdapter) Query(ctx context.Context, req *query.Request) (flux.Query, error) {
 	ctx = query.ContextWithRequest(ctx, req)
 	ctx = context.WithValue(ctx, "org", req.OrganizationID.String())
-	return q.Controller.Query(ctx, req.Compiler)
+
+	compiler := req.Compiler
+	if c, ok := compiler.(lang.FluxCompiler); ok {
+		// Compile Flux queries here, so the secrets they read are resolved
+		// for the organization running the query, and only if the
+		// authorization of the request may read them.
+		var secretSvc platform.SecretService
+		if q.SecretService != nil {
+			secretSvc = authorizer.NewSecretService(q.SecretService)
+		}
+		cctx := ctx
+		if req.Authorization != nil {
+			cctx = pcontext.SetAuthorizer(ctx, req.Authorization)
+		}
+		spec, err := query.Compile(cctx, c.Query, time.Now(), secretSvc, req.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		compiler = lang.SpecCompiler{Spec: spec}
+	}
+	return q.Controller.Query(ctx, compiler)
 }
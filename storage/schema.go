@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+)
+
+var _ platform.BucketSchemaService = (*Engine)(nil)
+
+// schemaRegistry tracks the measurements, field keys and types, and tag keys
+// of each bucket. The schema of a bucket is loaded from the index and series
+// file the first time it is requested, and is then kept up to date as series
+// are written.
+type schemaRegistry struct {
+	mu      sync.RWMutex
+	buckets map[string]*bucketSchema // keyed by the encoded org and bucket name
+}
+
+type bucketSchema struct {
+	measurements map[string]*measurementSchema
+}
+
+type measurementSchema struct {
+	fields map[string]models.FieldType
+	tags   map[string]struct{}
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		buckets: make(map[string]*bucketSchema),
+	}
+}
+
+func newBucketSchema() *bucketSchema {
+	return &bucketSchema{
+		measurements: make(map[string]*measurementSchema),
+	}
+}
+
+// has returns true if the series is already part of the bucket schema.
+func (s *bucketSchema) has(tags models.Tags, typ models.FieldType) bool {
+	m := s.measurements[string(tags.Get(tsdb.MeasurementTagKeyBytes))]
+	if m == nil {
+		return false
+	}
+	if t, ok := m.fields[string(tags.Get(tsdb.FieldKeyTagKeyBytes))]; !ok || t != typ {
+		return false
+	}
+	for _, t := range tags {
+		if isSchemaTagKey(t.Key) {
+			if _, ok := m.tags[string(t.Key)]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// add adds the measurement, field and tag keys of a series to the bucket schema.
+func (s *bucketSchema) add(tags models.Tags, typ models.FieldType) {
+	name := string(tags.Get(tsdb.MeasurementTagKeyBytes))
+	m := s.measurements[name]
+	if m == nil {
+		m = &measurementSchema{
+			fields: make(map[string]models.FieldType),
+			tags:   make(map[string]struct{}),
+		}
+		s.measurements[name] = m
+	}
+	m.fields[string(tags.Get(tsdb.FieldKeyTagKeyBytes))] = typ
+	for _, t := range tags {
+		if isSchemaTagKey(t.Key) {
+			m.tags[string(t.Key)] = struct{}{}
+		}
+	}
+}
+
+// isSchemaTagKey returns false for the tag keys holding the measurement
+// name and field key of a series.
+func isSchemaTagKey(key []byte) bool {
+	return string(key) != tsdb.MeasurementTagKey && string(key) != tsdb.FieldKeyTagKey
+}
+
+// Add adds the series of the collection to the schema of the buckets that
+// have been loaded.
+func (r *schemaRegistry) Add(collection *tsdb.SeriesCollection) {
+	var missing []int
+
+	r.mu.RLock()
+	if len(r.buckets) == 0 {
+		r.mu.RUnlock()
+		return
+	}
+	for iter := collection.Iterator(); iter.Next(); {
+		s := r.buckets[string(iter.Name())]
+		if s != nil && !s.has(iter.Tags(), iter.Type()) {
+			missing = append(missing, iter.Index())
+		}
+	}
+	r.mu.RUnlock()
+
+	if len(missing) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, i := range missing {
+		if s := r.buckets[string(collection.Names[i])]; s != nil {
+			s.add(collection.Tags[i], collection.Types[i])
+		}
+	}
+}
+
+// Load returns the schema of the bucket name, calling fn to load it if it
+// has not been loaded yet.
+func (r *schemaRegistry) Load(name []byte, fn func(*bucketSchema) error) (*platform.BucketSchema, error) {
+	r.mu.RLock()
+	s := r.buckets[string(name)]
+	if s != nil {
+		defer r.mu.RUnlock()
+		return s.platform(), nil
+	}
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s = r.buckets[string(name)]; s == nil {
+		s = newBucketSchema()
+		if err := fn(s); err != nil {
+			return nil, err
+		}
+		r.buckets[string(name)] = s
+	}
+	return s.platform(), nil
+}
+
+// Reset forgets the schema of all buckets, so they are loaded again from
+// the index when next requested.
+func (r *schemaRegistry) Reset() {
+	r.mu.Lock()
+	r.buckets = make(map[string]*bucketSchema)
+	r.mu.Unlock()
+}
+
+// platform returns the bucket schema sorted by name.
+func (s *bucketSchema) platform() *platform.BucketSchema {
+	schema := &platform.BucketSchema{
+		Measurements: make([]platform.MeasurementSchema, 0, len(s.measurements)),
+	}
+	for name, m := range s.measurements {
+		ms := platform.MeasurementSchema{
+			Name:    name,
+			Fields:  make([]platform.FieldSchema, 0, len(m.fields)),
+			TagKeys: make([]string, 0, len(m.tags)),
+		}
+		for k, typ := range m.fields {
+			ms.Fields = append(ms.Fields, platform.FieldSchema{Name: k, Type: schemaFieldType(typ)})
+		}
+		sort.Slice(ms.Fields, func(i, j int) bool { return ms.Fields[i].Name < ms.Fields[j].Name })
+		for k := range m.tags {
+			ms.TagKeys = append(ms.TagKeys, k)
+		}
+		sort.Strings(ms.TagKeys)
+		schema.Measurements = append(schema.Measurements, ms)
+	}
+	sort.Slice(schema.Measurements, func(i, j int) bool { return schema.Measurements[i].Name < schema.Measurements[j].Name })
+	return schema
+}
+
+func schemaFieldType(typ models.FieldType) string {
+	switch typ {
+	case models.Float:
+		return platform.FloatFieldType
+	case models.Integer:
+		return platform.IntegerFieldType
+	case models.Unsigned:
+		return platform.UnsignedFieldType
+	case models.String:
+		return platform.StringFieldType
+	case models.Boolean:
+		return platform.BooleanFieldType
+	}
+	return ""
+}
+
+// FindBucketSchema returns the measurements, field keys and types, and tag
+// keys written to the bucket.
+func (e *Engine) FindBucketSchema(ctx context.Context, orgID, bucketID platform.ID) (*platform.BucketSchema, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return nil, ErrEngineClosed
+	}
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	schema, err := e.schema.Load(name[:], func(s *bucketSchema) error {
+		return e.loadBucketSchema(name[:], s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	schema.BucketID = bucketID
+	return schema, nil
+}
+
+// loadBucketSchema reads the schema of the bucket name from the index and
+// series file.
+func (e *Engine) loadBucketSchema(name []byte, s *bucketSchema) error {
+	itr, err := e.index.MeasurementSeriesIDIterator(name)
+	if err != nil {
+		return err
+	} else if itr == nil {
+		return nil
+	}
+	defer itr.Close()
+
+	for {
+		elem, err := itr.Next()
+		if err != nil {
+			return err
+		} else if elem.SeriesID.IsZero() {
+			return nil
+		}
+
+		key := e.sfile.SeriesKey(elem.SeriesID)
+		if len(key) == 0 {
+			continue
+		}
+		typ := models.Empty
+		if id := e.sfile.SeriesIDTypedBySeriesKey(key); id.HasType() {
+			typ = id.Type()
+		}
+		_, tags := tsdb.ParseSeriesKey(key)
+		s.add(tags, typ)
+	}
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func TestSchemaRegistry(t *testing.T) {
+	seriesTags := func(m, f string, tags ...string) models.Tags {
+		tm := map[string]string{tsdb.MeasurementTagKey: m, tsdb.FieldKeyTagKey: f}
+		for _, k := range tags {
+			tm[k] = "v"
+		}
+		return models.NewTags(tm)
+	}
+
+	var (
+		name    = []byte("bucket")
+		r       = newSchemaRegistry()
+		loads   int
+		loadCPU = func(s *bucketSchema) error {
+			loads++
+			s.add(seriesTags("cpu", "usage", "host"), models.Float)
+			return nil
+		}
+	)
+
+	// Writes to buckets that have not been loaded are ignored.
+	r.Add(&tsdb.SeriesCollection{
+		Names: [][]byte{name},
+		Tags:  []models.Tags{seriesTags("mem", "free")},
+		Types: []models.FieldType{models.Integer},
+	})
+
+	if _, err := r.Load(name, loadCPU); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Add(&tsdb.SeriesCollection{
+		Names: [][]byte{name, name},
+		Tags: []models.Tags{
+			seriesTags("cpu", "idle", "region"),
+			seriesTags("disk", "used", "path"),
+		},
+		Types: []models.FieldType{models.Integer, models.Unsigned},
+	})
+
+	got, err := r.Load(name, loadCPU)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &platform.BucketSchema{
+		Measurements: []platform.MeasurementSchema{
+			{
+				Name: "cpu",
+				Fields: []platform.FieldSchema{
+					{Name: "idle", Type: platform.IntegerFieldType},
+					{Name: "usage", Type: platform.FloatFieldType},
+				},
+				TagKeys: []string{"host", "region"},
+			},
+			{
+				Name: "disk",
+				Fields: []platform.FieldSchema{
+					{Name: "used", Type: platform.UnsignedFieldType},
+				},
+				TagKeys: []string{"path"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected schema: got %+v, exp %+v", got, exp)
+	}
+	if loads != 1 {
+		t.Fatalf("bucket schema loaded %d times, exp 1", loads)
+	}
+
+	r.Reset()
+	if _, err := r.Load(name, loadCPU); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 {
+		t.Fatalf("bucket schema loaded %d times after reset, exp 2", loads)
+	}
+}
@@ -0,0 +1,358 @@
+package storage
+
+import (
+	"sync"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/pkg/estimator/hll"
+	"github.com/influxdata/platform/tsdb"
+)
+
+// seriesLimiter enforces the max-series-per-bucket and max-values-per-tag
+// limits of the engine. The series and the values of each tag key of a
+// bucket are counted with HyperLogLog sketches, which are loaded from the
+// index the first time the bucket is written to. A write only looks its
+// series up in the index once its bucket is close to a limit, so limits are
+// enforced within the error of the sketches. Each bucket is locked on its
+// own, so writes to different buckets are not limited one at a time.
+type seriesLimiter struct {
+	maxSeries int
+	maxValues int
+
+	mu      sync.Mutex
+	buckets map[string]*bucketCardinality // keyed by the encoded org and bucket name
+}
+
+// bucketCardinality holds the sketches of the series and tag values of a
+// bucket. The sketches are only accessed with mu held.
+type bucketCardinality struct {
+	mu     sync.Mutex
+	loaded bool
+	series *hll.Plus
+	values map[string]*hll.Plus // keyed by tag key
+}
+
+func newSeriesLimiter(c Config) *seriesLimiter {
+	return &seriesLimiter{
+		maxSeries: c.MaxSeriesPerBucket,
+		maxValues: c.MaxValuesPerTag,
+		buckets:   make(map[string]*bucketCardinality),
+	}
+}
+
+// enabled returns true if any series limit is set.
+func (l *seriesLimiter) enabled() bool {
+	return l.maxSeries > 0 || l.maxValues > 0
+}
+
+// Reset forgets the sketches of all buckets, so they are loaded again from
+// the index when next written to.
+func (l *seriesLimiter) Reset() {
+	l.mu.Lock()
+	l.buckets = make(map[string]*bucketCardinality)
+	l.mu.Unlock()
+}
+
+// bucket returns the sketches of the bucket name, which may not be loaded.
+func (l *seriesLimiter) bucket(name string) *bucketCardinality {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.buckets[name]
+	if b == nil {
+		b = &bucketCardinality{values: make(map[string]*hll.Plus)}
+		l.buckets[name] = b
+	}
+	return b
+}
+
+// enforceSeriesLimits drops the new series of the collection that would
+// exceed the series limits of their buckets, and adds the series kept to
+// the sketches of their buckets. The limits reached are added to the
+// collection, so that its partial write error names them.
+func (e *Engine) enforceSeriesLimits(collection *tsdb.SeriesCollection) error {
+	l := e.seriesLimiter
+	if !l.enabled() {
+		return nil
+	}
+
+	// Group the entries by bucket, in the order the buckets are written to.
+	var names []string
+	entries := make(map[string][]int)
+	for iter := collection.Iterator(); iter.Next(); {
+		name := string(iter.Name())
+		if _, ok := entries[name]; !ok {
+			names = append(names, name)
+		}
+		entries[name] = append(entries[name], iter.Index())
+	}
+
+	var (
+		dropped = make(map[int]struct{})
+		limits  []tsdb.SeriesLimit
+	)
+	for _, name := range names {
+		reached := make(map[tsdb.SeriesLimit]struct{})
+		drop := func(i int, limit tsdb.SeriesLimit) {
+			dropped[i] = struct{}{}
+			if _, ok := reached[limit]; !ok {
+				reached[limit] = struct{}{}
+				limits = append(limits, limit)
+			}
+		}
+		if err := e.enforceBucketLimits(collection, name, entries[name], drop); err != nil {
+			return err
+		}
+	}
+
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	j := 0
+	for i, n := 0, collection.Length(); i < n; i++ {
+		if _, ok := dropped[i]; ok {
+			collection.Dropped++
+			collection.DroppedKeys = append(collection.DroppedKeys, collection.Keys[i])
+			continue
+		}
+		collection.Copy(j, i)
+		j++
+	}
+	collection.Truncate(j)
+
+	if collection.Reason == "" {
+		collection.Reason = limits[0].String()
+	}
+	collection.Limits = append(collection.Limits, limits...)
+	return nil
+}
+
+// enforceBucketLimits drops the entries idx of the bucket name that would
+// exceed its limits, and adds the entries kept to its sketches. Only the
+// bucket is locked, while its series are looked up in the index.
+func (e *Engine) enforceBucketLimits(collection *tsdb.SeriesCollection, name string, idx []int, drop func(int, tsdb.SeriesLimit)) error {
+	l := e.seriesLimiter
+	b := l.bucket(name)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := e.loadBucketCardinality(name, b); err != nil {
+		return err
+	}
+
+	// The values of tag keys are checked first, so that the series
+	// dropped for a new tag value are not counted as new series.
+	var err error
+	if l.maxValues > 0 {
+		if idx, err = e.enforceMaxValuesPerTag(collection, name, b, idx, drop); err != nil {
+			return err
+		}
+	}
+	if l.maxSeries > 0 {
+		idx = e.enforceMaxSeriesPerBucket(collection, name, b, idx, drop)
+	}
+	b.add(collection, idx)
+	return nil
+}
+
+// enforceMaxValuesPerTag drops the entries idx of the bucket name with a new
+// value of a tag key that has reached the max-values-per-tag limit, and
+// returns the entries kept.
+func (e *Engine) enforceMaxValuesPerTag(collection *tsdb.SeriesCollection, name string, b *bucketCardinality, idx []int, drop func(int, tsdb.SeriesLimit)) ([]int, error) {
+	max := e.seriesLimiter.maxValues
+
+	// Every entry adds at most a value to each tag key, so only the values
+	// of the tag keys within that many values of the limit are looked up.
+	counts := make(map[string]int)
+	near := make(map[string]map[string]struct{}) // the new values of the tag keys near the limit
+	for _, i := range idx {
+		for _, t := range collection.Tags[i] {
+			if _, ok := counts[string(t.Key)]; ok || !isSchemaTagKey(t.Key) {
+				continue
+			}
+			s, err := e.loadTagValues(name, b, t.Key)
+			if err != nil {
+				return nil, err
+			}
+			n := int(s.Count())
+			counts[string(t.Key)] = n
+			if n+len(idx) > max {
+				near[string(t.Key)] = make(map[string]struct{})
+			}
+		}
+	}
+	if len(near) == 0 {
+		return idx, nil
+	}
+
+	orgID, bucketID := decodeName(name)
+	kept := idx[:0]
+	for _, i := range idx {
+		var (
+			added  []string
+			reject string
+		)
+		for _, t := range collection.Tags[i] {
+			values, ok := near[string(t.Key)]
+			if !ok {
+				continue
+			}
+			if _, ok := values[string(t.Value)]; ok {
+				continue
+			}
+			if ok, err := e.index.HasTagValue([]byte(name), t.Key, t.Value); err != nil {
+				return nil, err
+			} else if ok {
+				continue
+			}
+			if counts[string(t.Key)] >= max {
+				reject = string(t.Key)
+				break
+			}
+			added = append(added, string(t.Key), string(t.Value))
+		}
+
+		if reject != "" {
+			drop(i, tsdb.SeriesLimit{
+				OrgID:    orgID,
+				BucketID: bucketID,
+				Limit:    tsdb.MaxValuesPerTagLimit,
+				Max:      max,
+				TagKey:   reject,
+			})
+			continue
+		}
+		for j := 0; j < len(added); j += 2 {
+			near[added[j]][added[j+1]] = struct{}{}
+			counts[added[j]]++
+		}
+		kept = append(kept, i)
+	}
+	return kept, nil
+}
+
+// enforceMaxSeriesPerBucket drops the new series of the entries idx of the
+// bucket name once it has reached the max-series-per-bucket limit, and
+// returns the entries kept.
+func (e *Engine) enforceMaxSeriesPerBucket(collection *tsdb.SeriesCollection, name string, b *bucketCardinality, idx []int, drop func(int, tsdb.SeriesLimit)) []int {
+	max := e.seriesLimiter.maxSeries
+
+	// Every entry adds at most a series, so the series are only looked up
+	// when the bucket is within that many series of the limit.
+	n := int(b.series.Count())
+	if n+len(idx) <= max {
+		return idx
+	}
+
+	orgID, bucketID := decodeName(name)
+	var (
+		kept  = idx[:0]
+		added = make(map[string]struct{})
+		key   []byte
+	)
+	for _, i := range idx {
+		key = tsdb.AppendSeriesKey(key[:0], collection.Names[i], collection.Tags[i])
+		if _, ok := added[string(key)]; ok || !e.sfile.SeriesIDTypedBySeriesKey(key).SeriesID().IsZero() {
+			kept = append(kept, i)
+			continue
+		}
+		if n >= max {
+			drop(i, tsdb.SeriesLimit{
+				OrgID:    orgID,
+				BucketID: bucketID,
+				Limit:    tsdb.MaxSeriesPerBucketLimit,
+				Max:      max,
+			})
+			continue
+		}
+		added[string(key)] = struct{}{}
+		n++
+		kept = append(kept, i)
+	}
+	return kept
+}
+
+// add adds the series and tag values of the entries idx to the sketches.
+func (b *bucketCardinality) add(collection *tsdb.SeriesCollection, idx []int) {
+	var key []byte
+	for _, i := range idx {
+		if b.series != nil {
+			key = tsdb.AppendSeriesKey(key[:0], collection.Names[i], collection.Tags[i])
+			b.series.Add(key)
+		}
+		for _, t := range collection.Tags[i] {
+			if s := b.values[string(t.Key)]; s != nil {
+				s.Add(t.Value)
+			}
+		}
+	}
+}
+
+// loadBucketCardinality loads the series of the bucket name from the index
+// into its sketches b, if they have not been loaded yet. b must be locked.
+func (e *Engine) loadBucketCardinality(name string, b *bucketCardinality) error {
+	if b.loaded {
+		return nil
+	}
+
+	if e.seriesLimiter.maxSeries > 0 {
+		series := hll.NewDefaultPlus()
+		itr, err := e.index.MeasurementSeriesIDIterator([]byte(name))
+		if err != nil {
+			return err
+		}
+		if itr != nil {
+			defer itr.Close()
+			for {
+				elem, err := itr.Next()
+				if err != nil {
+					return err
+				} else if elem.SeriesID.IsZero() {
+					break
+				}
+				if key := e.sfile.SeriesKey(elem.SeriesID); len(key) > 0 {
+					series.Add(key)
+				}
+			}
+		}
+		b.series = series
+	}
+	b.loaded = true
+	return nil
+}
+
+// loadTagValues returns the sketch of the values of the tag key of the
+// bucket name, loading them from the index if they have not been loaded yet.
+// b must be locked.
+func (e *Engine) loadTagValues(name string, b *bucketCardinality, key []byte) (*hll.Plus, error) {
+	if s := b.values[string(key)]; s != nil {
+		return s, nil
+	}
+
+	s := hll.NewDefaultPlus()
+	itr, err := e.index.TagValueIterator([]byte(name), key)
+	if err != nil {
+		return nil, err
+	}
+	if itr != nil {
+		defer itr.Close()
+		for {
+			v, err := itr.Next()
+			if err != nil {
+				return nil, err
+			} else if v == nil {
+				break
+			}
+			s.Add(v)
+		}
+	}
+	b.values[string(key)] = s
+	return s, nil
+}
+
+// decodeName returns the organization and bucket of the encoded name.
+func decodeName(name string) (orgID, bucketID platform.ID) {
+	var n [16]byte
+	copy(n[:], name)
+	return tsdb.DecodeName(n)
+}
@@ -0,0 +1,152 @@
+package storage_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform/models"
+	"github.com/influxdata/platform/storage"
+	"github.com/influxdata/platform/tsdb"
+)
+
+func cpuPoint(tags map[string]string) models.Point {
+	return models.MustNewPoint(
+		"cpu",
+		models.NewTags(tags),
+		map[string]interface{}{"value": 1.0},
+		time.Unix(1, 2),
+	)
+}
+
+func TestEngine_MaxSeriesPerBucket(t *testing.T) {
+	config := storage.NewConfig()
+	config.MaxSeriesPerBucket = 3
+
+	engine := NewEngine(config)
+	defer engine.Close()
+	engine.MustOpen()
+
+	if err := engine.Write1xPoints([]models.Point{
+		cpuPoint(map[string]string{"host": "a"}),
+		cpuPoint(map[string]string{"host": "b"}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only one of the new series fits; existing series are still written.
+	err := engine.Write1xPoints([]models.Point{
+		cpuPoint(map[string]string{"host": "a"}),
+		cpuPoint(map[string]string{"host": "c"}),
+		cpuPoint(map[string]string{"host": "d"}),
+		cpuPoint(map[string]string{"host": "e"}),
+	})
+	lerr, ok := err.(tsdb.SeriesLimitError)
+	if !ok {
+		t.Fatalf("expected a series limit error, got %v", err)
+	}
+	if got, exp := lerr.Dropped, 2; got != exp {
+		t.Fatalf("got %d dropped series, exp %d", got, exp)
+	}
+	if got, exp := lerr.Limits[0].Limit, tsdb.MaxSeriesPerBucketLimit; got != exp {
+		t.Fatalf("got limit %q, exp %q", got, exp)
+	}
+	if got, exp := lerr.Limits[0].BucketID.String(), "3232323232323232"; got != exp {
+		t.Fatalf("got bucket %s, exp %s", got, exp)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(3); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+
+	// The series of the bucket are counted again once the engine is reopened.
+	engine.Engine.Close()
+	engine.MustOpen()
+
+	if err := engine.Write1xPoints([]models.Point{cpuPoint(map[string]string{"host": "b"})}); err != nil {
+		t.Fatalf("expected existing series to be written: %v", err)
+	}
+	if _, ok := engine.Write1xPoints([]models.Point{cpuPoint(map[string]string{"host": "f"})}).(tsdb.SeriesLimitError); !ok {
+		t.Fatal("expected new series over the limit to be dropped")
+	}
+}
+
+func TestEngine_MaxSeriesPerBucket_ConcurrentWrites(t *testing.T) {
+	config := storage.NewConfig()
+	config.MaxSeriesPerBucket = 10
+
+	engine := NewEngine(config)
+	defer engine.Close()
+	engine.MustOpen()
+
+	// Writes of new series at once must not exceed the limit together.
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var points []models.Point
+			for j := 0; j < 5; j++ {
+				points = append(points, cpuPoint(map[string]string{"host": fmt.Sprintf("%d-%d", i, j)}))
+			}
+			if err := engine.Write1xPoints(points); err != nil {
+				if _, ok := err.(tsdb.SeriesLimitError); !ok {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(10); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+}
+
+func TestEngine_MaxValuesPerTag(t *testing.T) {
+	config := storage.NewConfig()
+	config.MaxValuesPerTag = 2
+
+	engine := NewEngine(config)
+	defer engine.Close()
+	engine.MustOpen()
+
+	var points []models.Point
+	for i := 0; i < 3; i++ {
+		points = append(points, cpuPoint(map[string]string{
+			"host":   fmt.Sprintf("host%d", i),
+			"region": "west",
+		}))
+	}
+
+	err := engine.Write1xPoints(points)
+	lerr, ok := err.(tsdb.SeriesLimitError)
+	if !ok {
+		t.Fatalf("expected a series limit error, got %v", err)
+	}
+	if got, exp := lerr.Dropped, 1; got != exp {
+		t.Fatalf("got %d dropped series, exp %d", got, exp)
+	}
+	if got, exp := lerr.Limits[0].Limit, tsdb.MaxValuesPerTagLimit; got != exp {
+		t.Fatalf("got limit %q, exp %q", got, exp)
+	}
+	if got, exp := lerr.Limits[0].TagKey, "host"; got != exp {
+		t.Fatalf("got tag key %q, exp %q", got, exp)
+	}
+
+	// New series with existing values of the limited tag are written.
+	if err := engine.Write1xPoints([]models.Point{cpuPoint(map[string]string{
+		"host":   "host0",
+		"region": "east",
+	})}); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(3); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+}
@@ -1,6 +1,10 @@
 package platform
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
 
 // Task is a task. 🎊
 type Task struct {
@@ -12,6 +16,7 @@ type Task struct {
 	Flux         string `json:"flux"`
 	Every        string `json:"every,omitempty"`
 	Cron         string `json:"cron,omitempty"`
+	Version      int    `json:"version,omitempty"`
 }
 
 // Run is a record created when a run of a task is scheduled.
@@ -23,9 +28,30 @@ type Run struct {
 	StartedAt    string `json:"startedAt,omitempty"`
 	FinishedAt   string `json:"finishedAt,omitempty"`
 	RequestedAt  string `json:"requestedAt,omitempty"`
+	TaskVersion  int    `json:"taskVersion,omitempty"` // Version of the task's script the run executed.
 	Log          Log    `json:"log"`
 }
 
+// TaskVersion is an immutable version of a task's script, and so of the options it sets.
+type TaskVersion struct {
+	TaskID    ID     `json:"taskId"`
+	Version   int    `json:"version"`
+	Flux      string `json:"flux"`
+	Author    ID     `json:"authorId,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+}
+
+// ManualRun is a queued request to run a task for every schedule in a time range,
+// such as a backfill of the task over historical data.
+type ManualRun struct {
+	TaskID ID     `json:"taskId"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+	// LatestCompleted is the latest schedule of the range whose run has completed.
+	LatestCompleted string `json:"latestCompleted,omitempty"`
+	RequestedAt     string `json:"requestedAt,omitempty"`
+}
+
 // Log represents a link to a log resource
 type Log string
 
@@ -62,6 +88,79 @@ type TaskService interface {
 	// RetryRun creates and returns a new run (which is a retry of another run).
 	// The requestedAt parameter is the Unix timestamp that will be recorded for the retry.
 	RetryRun(ctx context.Context, taskID, runID ID, requestedAt int64) error
+
+	// ManuallyRunTimeRange queues runs of a task for every schedule no earlier than start and no later than end (Unix timestamps).
+	// The requestedAt parameter is the Unix timestamp that will be recorded for the runs.
+	ManuallyRunTimeRange(ctx context.Context, taskID ID, start, end, requestedAt int64) (*ManualRun, error)
+
+	// FindManualRuns returns the manual runs of a task that still have runs to be scheduled.
+	FindManualRuns(ctx context.Context, taskID ID) ([]*ManualRun, error)
+
+	// CancelManualRun cancels the remaining runs of the manual run of a task from start to end (Unix timestamps).
+	CancelManualRun(ctx context.Context, taskID ID, start, end int64) error
+
+	// FindTaskVersions returns the versions of a task's script, oldest first.
+	FindTaskVersions(ctx context.Context, taskID ID) ([]*TaskVersion, error)
+
+	// RollbackTask updates a task to the script of one of its previous versions, recorded as a new version.
+	RollbackTask(ctx context.Context, taskID ID, version int) (*Task, error)
+
+	// FindTaskNotificationRules returns the notification rules of a task.
+	FindTaskNotificationRules(ctx context.Context, taskID ID) ([]*TaskNotificationRule, error)
+
+	// CreateTaskNotificationRule adds a notification rule to its task, and sets its ID.
+	CreateTaskNotificationRule(ctx context.Context, r *TaskNotificationRule) error
+
+	// DeleteTaskNotificationRule removes a notification rule from a task.
+	DeleteTaskNotificationRule(ctx context.Context, taskID, ruleID ID) error
+}
+
+// Events on which a task notification rule notifies.
+const (
+	// TaskNotifyOnFailure notifies of every failed run.
+	TaskNotifyOnFailure = "failure"
+	// TaskNotifyOnRecovery notifies of the first successful run after failed runs.
+	TaskNotifyOnRecovery = "recovery"
+	// TaskNotifyOnConsecutiveFailures notifies once the number of consecutive failed runs reaches the rule's threshold.
+	TaskNotifyOnConsecutiveFailures = "consecutiveFailures"
+)
+
+// TaskNotificationRule sends a JSON notification to a webhook when runs of a task fail or recover.
+type TaskNotificationRule struct {
+	ID     ID     `json:"id,omitempty"`
+	TaskID ID     `json:"taskId"`
+	On     string `json:"on"`
+	// Threshold is the number of consecutive failed runs to notify of, for rules on consecutive failures.
+	Threshold int    `json:"threshold,omitempty"`
+	URL       string `json:"url"`
+	// SecretKey is the key of the secret of the task's organization used to sign notifications with HMAC-SHA256.
+	// Notifications are not signed if it is empty.
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+// Valid returns an error if the notification rule is invalid.
+func (r TaskNotificationRule) Valid() error {
+	switch r.On {
+	case TaskNotifyOnFailure, TaskNotifyOnRecovery:
+		if r.Threshold != 0 {
+			return fmt.Errorf("threshold is only valid for rules on %s", TaskNotifyOnConsecutiveFailures)
+		}
+	case TaskNotifyOnConsecutiveFailures:
+		if r.Threshold < 1 {
+			return fmt.Errorf("threshold of rules on %s must be positive", TaskNotifyOnConsecutiveFailures)
+		}
+	default:
+		return fmt.Errorf("invalid notification event %q, must be one of %s, %s or %s", r.On, TaskNotifyOnFailure, TaskNotifyOnRecovery, TaskNotifyOnConsecutiveFailures)
+	}
+
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q, must be an absolute http or https URL", r.URL)
+	}
+	return nil
 }
 
 // TaskUpdate represents updates to a task
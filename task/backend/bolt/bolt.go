@@ -9,6 +9,8 @@
 //    bucket(/tasks/v1/user_by_task_id) key(:task_id) -> The user ID (stored as encoded string) associated with given task.
 //    buket(/tasks/v1/name_by_task_id) key(:task_id) -> The user-supplied name of the script.
 //    bucket(/tasks/v1/run_ids) -> Counter for run IDs
+//    bucket(/tasks/v1/task_versions).bucket(:task_id) key(:version) -> JSON encoded version of the task's script,
+//                                    keyed by big-endian uint32 version numbers.
 //    bucket(/tasks/v1/orgs).bucket(:org_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from org to tasks.
 //    bucket(/tasks/v1/users).bucket(:user_id) key(:task_id) -> Empty content; presence of :task_id allows for lookup from user to tasks.
 // Note that task IDs are stored big-endian uint64s for sorting purposes,
@@ -18,6 +20,8 @@ package bolt
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -37,7 +41,7 @@ var ErrDBReadOnly = errors.New("db is read only")
 // reached for a task when you try to schedule a task.
 var ErrMaxConcurrency = errors.New("MaxConcurrency reached")
 
-// ErrRunNotFound is an error for when a run isn't found in a FinishRun method.
+// ErrRunNotFound is an error for when a run isn't found in a FinishRun or IncrementRunTry method.
 var ErrRunNotFound = errors.New("run not found")
 
 // ErrNotFound is an error for when a task could not be found
@@ -61,6 +65,9 @@ var (
 	userByTaskID = []byte(basePath + "user_by_task_id")
 	nameByTaskID = []byte(basePath + "name_by_task_id")
 	runIDs       = []byte(basePath + "run_ids")
+	taskVersions = []byte(basePath + "task_versions")
+	notifyRules  = []byte(basePath + "notification_rules")
+	taskLeases   = []byte(basePath + "task_leases")
 )
 
 // New gives us a new Store based on "go.etcd.io/bbolt"
@@ -80,7 +87,8 @@ func New(db *bolt.DB, rootBucket string) (*Store, error) {
 		for _, b := range [][]byte{
 			tasksPath, orgsPath, usersPath, taskMetaPath,
 			orgByTaskID, userByTaskID,
-			nameByTaskID, runIDs,
+			nameByTaskID, runIDs, taskVersions, notifyRules,
+			taskLeases,
 		} {
 			_, err := root.CreateBucketIfNotExists(b)
 			if err != nil {
@@ -119,6 +127,11 @@ func (s *Store) CreateTask(ctx context.Context, req backend.CreateTaskRequest) (
 			return err
 		}
 
+		// first version of the script
+		if _, err := putTaskVersion(b, encodedID, req.Script, req.Author); err != nil {
+			return err
+		}
+
 		// name
 		err = b.Bucket(nameByTaskID).Put(encodedID, name)
 		if err != nil {
@@ -218,6 +231,7 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 		res.OldScript = string(v)
 
 		newScript := req.Script
+		version := latestTaskVersion(b, encodedID)
 		if req.Script == "" {
 			// Need to build op from existing script.
 			op, err = options.FromScript(string(v))
@@ -232,6 +246,17 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 			if err := b.Bucket(nameByTaskID).Put(encodedID, []byte(op.Name)); err != nil {
 				return err
 			}
+			if req.Script != res.OldScript {
+				if version == 0 {
+					// The task was created before versions were recorded; keep its old script as the first version.
+					if _, err := putTaskVersion(b, encodedID, res.OldScript, platform.InvalidID()); err != nil {
+						return err
+					}
+				}
+				if version, err = putTaskVersion(b, encodedID, req.Script, req.Author); err != nil {
+					return err
+				}
+			}
 		}
 
 		var userID, orgID platform.ID
@@ -265,11 +290,12 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 		res.NewMeta = stm
 
 		res.NewTask = backend.StoreTask{
-			ID:     req.ID,
-			Org:    orgID,
-			User:   userID,
-			Name:   op.Name,
-			Script: newScript,
+			ID:      req.ID,
+			Org:     orgID,
+			User:    userID,
+			Name:    op.Name,
+			Script:  newScript,
+			Version: version,
 		}
 
 		return nil
@@ -364,6 +390,7 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 				tasks[i].Task.ID = taskIDs[i]
 				tasks[i].Task.Script = string(b.Bucket(tasksPath).Get(encodedID))
 				tasks[i].Task.Name = string(b.Bucket(nameByTaskID).Get(encodedID))
+				tasks[i].Task.Version = latestTaskVersion(b, encodedID)
 			}
 		}
 		if params.Org.Valid() {
@@ -462,6 +489,7 @@ func (s *Store) ListTasks(ctx context.Context, params backend.TaskSearchParams)
 func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.StoreTask, error) {
 	var userID, orgID platform.ID
 	var script, name string
+	var version uint32
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, err
@@ -483,6 +511,7 @@ func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.Stor
 		}
 
 		name = string(b.Bucket(nameByTaskID).Get(encodedID))
+		version = latestTaskVersion(b, encodedID)
 		return nil
 	})
 	if err != nil {
@@ -490,11 +519,12 @@ func (s *Store) FindTaskByID(ctx context.Context, id platform.ID) (*backend.Stor
 	}
 
 	return &backend.StoreTask{
-		ID:     id,
-		Org:    orgID,
-		User:   userID,
-		Name:   name,
-		Script: script,
+		ID:      id,
+		Org:     orgID,
+		User:    userID,
+		Name:    name,
+		Script:  script,
+		Version: version,
 	}, err
 }
 
@@ -523,6 +553,7 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 	var stmBytes []byte
 	var userID, orgID platform.ID
 	var script, name string
+	var version uint32
 	encodedID, err := id.Encode()
 	if err != nil {
 		return nil, nil, err
@@ -547,6 +578,7 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 		}
 
 		name = string(b.Bucket(nameByTaskID).Get(encodedID))
+		version = latestTaskVersion(b, encodedID)
 		return nil
 	})
 	if err != nil {
@@ -559,11 +591,12 @@ func (s *Store) FindTaskByIDWithMeta(ctx context.Context, id platform.ID) (*back
 	}
 
 	return &backend.StoreTask{
-		ID:     id,
-		Org:    orgID,
-		User:   userID,
-		Name:   name,
-		Script: script,
+		ID:      id,
+		Org:     orgID,
+		User:    userID,
+		Name:    name,
+		Script:  script,
+		Version: version,
 	}, &stm, nil
 }
 
@@ -596,6 +629,15 @@ func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, e
 		if err := b.Bucket(nameByTaskID).Delete(encodedID); err != nil {
 			return err
 		}
+		if err := deleteTaskVersions(b, encodedID); err != nil {
+			return err
+		}
+		if err := deleteNotificationRules(b, encodedID); err != nil {
+			return err
+		}
+		if err := b.Bucket(taskLeases).Delete(encodedID); err != nil {
+			return err
+		}
 
 		org := b.Bucket(orgByTaskID).Get(encodedID)
 		if len(org) > 0 {
@@ -614,7 +656,7 @@ func (s *Store) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, e
 	return true, nil
 }
 
-func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
+func (s *Store) CreateNextRun(ctx context.Context, taskID, owner platform.ID, now int64) (backend.RunCreation, error) {
 	var rc backend.RunCreation
 
 	encodedID, err := taskID.Encode()
@@ -629,6 +671,16 @@ func (s *Store) CreateNextRun(ctx context.Context, taskID platform.ID, now int64
 			return backend.ErrTaskNotFound
 		}
 
+		if owner.Valid() {
+			v := b.Bucket(taskLeases).Get(encodedID)
+			if v == nil {
+				return backend.ErrTaskLeaseNotHeld
+			}
+			if leaseOwner, leaseExpiresAt := decodeTaskLease(v); leaseOwner != owner || leaseExpiresAt <= now {
+				return backend.ErrTaskLeaseNotHeld
+			}
+		}
+
 		var stm backend.StoreTaskMeta
 		err := stm.Unmarshal(stmBytes)
 		if err != nil {
@@ -682,6 +734,43 @@ func (s *Store) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 	})
 }
 
+// IncrementRunTry increments the try of the in-progress run runID, and returns the new try.
+func (s *Store) IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return 0, err
+	}
+
+	var try uint32
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
+		if stmBytes == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(stmBytes); err != nil {
+			return err
+		}
+		var ok bool
+		if try, ok = stm.IncrementRunTry(runID); !ok {
+			return ErrRunNotFound
+		}
+
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(s.bucket).Bucket(taskMetaPath).Put(encodedID, stmBytes)
+	}); err != nil {
+		return 0, err
+	}
+
+	return try, nil
+}
+
 func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, start, end, requestedAt int64) error {
 	encodedID, err := taskID.Encode()
 	if err != nil {
@@ -708,6 +797,369 @@ func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 	})
 }
 
+func (s *Store) CancelManualRun(_ context.Context, taskID platform.ID, start, end int64) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
+		if stmBytes == nil {
+			return backend.ErrTaskNotFound
+		}
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(stmBytes); err != nil {
+			return err
+		}
+		if !stm.CancelManualRun(start, end) {
+			return backend.ErrManualRunNotFound
+		}
+
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return err
+		}
+
+		return tx.Bucket(s.bucket).Bucket(taskMetaPath).Put(encodedID, stmBytes)
+	})
+}
+
+// FindTaskVersions returns the versions of the task's script, oldest first.
+func (s *Store) FindTaskVersions(ctx context.Context, taskID platform.ID) ([]backend.StoreTaskVersion, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []backend.StoreTaskVersion
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		vb := b.Bucket(taskVersions).Bucket(encodedID)
+		if vb == nil {
+			return nil
+		}
+		return vb.ForEach(func(k, v []byte) error {
+			tv, err := decodeTaskVersion(taskID, k, v)
+			if err != nil {
+				return err
+			}
+			versions = append(versions, *tv)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// FindTaskVersion returns the given version of the task's script.
+func (s *Store) FindTaskVersion(ctx context.Context, taskID platform.ID, version uint32) (*backend.StoreTaskVersion, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var tv *backend.StoreTaskVersion
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		vb := b.Bucket(taskVersions).Bucket(encodedID)
+		if vb == nil {
+			return backend.ErrTaskVersionNotFound
+		}
+		k := encodeTaskVersion(version)
+		v := vb.Get(k)
+		if v == nil {
+			return backend.ErrTaskVersionNotFound
+		}
+		tv, err = decodeTaskVersion(taskID, k, v)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tv, nil
+}
+
+// storedTaskVersion is the JSON encoding of a version of a task's script.
+type storedTaskVersion struct {
+	Script    string `json:"script"`
+	Author    uint64 `json:"author,omitempty"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+func encodeTaskVersion(version uint32) []byte {
+	k := make([]byte, 4)
+	binary.BigEndian.PutUint32(k, version)
+	return k
+}
+
+func decodeTaskVersion(taskID platform.ID, k, v []byte) (*backend.StoreTaskVersion, error) {
+	var stv storedTaskVersion
+	if err := json.Unmarshal(v, &stv); err != nil {
+		return nil, err
+	}
+	return &backend.StoreTaskVersion{
+		TaskID:    taskID,
+		Version:   binary.BigEndian.Uint32(k),
+		Script:    stv.Script,
+		Author:    platform.ID(stv.Author),
+		CreatedAt: stv.CreatedAt,
+	}, nil
+}
+
+// latestTaskVersion returns the latest version of the script of the task encodedID,
+// or 0 if no version of it was recorded.
+func latestTaskVersion(b *bolt.Bucket, encodedID []byte) uint32 {
+	vb := b.Bucket(taskVersions).Bucket(encodedID)
+	if vb == nil {
+		return 0
+	}
+	k, _ := vb.Cursor().Last()
+	if k == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(k)
+}
+
+// putTaskVersion records script as the next version of the script of the task encodedID, and returns its version.
+func putTaskVersion(b *bolt.Bucket, encodedID []byte, script string, author platform.ID) (uint32, error) {
+	vb, err := b.Bucket(taskVersions).CreateBucketIfNotExists(encodedID)
+	if err != nil {
+		return 0, err
+	}
+
+	version := latestTaskVersion(b, encodedID) + 1
+	v, err := json.Marshal(storedTaskVersion{
+		Script:    script,
+		Author:    uint64(author),
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := vb.Put(encodeTaskVersion(version), v); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// deleteTaskVersions deletes the versions of the script of the task encodedID.
+func deleteTaskVersions(b *bolt.Bucket, encodedID []byte) error {
+	if err := b.Bucket(taskVersions).DeleteBucket(encodedID); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	return nil
+}
+
+// CreateNotificationRule adds the notification rule to its task, and sets its ID.
+func (s *Store) CreateNotificationRule(ctx context.Context, r *platform.TaskNotificationRule) error {
+	encodedID, err := r.TaskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		rb, err := b.Bucket(notifyRules).CreateBucketIfNotExists(encodedID)
+		if err != nil {
+			return err
+		}
+
+		rule := *r
+		rule.ID = s.idGen.ID()
+		encodedRuleID, err := rule.ID.Encode()
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		if err := rb.Put(encodedRuleID, v); err != nil {
+			return err
+		}
+
+		r.ID = rule.ID
+		return nil
+	})
+}
+
+// FindNotificationRules returns the notification rules of the task, in the order they were created.
+func (s *Store) FindNotificationRules(ctx context.Context, taskID platform.ID) ([]*platform.TaskNotificationRule, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []*platform.TaskNotificationRule{}
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		rb := b.Bucket(notifyRules).Bucket(encodedID)
+		if rb == nil {
+			return nil
+		}
+		return rb.ForEach(func(k, v []byte) error {
+			var r platform.TaskNotificationRule
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			rules = append(rules, &r)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// DeleteNotificationRule removes the notification rule from the task.
+func (s *Store) DeleteNotificationRule(ctx context.Context, taskID, ruleID platform.ID) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+	encodedRuleID, err := ruleID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		rb := b.Bucket(notifyRules).Bucket(encodedID)
+		if rb == nil || rb.Get(encodedRuleID) == nil {
+			return backend.ErrNotificationRuleNotFound
+		}
+		return rb.Delete(encodedRuleID)
+	})
+}
+
+// ClaimTaskLease acquires or renews the lease of owner on the task, until the Unix timestamp expiresAt.
+func (s *Store) ClaimTaskLease(ctx context.Context, taskID, owner platform.ID, now, expiresAt int64) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		if b.Bucket(tasksPath).Get(encodedID) == nil {
+			return backend.ErrTaskNotFound
+		}
+
+		lb := b.Bucket(taskLeases)
+		if v := lb.Get(encodedID); v != nil {
+			leaseOwner, leaseExpiresAt := decodeTaskLease(v)
+			if leaseOwner != owner && leaseExpiresAt > now {
+				return backend.ErrTaskLeaseHeld
+			}
+		}
+		return lb.Put(encodedID, encodeTaskLease(owner, expiresAt))
+	})
+}
+
+// ClaimTaskLeases acquires or renews the leases of owner on the tasks, until the Unix timestamp expiresAt, in a single transaction.
+func (s *Store) ClaimTaskLeases(ctx context.Context, taskIDs []platform.ID, owner platform.ID, now, expiresAt int64) ([]platform.ID, error) {
+	var claimed []platform.ID
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		claimed = claimed[:0]
+		b := tx.Bucket(s.bucket)
+		lb := b.Bucket(taskLeases)
+		for _, id := range taskIDs {
+			encodedID, err := id.Encode()
+			if err != nil {
+				return err
+			}
+			if b.Bucket(tasksPath).Get(encodedID) == nil {
+				continue
+			}
+
+			if v := lb.Get(encodedID); v != nil {
+				leaseOwner, leaseExpiresAt := decodeTaskLease(v)
+				if leaseOwner != owner && leaseExpiresAt > now {
+					continue
+				}
+			}
+			if err := lb.Put(encodedID, encodeTaskLease(owner, expiresAt)); err != nil {
+				return err
+			}
+			claimed = append(claimed, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// ReleaseTaskLease releases the lease of owner on the task.
+func (s *Store) ReleaseTaskLease(ctx context.Context, taskID, owner platform.ID) error {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		lb := tx.Bucket(s.bucket).Bucket(taskLeases)
+		v := lb.Get(encodedID)
+		if v == nil {
+			return nil
+		}
+		if leaseOwner, _ := decodeTaskLease(v); leaseOwner != owner {
+			return nil
+		}
+		return lb.Delete(encodedID)
+	})
+}
+
+// encodeTaskLease encodes the lease of owner until expiresAt as the big-endian owner followed by the big-endian expiresAt.
+func encodeTaskLease(owner platform.ID, expiresAt int64) []byte {
+	v := make([]byte, 16)
+	binary.BigEndian.PutUint64(v, uint64(owner))
+	binary.BigEndian.PutUint64(v[8:], uint64(expiresAt))
+	return v
+}
+
+func decodeTaskLease(v []byte) (owner platform.ID, expiresAt int64) {
+	return platform.ID(binary.BigEndian.Uint64(v)), int64(binary.BigEndian.Uint64(v[8:]))
+}
+
+// deleteNotificationRules deletes the notification rules of the task encodedID.
+func deleteNotificationRules(b *bolt.Bucket, encodedID []byte) error {
+	if err := b.Bucket(notifyRules).DeleteBucket(encodedID); err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+	return nil
+}
+
 // Close closes the store
 func (s *Store) Close() error {
 	return s.db.Close()
@@ -753,6 +1205,15 @@ func (s *Store) DeleteUser(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := deleteTaskVersions(b, k); err != nil {
+				return err
+			}
+			if err := deleteNotificationRules(b, k); err != nil {
+				return err
+			}
+			if err := b.Bucket(taskLeases).Delete(k); err != nil {
+				return err
+			}
 
 			org := b.Bucket(orgByTaskID).Get(k)
 			if len(org) > 0 {
@@ -817,6 +1278,15 @@ func (s *Store) DeleteOrg(ctx context.Context, id platform.ID) error {
 			if err := b.Bucket(nameByTaskID).Delete(k); err != nil {
 				return err
 			}
+			if err := deleteTaskVersions(b, k); err != nil {
+				return err
+			}
+			if err := deleteNotificationRules(b, k); err != nil {
+				return err
+			}
+			if err := b.Bucket(taskLeases).Delete(k); err != nil {
+				return err
+			}
 			user := b.Bucket(userByTaskID).Get(k)
 			if len(user) > 0 {
 				ub := b.Bucket(usersPath).Bucket(user)
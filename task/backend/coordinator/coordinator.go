@@ -3,6 +3,8 @@ package coordinator
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/task/backend"
@@ -16,6 +18,26 @@ type Coordinator struct {
 	sch    backend.Scheduler
 
 	limit int
+
+	// Owner of the leases claimed by this coordinator, and how long they last, in seconds.
+	// Leases are not used when owner is invalid.
+	owner        platform.ID
+	leaseSeconds int64
+
+	// Context and interval of the heartbeats, if set.
+	heartbeatCtx      context.Context
+	heartbeatInterval time.Duration
+
+	leaseMu  sync.Mutex            // Protects the following fields.
+	leases   map[platform.ID]lease // Leases held on the tasks claimed by the scheduler, by task ID.
+	released bool                  // Whether ReleaseLeases was called.
+}
+
+// lease is the lease held by a coordinator on a task claimed by its scheduler.
+type lease struct {
+	expiresAt int64
+	// Script of the task claimed by the scheduler, to update the scheduler when another process updates the task.
+	script string
 }
 
 type Option func(*Coordinator)
@@ -26,23 +48,67 @@ func WithLimit(i int) Option {
 	}
 }
 
+// WithLeases makes the coordinator claim the lease of tasks as owner before its scheduler runs them,
+// so that the schedulers of processes sharing a store each run a distinct part of the tasks.
+// Leases last d, rounded down to a second and at least a second, and are renewed by Heartbeat.
+func WithLeases(owner platform.ID, d time.Duration) Option {
+	return func(c *Coordinator) {
+		c.owner = owner
+		c.leaseSeconds = int64(d / time.Second)
+		if c.leaseSeconds < 1 {
+			c.leaseSeconds = 1
+		}
+	}
+}
+
+// WithHeartbeat calls Heartbeat once the coordinator is created, and then every interval until ctx is done.
+// The interval must be well under the duration of leases, so that they are renewed before they expire.
+func WithHeartbeat(ctx context.Context, interval time.Duration) Option {
+	return func(c *Coordinator) {
+		c.heartbeatCtx = ctx
+		c.heartbeatInterval = interval
+	}
+}
+
 func New(logger *zap.Logger, scheduler backend.Scheduler, st backend.Store, opts ...Option) *Coordinator {
 	c := &Coordinator{
 		logger: logger,
 		sch:    scheduler,
 		Store:  st,
 		limit:  1000,
+		leases: make(map[platform.ID]lease),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
 
-	go c.claimExistingTasks()
+	// With leases, tasks are claimed by Heartbeat.
+	if !c.owner.Valid() {
+		go c.claimExistingTasks()
+	} else if c.heartbeatInterval > 0 {
+		go c.heartbeat(c.heartbeatCtx, c.heartbeatInterval)
+	}
 
 	return c
 }
 
+// heartbeat calls Heartbeat at once, and then every interval until ctx is done.
+func (c *Coordinator) heartbeat(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.Heartbeat(ctx, time.Now().Unix())
+	for {
+		select {
+		case now := <-ticker.C:
+			c.Heartbeat(ctx, now.Unix())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // claimExistingTasks is called on startup to claim all tasks in the store.
 func (c *Coordinator) claimExistingTasks() {
 	tasks, err := c.Store.ListTasks(context.Background(), backend.TaskSearchParams{})
@@ -80,7 +146,8 @@ func (c *Coordinator) CreateTask(ctx context.Context, req backend.CreateTaskRequ
 		return id, err
 	}
 
-	if err := c.sch.ClaimTask(task, meta); err != nil {
+	// A task leased by another process as soon as it was created is run by that process.
+	if err := c.claimTask(ctx, task, meta); err != nil && err != backend.ErrTaskLeaseHeld {
 		_, delErr := c.Store.DeleteTask(ctx, id)
 		if delErr != nil {
 			return id, fmt.Errorf("schedule task failed: %s\n\tcleanup also failed: %s", err, delErr)
@@ -104,18 +171,18 @@ func (c *Coordinator) UpdateTask(ctx context.Context, req backend.UpdateTaskRequ
 
 	// If disabling the task, do so before modifying the script.
 	if req.Status == backend.TaskInactive && res.OldStatus != backend.TaskInactive {
-		if err := c.sch.ReleaseTask(req.ID); err != nil && err != backend.ErrTaskNotClaimed {
+		if err := c.releaseTask(ctx, req.ID); err != nil && err != backend.ErrTaskNotClaimed {
 			return res, err
 		}
 	}
 
-	if err := c.sch.UpdateTask(task, meta); err != nil && err != backend.ErrTaskNotClaimed {
+	if err := c.updateTask(task, meta); err != nil && err != backend.ErrTaskNotClaimed {
 		return res, err
 	}
 
 	// If enabling the task, claim it after modifying the script.
 	if req.Status == backend.TaskActive {
-		if err := c.sch.ClaimTask(task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+		if err := c.claimTask(ctx, task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed && err != backend.ErrTaskLeaseHeld {
 			return res, err
 		}
 	}
@@ -124,7 +191,7 @@ func (c *Coordinator) UpdateTask(ctx context.Context, req backend.UpdateTaskRequ
 }
 
 func (c *Coordinator) DeleteTask(ctx context.Context, id platform.ID) (deleted bool, err error) {
-	if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
+	if err := c.releaseTask(ctx, id); err != nil && err != backend.ErrTaskNotClaimed {
 		return false, err
 	}
 
@@ -140,7 +207,7 @@ func (c *Coordinator) DeleteOrg(ctx context.Context, orgID platform.ID) error {
 	}
 
 	for _, orgTask := range orgTasks {
-		if err := c.sch.ReleaseTask(orgTask.Task.ID); err != nil {
+		if err := c.releaseTask(ctx, orgTask.Task.ID); err != nil && err != backend.ErrTaskNotClaimed {
 			return err
 		}
 	}
@@ -157,7 +224,7 @@ func (c *Coordinator) DeleteUser(ctx context.Context, userID platform.ID) error
 	}
 
 	for _, userTask := range userTasks {
-		if err := c.sch.ReleaseTask(userTask.Task.ID); err != nil {
+		if err := c.releaseTask(ctx, userTask.Task.ID); err != nil && err != backend.ErrTaskNotClaimed {
 			return err
 		}
 	}
@@ -168,3 +235,213 @@ func (c *Coordinator) DeleteUser(ctx context.Context, userID platform.ID) error
 func (c *Coordinator) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
 	return c.sch.CancelRun(ctx, taskID, runID)
 }
+
+// Heartbeat renews the leases held by the coordinator at the Unix timestamp now,
+// and claims the leases of the active tasks that are not leased or whose lease expired,
+// such as the tasks of a coordinator that stopped; the scheduler resumes their runs in progress.
+// The tasks whose lease was taken over by another owner, that were disabled, or that were deleted are released from the scheduler.
+// Heartbeat does nothing unless the coordinator uses leases.
+func (c *Coordinator) Heartbeat(ctx context.Context, now int64) {
+	if !c.owner.Valid() {
+		return
+	}
+
+	c.leaseMu.Lock()
+	defer c.leaseMu.Unlock()
+	if c.released {
+		return
+	}
+
+	listed := make(map[platform.ID]struct{}, len(c.leases))
+	var active []backend.StoreTaskWithMeta
+	tasks, err := c.Store.ListTasks(ctx, backend.TaskSearchParams{})
+	for err == nil && len(tasks) > 0 {
+		for _, task := range tasks {
+			listed[task.Task.ID] = struct{}{}
+			if task.Meta.Status == string(backend.TaskActive) {
+				active = append(active, task)
+				continue
+			}
+			if _, held := c.leases[task.Task.ID]; held {
+				c.unscheduleTask(task.Task.ID)
+				if err := c.Store.ReleaseTaskLease(ctx, task.Task.ID, c.owner); err != nil {
+					c.logger.Info("failed to release task lease", zap.String("task_id", task.Task.ID.String()), zap.Error(err))
+				}
+			}
+		}
+		tasks, err = c.Store.ListTasks(ctx, backend.TaskSearchParams{
+			After: tasks[len(tasks)-1].Task.ID,
+		})
+	}
+	if err != nil {
+		// Tasks that were not listed are kept until their lease expires.
+		c.logger.Error("failed to list tasks to renew leases", zap.Error(err))
+		for id, l := range c.leases {
+			if _, ok := listed[id]; !ok && l.expiresAt <= now {
+				c.unscheduleTask(id)
+			}
+		}
+	} else {
+		// Release the tasks deleted by other processes.
+		for id := range c.leases {
+			if _, ok := listed[id]; !ok {
+				c.unscheduleTask(id)
+			}
+		}
+	}
+
+	// Renew and claim the leases of all the active tasks in a single transaction.
+	ids := make([]platform.ID, len(active))
+	for i, task := range active {
+		ids[i] = task.Task.ID
+	}
+	expiresAt := now + c.leaseSeconds
+	claimed, err := c.Store.ClaimTaskLeases(ctx, ids, c.owner, now, expiresAt)
+	if err != nil {
+		c.logger.Error("failed to claim task leases", zap.Error(err))
+		// Stop running the tasks once their lease may have been taken over.
+		for id, l := range c.leases {
+			if l.expiresAt <= now {
+				c.unscheduleTask(id)
+			}
+		}
+		return
+	}
+
+	isClaimed := make(map[platform.ID]struct{}, len(claimed))
+	for _, id := range claimed {
+		isClaimed[id] = struct{}{}
+	}
+	for _, task := range active {
+		t := task // Copy to avoid mistaken closure around task value.
+		if _, ok := isClaimed[t.Task.ID]; !ok {
+			if _, held := c.leases[t.Task.ID]; held {
+				c.logger.Info("task lease taken over by another owner", zap.String("task_id", t.Task.ID.String()))
+				c.unscheduleTask(t.Task.ID)
+			}
+			continue
+		}
+		c.scheduleLeasedTask(ctx, &t.Task, &t.Meta, expiresAt)
+	}
+}
+
+// scheduleLeasedTask claims or updates the task in the scheduler, once its lease was claimed until expiresAt.
+// c.leaseMu must be held.
+func (c *Coordinator) scheduleLeasedTask(ctx context.Context, task *backend.StoreTask, meta *backend.StoreTaskMeta, expiresAt int64) {
+	l, held := c.leases[task.ID]
+	logger := c.logger.With(zap.String("task_id", task.ID.String()))
+
+	if !held {
+		if err := c.sch.ClaimTask(task, meta); err != nil && err != backend.ErrTaskAlreadyClaimed {
+			logger.Error("failed claim task", zap.Error(err))
+			if err := c.Store.ReleaseTaskLease(ctx, task.ID, c.owner); err != nil {
+				logger.Info("failed to release task lease", zap.Error(err))
+			}
+			return
+		}
+	} else if task.Script != l.script {
+		// Another process updated the task.
+		if err := c.sch.UpdateTask(task, meta); err != nil {
+			logger.Error("failed to update task", zap.Error(err))
+		}
+	}
+	c.leases[task.ID] = lease{expiresAt: expiresAt, script: task.Script}
+}
+
+// unscheduleTask releases the task from the scheduler, and forgets its lease.
+// c.leaseMu must be held.
+func (c *Coordinator) unscheduleTask(id platform.ID) {
+	delete(c.leases, id)
+	if err := c.sch.ReleaseTask(id); err != nil && err != backend.ErrTaskNotClaimed {
+		c.logger.Error("failed to release task", zap.String("task_id", id.String()), zap.Error(err))
+	}
+}
+
+// claimTask claims the task in the scheduler, after claiming its lease when using leases.
+// If another owner holds the lease of the task, ErrTaskLeaseHeld is returned.
+func (c *Coordinator) claimTask(ctx context.Context, task *backend.StoreTask, meta *backend.StoreTaskMeta) error {
+	if !c.owner.Valid() {
+		return c.sch.ClaimTask(task, meta)
+	}
+
+	c.leaseMu.Lock()
+	defer c.leaseMu.Unlock()
+	if c.released {
+		return backend.ErrTaskLeaseHeld
+	}
+
+	now := time.Now().Unix()
+	expiresAt := now + c.leaseSeconds
+	if err := c.Store.ClaimTaskLease(ctx, task.ID, c.owner, now, expiresAt); err != nil {
+		return err
+	}
+	if err := c.sch.ClaimTask(task, meta); err != nil {
+		if err == backend.ErrTaskAlreadyClaimed {
+			c.leases[task.ID] = lease{expiresAt: expiresAt, script: task.Script}
+			return err
+		}
+		if err := c.Store.ReleaseTaskLease(ctx, task.ID, c.owner); err != nil {
+			c.logger.Info("failed to release task lease", zap.String("task_id", task.ID.String()), zap.Error(err))
+		}
+		return err
+	}
+	c.leases[task.ID] = lease{expiresAt: expiresAt, script: task.Script}
+	return nil
+}
+
+// updateTask updates the task in the scheduler.
+func (c *Coordinator) updateTask(task *backend.StoreTask, meta *backend.StoreTaskMeta) error {
+	if !c.owner.Valid() {
+		return c.sch.UpdateTask(task, meta)
+	}
+
+	c.leaseMu.Lock()
+	defer c.leaseMu.Unlock()
+
+	if err := c.sch.UpdateTask(task, meta); err != nil {
+		return err
+	}
+	if l, ok := c.leases[task.ID]; ok {
+		l.script = task.Script
+		c.leases[task.ID] = l
+	}
+	return nil
+}
+
+// releaseTask releases the task from the scheduler, and releases its lease when using leases.
+func (c *Coordinator) releaseTask(ctx context.Context, id platform.ID) error {
+	if !c.owner.Valid() {
+		return c.sch.ReleaseTask(id)
+	}
+
+	c.leaseMu.Lock()
+	defer c.leaseMu.Unlock()
+
+	delete(c.leases, id)
+	if err := c.Store.ReleaseTaskLease(ctx, id, c.owner); err != nil {
+		return err
+	}
+	return c.sch.ReleaseTask(id)
+}
+
+// ReleaseLeases releases the leases held by the coordinator, so that other processes take over its tasks
+// at their next heartbeat instead of once the leases expire. It is meant to be called on shutdown,
+// after which the coordinator no longer claims leases.
+func (c *Coordinator) ReleaseLeases(ctx context.Context) error {
+	if !c.owner.Valid() {
+		return nil
+	}
+
+	c.leaseMu.Lock()
+	defer c.leaseMu.Unlock()
+	c.released = true
+
+	var firstErr error
+	for id := range c.leases {
+		c.unscheduleTask(id)
+		if err := c.Store.ReleaseTaskLease(ctx, id, c.owner); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
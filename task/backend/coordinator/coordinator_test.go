@@ -188,3 +188,95 @@ func TestCoordinator_ClaimExistingTasks(t *testing.T) {
 		}
 	}
 }
+
+func TestCoordinator_Leases(t *testing.T) {
+	ctx := context.Background()
+	st := backend.NewInMemStore()
+	schedA, schedB := mock.NewScheduler(), mock.NewScheduler()
+	createA, releaseA := schedA.TaskCreateChan(), schedA.TaskReleaseChan()
+	createB, updateB := schedB.TaskCreateChan(), schedB.TaskUpdateChan()
+
+	coordA := coordinator.New(zaptest.NewLogger(t), schedA, st, coordinator.WithLeases(1, time.Minute))
+	coordB := coordinator.New(zaptest.NewLogger(t), schedB, st, coordinator.WithLeases(2, time.Minute))
+
+	// A task created through a coordinator is leased to it.
+	id1, err := coordA.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := timeoutSelector(createA); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().Unix()
+	coordB.Heartbeat(ctx, now)
+	if schedB.TaskFor(id1) != nil {
+		t.Fatal("expected task leased to another coordinator not to be claimed")
+	}
+
+	// A task created by another process is claimed by the first heartbeat.
+	id2, err := st.CreateTask(ctx, backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordB.Heartbeat(ctx, now)
+	if _, err := timeoutSelector(createB); err != nil {
+		t.Fatal(err)
+	}
+	coordA.Heartbeat(ctx, now)
+	if schedA.TaskFor(id2) != nil {
+		t.Fatal("expected task leased to another coordinator not to be claimed")
+	}
+
+	// The owner of the lease of a task updated by another process updates its scheduler at its next heartbeat.
+	newScript := `option task = {name: "a task",cron: "1 * * * *"} from(bucket:"test") |> range(start:-2h)`
+	if _, err := coordA.UpdateTask(ctx, backend.UpdateTaskRequest{ID: id2, Script: newScript}); err != nil {
+		t.Fatal(err)
+	}
+	coordB.Heartbeat(ctx, now+1)
+	task, err := timeoutSelector(updateB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Script != newScript {
+		t.Fatalf("expected task to be updated to %q, got %q", newScript, task.Script)
+	}
+
+	// The lease of a coordinator that stopped heartbeating is taken over once expired,
+	// and the runs it left in progress are resumed.
+	rc, err := st.CreateNextRun(ctx, id1, platform.InvalidID(), now+120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	later := now + 2*60
+	coordB.Heartbeat(ctx, later)
+	if _, err := timeoutSelector(createB); err != nil {
+		t.Fatal(err)
+	}
+	meta := schedB.TaskMetaFor(id1)
+	if meta == nil || len(meta.CurrentlyRunning) != 1 || platform.ID(meta.CurrentlyRunning[0].RunID) != rc.Created.RunID {
+		t.Fatalf("expected task taken over with run %s in progress, got meta %v", rc.Created.RunID, meta)
+	}
+
+	// The previous owner releases the task at its next heartbeat.
+	coordA.Heartbeat(ctx, later)
+	if _, err := timeoutSelector(releaseA); err != nil {
+		t.Fatal(err)
+	}
+	if schedA.TaskFor(id1) != nil {
+		t.Fatal("expected task taken over to be released")
+	}
+
+	// Released leases are taken over at once.
+	if err := coordB.ReleaseLeases(ctx); err != nil {
+		t.Fatal(err)
+	}
+	coordA.Heartbeat(ctx, later)
+	for i := 0; i < 2; i++ {
+		if _, err := timeoutSelector(createA); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if schedA.TaskFor(id1) == nil || schedA.TaskFor(id2) == nil {
+		t.Fatal("expected released tasks to be claimed")
+	}
+}
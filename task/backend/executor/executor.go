@@ -9,17 +9,48 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/logger"
 	"github.com/influxdata/platform/query"
 	"github.com/influxdata/platform/task/backend"
 	"go.uber.org/zap"
 )
 
+// Option configures an executor.
+type Option func(*options)
+
+type options struct {
+	secrets platform.SecretService
+}
+
+// WithSecretService sets the service used to load the secrets that task
+// scripts read with secrets.get. Without it, reading a secret fails the run.
+func WithSecretService(svc platform.SecretService) Option {
+	return func(o *options) {
+		o.secrets = svc
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// compile compiles the script of task t to run at now, resolving its
+// secrets for the organization of the task.
+func (o options) compile(ctx context.Context, t *backend.StoreTask, now int64) (*flux.Spec, error) {
+	return query.Compile(ctx, t.Script, time.Unix(now, 0), o.secrets, t.Org)
+}
+
 // queryServiceExecutor is an implementation of backend.Executor that depends on a QueryService.
 type queryServiceExecutor struct {
 	svc    query.QueryService
 	st     backend.Store
 	logger *zap.Logger
+	opts   options
 }
 
 var _ backend.Executor = (*queryServiceExecutor)(nil)
@@ -27,8 +58,8 @@ var _ backend.Executor = (*queryServiceExecutor)(nil)
 // NewQueryServiceExecutor returns a new executor based on the given QueryService.
 // In general, you should prefer NewAsyncQueryServiceExecutor, as that code is smaller and simpler,
 // because asynchronous queries are more in line with the Executor interface.
-func NewQueryServiceExecutor(logger *zap.Logger, svc query.QueryService, st backend.Store) backend.Executor {
-	return &queryServiceExecutor{logger: logger, svc: svc, st: st}
+func NewQueryServiceExecutor(logger *zap.Logger, svc query.QueryService, st backend.Store, opts ...Option) backend.Executor {
+	return &queryServiceExecutor{logger: logger, svc: svc, st: st, opts: newOptions(opts)}
 }
 
 func (e *queryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
@@ -44,6 +75,7 @@ func (e *queryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRu
 type syncRunPromise struct {
 	qr     backend.QueuedRun
 	svc    query.QueryService
+	opts   options
 	t      *backend.StoreTask
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -65,6 +97,7 @@ func newSyncRunPromise(ctx context.Context, qr backend.QueuedRun, e *queryServic
 	rp := &syncRunPromise{
 		qr:     qr,
 		svc:    e.svc,
+		opts:   e.opts,
 		t:      t,
 		logger: log,
 		logEnd: logEnd,
@@ -120,7 +153,7 @@ func (p *syncRunPromise) finish(res *runResult, err error) {
 }
 
 func (p *syncRunPromise) doQuery() {
-	spec, err := flux.Compile(p.ctx, p.t.Script, time.Unix(p.qr.Now, 0))
+	spec, err := p.opts.compile(p.ctx, p.t, p.qr.Now)
 	if err != nil {
 		p.finish(nil, err)
 		return
@@ -170,13 +203,14 @@ type asyncQueryServiceExecutor struct {
 	svc    query.AsyncQueryService
 	st     backend.Store
 	logger *zap.Logger
+	opts   options
 }
 
 var _ backend.Executor = (*asyncQueryServiceExecutor)(nil)
 
 // NewQueryServiceExecutor returns a new executor based on the given AsyncQueryService.
-func NewAsyncQueryServiceExecutor(logger *zap.Logger, svc query.AsyncQueryService, st backend.Store) backend.Executor {
-	return &asyncQueryServiceExecutor{logger: logger, svc: svc, st: st}
+func NewAsyncQueryServiceExecutor(logger *zap.Logger, svc query.AsyncQueryService, st backend.Store, opts ...Option) backend.Executor {
+	return &asyncQueryServiceExecutor{logger: logger, svc: svc, st: st, opts: newOptions(opts)}
 }
 
 func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
@@ -185,7 +219,7 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 		return nil, err
 	}
 
-	spec, err := flux.Compile(ctx, t.Script, time.Unix(run.Now, 0))
+	spec, err := e.opts.compile(ctx, t, run.Now)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +232,8 @@ func (e *asyncQueryServiceExecutor) Execute(ctx context.Context, run backend.Que
 	}
 	q, err := e.svc.Query(ctx, req)
 	if err != nil {
-		return nil, err
+		// The query service may be unavailable or over its quota for now.
+		return nil, backend.RetryableError(err)
 	}
 
 	return newAsyncRunPromise(run, q, e), nil
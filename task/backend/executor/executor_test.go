@@ -371,8 +371,11 @@ func testExecutorServiceError(t *testing.T, fn createSysFn) {
 		var forced = errors.New("forced")
 		sys.svc.FailNextQuery(forced)
 		rp, err := sys.ex.Execute(context.Background(), qr)
-		if err == forced {
-			// Expected err matches.
+		if err != nil && err.Error() == forced.Error() {
+			// Expected err matches, and the run may be retried.
+			if !backend.IsRetryable(err) {
+				t.Fatalf("expected query service error to be retryable")
+			}
 			if rp != nil {
 				t.Fatalf("expected nil run promise when execution fails, got %v", rp)
 			}
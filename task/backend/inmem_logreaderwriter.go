@@ -42,6 +42,7 @@ func (r *runReaderWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, wh
 			TaskID:       rlb.Task.ID,
 			Status:       status.String(),
 			ScheduledFor: sf.Format(time.RFC3339),
+			TaskVersion:  int(rlb.Task.Version),
 		}
 		if rlb.RequestedAt != 0 {
 			run.RequestedAt = time.Unix(rlb.RequestedAt, 0).UTC().Format(time.RFC3339)
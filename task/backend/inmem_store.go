@@ -25,14 +25,29 @@ type inmem struct {
 	tasks []StoreTask
 
 	runners map[string]StoreTaskMeta
+
+	versions map[string][]StoreTaskVersion
+
+	notificationRules map[string][]platform.TaskNotificationRule
+
+	leases map[string]taskLease
+}
+
+// taskLease is the lease of an owner on a task.
+type taskLease struct {
+	owner     platform.ID
+	expiresAt int64
 }
 
 // NewInMemStore returns a new in-memory store.
 // This store is not designed to be efficient, it is here for testing purposes.
 func NewInMemStore() Store {
 	return &inmem{
-		idgen:   snowflake.NewIDGenerator(),
-		runners: map[string]StoreTaskMeta{},
+		idgen:             snowflake.NewIDGenerator(),
+		runners:           map[string]StoreTaskMeta{},
+		versions:          map[string][]StoreTaskVersion{},
+		notificationRules: map[string][]platform.TaskNotificationRule{},
+		leases:            map[string]taskLease{},
 	}
 }
 
@@ -53,12 +68,21 @@ func (s *inmem) CreateTask(_ context.Context, req CreateTaskRequest) (platform.I
 		Name: o.Name,
 
 		Script: req.Script,
+
+		Version: 1,
 	}
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.tasks = append(s.tasks, task)
+	s.versions[id.String()] = []StoreTaskVersion{{
+		TaskID:    id,
+		Version:   task.Version,
+		Script:    task.Script,
+		Author:    req.Author,
+		CreatedAt: time.Now().Unix(),
+	}}
 
 	stm := StoreTaskMeta{
 		MaxConcurrency:  int32(o.Concurrency),
@@ -100,8 +124,16 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 			if err != nil {
 				return res, err
 			}
-		} else {
+		} else if req.Script != t.Script {
 			t.Script = req.Script
+			t.Version++
+			s.versions[idStr] = append(s.versions[idStr], StoreTaskVersion{
+				TaskID:    t.ID,
+				Version:   t.Version,
+				Script:    t.Script,
+				Author:    req.Author,
+				CreatedAt: time.Now().Unix(),
+			})
 		}
 		t.Name = op.Name
 
@@ -262,6 +294,9 @@ func (s *inmem) DeleteTask(_ context.Context, id platform.ID) (deleted bool, err
 
 	// Delete entry from slice.
 	s.tasks = append(s.tasks[:idx], s.tasks[idx+1:]...)
+	delete(s.versions, id.String())
+	delete(s.notificationRules, id.String())
+	delete(s.leases, id.String())
 	return true, nil
 }
 
@@ -269,7 +304,7 @@ func (s *inmem) Close() error {
 	return nil
 }
 
-func (s *inmem) CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (RunCreation, error) {
+func (s *inmem) CreateNextRun(ctx context.Context, taskID, owner platform.ID, now int64) (RunCreation, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -278,6 +313,12 @@ func (s *inmem) CreateNextRun(ctx context.Context, taskID platform.ID, now int64
 		return RunCreation{}, errors.New("task not found")
 	}
 
+	if owner.Valid() {
+		if l, ok := s.leases[taskID.String()]; !ok || l.owner != owner || l.expiresAt <= now {
+			return RunCreation{}, ErrTaskLeaseNotHeld
+		}
+	}
+
 	makeID := func() (platform.ID, error) {
 		return s.idgen.ID(), nil
 	}
@@ -312,6 +353,154 @@ func (s *inmem) FinishRun(ctx context.Context, taskID, runID platform.ID) error
 	return nil
 }
 
+// IncrementRunTry increments the try of the in-progress run runID, and returns the new try.
+func (s *inmem) IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stm, ok := s.runners[taskID.String()]
+	if !ok {
+		return 0, errors.New("taskRunner not found")
+	}
+
+	try, ok := stm.IncrementRunTry(runID)
+	if !ok {
+		return 0, errors.New("run not found")
+	}
+
+	s.runners[taskID.String()] = stm
+	return try, nil
+}
+
+func (s *inmem) FindTaskVersions(_ context.Context, taskID platform.ID) ([]StoreTaskVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions, ok := s.versions[taskID.String()]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	// Return a copy of the versions.
+	return append([]StoreTaskVersion(nil), versions...), nil
+}
+
+func (s *inmem) FindTaskVersion(_ context.Context, taskID platform.ID, version uint32) (*StoreTaskVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions, ok := s.versions[taskID.String()]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	for _, v := range versions {
+		if v.Version == version {
+			return &v, nil
+		}
+	}
+	return nil, ErrTaskVersionNotFound
+}
+
+func (s *inmem) CreateNotificationRule(_ context.Context, r *platform.TaskNotificationRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.versions[r.TaskID.String()]; !ok {
+		return ErrTaskNotFound
+	}
+
+	r.ID = s.idgen.ID()
+	s.notificationRules[r.TaskID.String()] = append(s.notificationRules[r.TaskID.String()], *r)
+	return nil
+}
+
+func (s *inmem) FindNotificationRules(_ context.Context, taskID platform.ID) ([]*platform.TaskNotificationRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.versions[taskID.String()]; !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	rules := s.notificationRules[taskID.String()]
+	rs := make([]*platform.TaskNotificationRule, len(rules))
+	for i := range rules {
+		// Return copies of the rules.
+		r := rules[i]
+		rs[i] = &r
+	}
+	return rs, nil
+}
+
+func (s *inmem) DeleteNotificationRule(_ context.Context, taskID, ruleID platform.ID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.versions[taskID.String()]; !ok {
+		return ErrTaskNotFound
+	}
+
+	rules := s.notificationRules[taskID.String()]
+	for i := range rules {
+		if rules[i].ID == ruleID {
+			s.notificationRules[taskID.String()] = append(rules[:i:i], rules[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotificationRuleNotFound
+}
+
+func (s *inmem) ClaimTaskLease(_ context.Context, taskID, owner platform.ID, now, expiresAt int64) error {
+	tid := taskID.String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.versions[tid]; !ok {
+		return ErrTaskNotFound
+	}
+
+	if l, ok := s.leases[tid]; ok && l.owner != owner && l.expiresAt > now {
+		return ErrTaskLeaseHeld
+	}
+
+	s.leases[tid] = taskLease{owner: owner, expiresAt: expiresAt}
+	return nil
+}
+
+func (s *inmem) ClaimTaskLeases(_ context.Context, taskIDs []platform.ID, owner platform.ID, now, expiresAt int64) ([]platform.ID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var claimed []platform.ID
+	for _, id := range taskIDs {
+		tid := id.String()
+		if _, ok := s.versions[tid]; !ok {
+			continue
+		}
+		if l, ok := s.leases[tid]; ok && l.owner != owner && l.expiresAt > now {
+			continue
+		}
+
+		s.leases[tid] = taskLease{owner: owner, expiresAt: expiresAt}
+		claimed = append(claimed, id)
+	}
+	return claimed, nil
+}
+
+func (s *inmem) ReleaseTaskLease(_ context.Context, taskID, owner platform.ID) error {
+	tid := taskID.String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if l, ok := s.leases[tid]; ok && l.owner == owner {
+		delete(s.leases, tid)
+	}
+	return nil
+}
+
 func (s *inmem) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, start, end, requestedAt int64) error {
 	tid := taskID.String()
 
@@ -331,6 +520,25 @@ func (s *inmem) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 	return nil
 }
 
+func (s *inmem) CancelManualRun(_ context.Context, taskID platform.ID, start, end int64) error {
+	tid := taskID.String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stm, ok := s.runners[tid]
+	if !ok {
+		return errors.New("task not found")
+	}
+
+	if !stm.CancelManualRun(start, end) {
+		return ErrManualRunNotFound
+	}
+
+	s.runners[tid] = stm
+	return nil
+}
+
 func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) platform.ID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -359,6 +567,9 @@ func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) pl
 	}
 	for i := range deletingTasks {
 		delete(s.runners, s.tasks[i].ID.String())
+		delete(s.versions, deletingTasks[i].String())
+		delete(s.notificationRules, deletingTasks[i].String())
+		delete(s.leases, deletingTasks[i].String())
 	}
 	s.tasks = newTasks
 	return nil
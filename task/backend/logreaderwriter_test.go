@@ -86,6 +86,7 @@ func newFullStackAwareLogReaderWriter(t *testing.T) *fullStackAwareLogReaderWrit
 		engine,
 		svc,
 		svc,
+		nil,
 		logger.With(zap.String("service", "storage-reads")),
 	)
 	if err != nil {
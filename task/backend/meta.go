@@ -48,6 +48,22 @@ func (stm *StoreTaskMeta) FinishRun(runID platform.ID) bool {
 	return false
 }
 
+// IncrementRunTry increments the Try value of the run matching runID in m's CurrentlyRunning slice,
+// so that the run is executed again for the same now.
+//
+// If runID matched a run, IncrementRunTry returns the run's new Try value and true. Otherwise it returns 0 and false.
+func (stm *StoreTaskMeta) IncrementRunTry(runID platform.ID) (uint32, bool) {
+	for _, runner := range stm.CurrentlyRunning {
+		if platform.ID(runner.RunID) != runID {
+			continue
+		}
+
+		runner.Try++
+		return runner.Try, true
+	}
+	return 0, false
+}
+
 // CreateNextRun attempts to update stm's CurrentlyRunning slice with a new run.
 // The new run's now is assigned the earliest possible time according to stm.EffectiveCron,
 // that is later than any in-progress run and stm's LatestCompleted timestamp.
@@ -99,6 +115,7 @@ func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, e
 		Created: QueuedRun{
 			RunID: id,
 			Now:   nextScheduledUnix,
+			Try:   1,
 		},
 		NextDue:  sch.Next(nextScheduled).Unix() + int64(stm.Delay),
 		HasQueue: len(stm.ManualRuns) > 0,
@@ -151,6 +168,7 @@ func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Sc
 			RunID:       id,
 			Now:         runNow,
 			RequestedAt: q.RequestedAt,
+			Try:         1,
 		},
 		NextDue:  nextDue,
 		HasQueue: len(stm.ManualRuns) > 0,
@@ -211,6 +229,20 @@ func (stm *StoreTaskMeta) ManuallyRunTimeRange(start, end, requestedAt int64) er
 	return nil
 }
 
+// CancelManualRun removes the manual run matching start and end from stm's ManualRuns slice,
+// so that no more runs are created from it.
+//
+// If start and end matched a manual run, CancelManualRun returns true. Otherwise it returns false.
+func (stm *StoreTaskMeta) CancelManualRun(start, end int64) bool {
+	for i, mr := range stm.ManualRuns {
+		if mr.Start == start && mr.End == end {
+			stm.ManualRuns = append(stm.ManualRuns[:i], stm.ManualRuns[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // Equal returns true if all of stm's fields compare equal to other.
 // Note that this method operates on values, unlike the other methods which operate on pointers.
 //
@@ -0,0 +1,374 @@
+// Package notify sends the notifications of the notification rules of tasks
+// to their webhooks, when runs of the tasks fail or recover.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/backend"
+	"go.uber.org/zap"
+)
+
+// SignatureHeader is the header of the HMAC-SHA256 signature of the body of
+// notifications of rules with a secret key, formatted as "sha256=<hex>".
+const SignatureHeader = "X-Influx-Signature"
+
+// Default retries of notifications that could not be delivered.
+const (
+	DefaultMaxAttempts = 5
+	DefaultBackoff     = time.Second
+)
+
+// RuleFinder finds the notification rules of tasks. It is implemented by backend.Store.
+type RuleFinder interface {
+	FindNotificationRules(ctx context.Context, taskID platform.ID) ([]*platform.TaskNotificationRule, error)
+}
+
+// Notification is the JSON body posted to the webhook of a notification rule.
+type Notification struct {
+	RuleID       platform.ID `json:"ruleId"`
+	TaskID       platform.ID `json:"taskId"`
+	TaskName     string      `json:"taskName"`
+	RunID        platform.ID `json:"runId"`
+	Event        string      `json:"event"`
+	Status       string      `json:"status"`
+	ScheduledFor string      `json:"scheduledFor"`
+	// ConsecutiveFailures is the number of consecutive failed runs of the task,
+	// including the run notified of, or preceding it if the run recovered the task.
+	ConsecutiveFailures int    `json:"consecutiveFailures"`
+	Error               string `json:"error,omitempty"`
+}
+
+// Option configures a Notifier.
+type Option func(*options)
+
+type options struct {
+	secrets      platform.SecretService
+	allowedHosts map[string]struct{}
+	deniedNets   []*net.IPNet
+	maxAttempts  int
+	backoff      time.Duration
+}
+
+// WithSecretService sets the service used to load the secret keys that
+// notifications are signed with. Without it, notifications of rules with a
+// secret key are not sent.
+func WithSecretService(svc platform.SecretService) Option {
+	return func(o *options) {
+		o.secrets = svc
+	}
+}
+
+// WithAllowedHosts only posts notifications to webhooks whose host is one of
+// hosts, so that rules cannot make the server post to hosts of its internal
+// network. Hosts are compared without case, and without the port of the
+// webhook URL. Notifications are posted to any host if hosts is empty.
+func WithAllowedHosts(hosts ...string) Option {
+	return func(o *options) {
+		o.allowedHosts = nil
+		if len(hosts) == 0 {
+			return
+		}
+		o.allowedHosts = make(map[string]struct{}, len(hosts))
+		for _, h := range hosts {
+			o.allowedHosts[strings.ToLower(h)] = struct{}{}
+		}
+	}
+}
+
+// WithDeniedNetworks refuses to post notifications to the webhooks whose host
+// resolves to an address of one of nets, such as loopback or link-local
+// addresses. Addresses are checked when connecting to them, so that a host
+// cannot resolve to another address once it is checked.
+func WithDeniedNetworks(nets ...*net.IPNet) Option {
+	return func(o *options) {
+		o.deniedNets = nets
+	}
+}
+
+// WithRetries sets the maximum number of attempts to deliver a notification,
+// and how long the first retry waits. Each following retry waits twice as long
+// as the previous one. Notifications are retried after network errors and
+// responses with a 5xx or 429 status.
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.maxAttempts = maxAttempts
+		o.backoff = backoff
+	}
+}
+
+var _ backend.RunNotifier = (*Notifier)(nil)
+
+// Notifier notifies the webhooks of the notification rules of tasks of their
+// failed and recovered runs. The consecutive failed runs of tasks are counted
+// in memory, from the time the Notifier is created.
+type Notifier struct {
+	logger *zap.Logger
+	rules  RuleFinder
+	opts   options
+	client *http.Client
+
+	mu       sync.Mutex
+	failures map[platform.ID]int // Consecutive failed runs by task ID.
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewNotifier returns a Notifier of the rules found by rules.
+func NewNotifier(logger *zap.Logger, rules RuleFinder, opts ...Option) *Notifier {
+	o := options{
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Notifier{
+		logger:   logger.With(zap.String("svc", "taskd/notify")),
+		rules:    rules,
+		opts:     o,
+		client:   newClient(o.deniedNets),
+		failures: make(map[platform.ID]int),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// newClient returns the client notifications are posted with. It does not
+// follow redirects, which would post notifications to hosts that are not
+// allowed, nor use proxies, which would connect to denied addresses in their
+// place.
+func newClient(denied []*net.IPNet) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			for _, n := range denied {
+				if n.Contains(ip) {
+					return deniedAddressError{ip: ip}
+				}
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// deniedAddressError is the error of connections to webhooks at denied addresses.
+type deniedAddressError struct {
+	ip net.IP
+}
+
+func (e deniedAddressError) Error() string {
+	return fmt.Sprintf("webhook address %s is denied", e.ip)
+}
+
+// isDenied returns whether err is the error of a request to a webhook at a denied address.
+func isDenied(err error) bool {
+	if ue, ok := err.(*url.Error); ok {
+		err = ue.Err
+	}
+	if oe, ok := err.(*net.OpError); ok {
+		err = oe.Err
+	}
+	_, ok := err.(deniedAddressError)
+	return ok
+}
+
+// RunFinished counts the consecutive failed runs of the task of rlb, and
+// sends the notifications of its rules in the background.
+func (n *Notifier) RunFinished(rlb backend.RunLogBase, status backend.RunStatus, runErr error) {
+	taskID := rlb.Task.ID
+
+	n.mu.Lock()
+	failures := n.failures[taskID]
+	switch status {
+	case backend.RunFail:
+		failures++
+		n.failures[taskID] = failures
+	case backend.RunSuccess:
+		delete(n.failures, taskID)
+	}
+	n.mu.Unlock()
+
+	// Only a success following failures is notified of.
+	if status != backend.RunFail && failures == 0 {
+		return
+	}
+
+	nt := Notification{
+		TaskID:              taskID,
+		TaskName:            rlb.Task.Name,
+		RunID:               rlb.RunID,
+		Status:              status.String(),
+		ScheduledFor:        time.Unix(rlb.RunScheduledFor, 0).UTC().Format(time.RFC3339),
+		ConsecutiveFailures: failures,
+	}
+	if runErr != nil {
+		nt.Error = runErr.Error()
+	}
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		n.notify(rlb.Task.Org, status, nt)
+	}()
+}
+
+// notify sends nt to the webhooks of the rules of its task matching the run status.
+func (n *Notifier) notify(orgID platform.ID, status backend.RunStatus, nt Notification) {
+	logger := n.logger.With(zap.String("task_id", nt.TaskID.String()), zap.String("run_id", nt.RunID.String()))
+
+	rules, err := n.rules.FindNotificationRules(n.ctx, nt.TaskID)
+	if err != nil {
+		logger.Info("Failed to find notification rules", zap.Error(err))
+		return
+	}
+
+	for _, r := range rules {
+		switch {
+		case status == backend.RunFail && r.On == platform.TaskNotifyOnFailure:
+		case status == backend.RunFail && r.On == platform.TaskNotifyOnConsecutiveFailures && nt.ConsecutiveFailures == r.Threshold:
+		case status == backend.RunSuccess && r.On == platform.TaskNotifyOnRecovery:
+		default:
+			continue
+		}
+
+		rnt := nt
+		rnt.RuleID = r.ID
+		rnt.Event = r.On
+		if err := n.deliver(orgID, r, rnt); err != nil {
+			logger.Info("Failed to deliver notification", zap.String("rule_id", r.ID.String()), zap.Error(err))
+		}
+	}
+}
+
+// deliver posts nt to the webhook of rule r, signed with the secret of the
+// organization orgID at the rule's secret key, retrying until it is delivered
+// or the attempts run out.
+func (n *Notifier) deliver(orgID platform.ID, r *platform.TaskNotificationRule, nt Notification) error {
+	body, err := json.Marshal(nt)
+	if err != nil {
+		return err
+	}
+
+	var signature string
+	if r.SecretKey != "" {
+		if n.opts.secrets == nil {
+			return fmt.Errorf("cannot sign notification with secret %q: no secret service", r.SecretKey)
+		}
+		secret, err := n.opts.secrets.LoadSecret(n.ctx, orgID, r.SecretKey)
+		if err != nil {
+			return fmt.Errorf("cannot sign notification with secret %q: %v", r.SecretKey, err)
+		}
+		signature = Sign([]byte(secret), body)
+	}
+
+	for attempt := 1; ; attempt++ {
+		retry, err := n.post(r.URL, body, signature)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= n.opts.maxAttempts {
+			return fmt.Errorf("attempt %d: %v", attempt, err)
+		}
+
+		backoff := n.opts.backoff << uint(attempt-1)
+		n.logger.Debug("Retrying notification", zap.String("rule_id", r.ID.String()), zap.Int("attempt", attempt), zap.Duration("backoff", backoff), zap.Error(err))
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-n.ctx.Done():
+			t.Stop()
+			return n.ctx.Err()
+		}
+	}
+}
+
+// post posts body to rawURL, and returns whether a failure to deliver it is worth retrying.
+// Redirects are not followed, and fail to deliver the notification.
+func (n *Notifier) post(rawURL string, body []byte, signature string) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	if n.opts.allowedHosts != nil {
+		if _, ok := n.opts.allowedHosts[strings.ToLower(req.URL.Hostname())]; !ok {
+			return false, fmt.Errorf("webhook host %q is not allowed", req.URL.Hostname())
+		}
+	}
+	req = req.WithContext(n.ctx)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return n.ctx.Err() == nil && !isDenied(err), err
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	retry = resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected response status %s", resp.Status)
+}
+
+// Sign returns the value of the SignatureHeader of a notification body signed with secret.
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Wait waits until the notifications of the runs finished so far are delivered, or their attempts run out.
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// Close stops delivering notifications, and waits for the deliveries in progress to return.
+func (n *Notifier) Close() error {
+	n.cancel()
+	n.wg.Wait()
+	return nil
+}
@@ -0,0 +1,251 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/mock"
+	"github.com/influxdata/platform/task/backend"
+	"github.com/influxdata/platform/task/backend/notify"
+	"go.uber.org/zap/zaptest"
+)
+
+type ruleFinder []*platform.TaskNotificationRule
+
+func (f ruleFinder) FindNotificationRules(ctx context.Context, taskID platform.ID) ([]*platform.TaskNotificationRule, error) {
+	return f, nil
+}
+
+type request struct {
+	path         string
+	signature    string
+	notification notify.Notification
+}
+
+// newWebhook returns a server sending the requests it receives on the returned channel.
+func newWebhook(t *testing.T) (*httptest.Server, chan request) {
+	requests := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		var nt notify.Notification
+		if err := json.Unmarshal(body, &nt); err != nil {
+			t.Errorf("failed to decode notification %q: %v", body, err)
+		}
+		if sig := r.Header.Get(notify.SignatureHeader); sig != "" && sig != notify.Sign([]byte("s3cr3t"), body) {
+			t.Errorf("unexpected signature %q of notification %q", sig, body)
+		}
+		requests <- request{path: r.URL.Path, signature: r.Header.Get(notify.SignatureHeader), notification: nt}
+	}))
+	return srv, requests
+}
+
+// receive returns the next n requests sent to the webhook, sorted by path.
+func receive(t *testing.T, requests chan request, n int) []request {
+	t.Helper()
+
+	var got []request
+	for len(got) < n {
+		select {
+		case r := <-requests:
+			got = append(got, r)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %d notifications, got %d", n, len(got))
+		}
+	}
+	select {
+	case r := <-requests:
+		t.Fatalf("unexpected notification %v", r)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	sort.Slice(got, func(i, j int) bool { return got[i].path < got[j].path })
+	return got
+}
+
+func TestNotifier_RunFinished(t *testing.T) {
+	srv, requests := newWebhook(t)
+	defer srv.Close()
+
+	rules := ruleFinder{
+		{ID: 10, TaskID: 1, On: platform.TaskNotifyOnFailure, URL: srv.URL + "/failure", SecretKey: "hook"},
+		{ID: 20, TaskID: 1, On: platform.TaskNotifyOnConsecutiveFailures, Threshold: 2, URL: srv.URL + "/failures"},
+		{ID: 30, TaskID: 1, On: platform.TaskNotifyOnRecovery, URL: srv.URL + "/recovery"},
+	}
+	secrets := mock.NewSecretService()
+	secrets.LoadSecretFn = func(ctx context.Context, orgID platform.ID, k string) (string, error) {
+		if orgID != 2 || k != "hook" {
+			return "", errors.New("secret not found")
+		}
+		return "s3cr3t", nil
+	}
+	n := notify.NewNotifier(zaptest.NewLogger(t), rules, notify.WithSecretService(secrets))
+	defer n.Close()
+
+	task := &backend.StoreTask{ID: 1, Org: 2, Name: "a task"}
+	rlb := func(runID platform.ID) backend.RunLogBase {
+		return backend.RunLogBase{Task: task, RunID: runID, RunScheduledFor: 60}
+	}
+
+	// A success without failures is not notified of.
+	n.RunFinished(rlb(3), backend.RunSuccess, nil)
+	n.RunFinished(rlb(4), backend.RunFail, errors.New("boom"))
+	got := receive(t, requests, 1)
+	if got[0].path != "/failure" {
+		t.Fatalf("expected failure notification, got %s", got[0].path)
+	}
+	if got[0].signature == "" {
+		t.Fatal("expected signed failure notification")
+	}
+	exp := notify.Notification{
+		RuleID:              10,
+		TaskID:              1,
+		TaskName:            "a task",
+		RunID:               4,
+		Event:               platform.TaskNotifyOnFailure,
+		Status:              backend.RunFail.String(),
+		ScheduledFor:        "1970-01-01T00:01:00Z",
+		ConsecutiveFailures: 1,
+		Error:               "boom",
+	}
+	if got[0].notification != exp {
+		t.Fatalf("unexpected notification: got %#v, expected %#v", got[0].notification, exp)
+	}
+
+	// The threshold of consecutive failures is notified of once reached.
+	n.RunFinished(rlb(5), backend.RunFail, errors.New("boom"))
+	got = receive(t, requests, 2)
+	if got[0].path != "/failure" || got[1].path != "/failures" {
+		t.Fatalf("expected failure and consecutive failures notifications, got %s and %s", got[0].path, got[1].path)
+	}
+	if nt := got[1].notification; nt.RuleID != 20 || nt.Event != platform.TaskNotifyOnConsecutiveFailures || nt.ConsecutiveFailures != 2 {
+		t.Fatalf("unexpected consecutive failures notification %#v", nt)
+	}
+	if got[1].signature != "" {
+		t.Fatal("expected unsigned notification of rule without secret key")
+	}
+
+	n.RunFinished(rlb(6), backend.RunFail, errors.New("boom"))
+	receive(t, requests, 1)
+
+	// A success after failures is a recovery.
+	n.RunFinished(rlb(7), backend.RunSuccess, nil)
+	got = receive(t, requests, 1)
+	if nt := got[0].notification; got[0].path != "/recovery" || nt.RuleID != 30 || nt.Event != platform.TaskNotifyOnRecovery || nt.Status != backend.RunSuccess.String() || nt.ConsecutiveFailures != 3 {
+		t.Fatalf("unexpected recovery notification to %s: %#v", got[0].path, nt)
+	}
+
+	n.RunFinished(rlb(8), backend.RunSuccess, nil)
+	receive(t, requests, 0)
+}
+
+func TestNotifier_Retry(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts = make(map[string]int)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[r.URL.Path]++
+
+		switch {
+		case r.URL.Path == "/unavailable" && attempts[r.URL.Path] < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/invalid":
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	rules := ruleFinder{
+		{ID: 10, TaskID: 1, On: platform.TaskNotifyOnFailure, URL: srv.URL + "/unavailable"},
+		{ID: 20, TaskID: 1, On: platform.TaskNotifyOnFailure, URL: srv.URL + "/limited"},
+		{ID: 30, TaskID: 1, On: platform.TaskNotifyOnFailure, URL: srv.URL + "/invalid"},
+	}
+	n := notify.NewNotifier(zaptest.NewLogger(t), rules, notify.WithRetries(4, time.Millisecond))
+	defer n.Close()
+
+	n.RunFinished(backend.RunLogBase{Task: &backend.StoreTask{ID: 1}, RunID: 2}, backend.RunFail, nil)
+	n.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for path, exp := range map[string]int{
+		"/unavailable": 3, // Delivered on the third attempt.
+		"/limited":     4, // Retried until the attempts run out.
+		"/invalid":     1, // Client errors are not retried.
+	} {
+		if got := attempts[path]; got != exp {
+			t.Fatalf("expected %d attempts to deliver to %s, got %d", exp, path, got)
+		}
+	}
+}
+
+func TestNotifier_WebhookRestrictions(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts = make(map[string]int)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[r.URL.Path]++
+
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/target", http.StatusTemporaryRedirect)
+		}
+	}))
+	defer srv.Close()
+
+	_, loopback, err := net.ParseCIDR("127.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name string
+		path string
+		opts []notify.Option
+	}{
+		{name: "denied network", path: "/denied", opts: []notify.Option{notify.WithDeniedNetworks(loopback)}},
+		{name: "host not allowed", path: "/disallowed", opts: []notify.Option{notify.WithAllowedHosts("example.com")}},
+		{name: "redirect", path: "/redirect"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := ruleFinder{{ID: 10, TaskID: 1, On: platform.TaskNotifyOnFailure, URL: srv.URL + tt.path}}
+			// Notifications that are retried do not finish in time.
+			n := notify.NewNotifier(zaptest.NewLogger(t), rules, append(tt.opts, notify.WithRetries(2, time.Hour))...)
+			defer n.Close()
+
+			n.RunFinished(backend.RunLogBase{Task: &backend.StoreTask{ID: 1}, RunID: 2}, backend.RunFail, nil)
+			n.Wait()
+		})
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for path, exp := range map[string]int{
+		"/denied":     0,
+		"/disallowed": 0,
+		"/redirect":   1,
+		"/target":     0,
+	} {
+		if got := attempts[path]; got != exp {
+			t.Fatalf("expected %d attempts to deliver to %s, got %d", exp, path, got)
+		}
+	}
+}
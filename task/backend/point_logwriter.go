@@ -14,6 +14,7 @@ const (
 	runIDField        = "runID"
 	scheduledForField = "scheduledFor"
 	requestedAtField  = "requestedAt"
+	taskVersionField  = "taskVersion"
 
 	taskIDTag = "taskID"
 	statusTag = "status"
@@ -43,12 +44,15 @@ func (p *PointLogWriter) UpdateRunState(ctx context.Context, rlb RunLogBase, whe
 		models.NewTag([]byte(statusTag), []byte(status.String())),
 		models.NewTag([]byte(taskIDTag), []byte(rlb.Task.ID.String())),
 	}
-	fields := make(map[string]interface{}, 3)
+	fields := make(map[string]interface{}, 4)
 	fields[runIDField] = rlb.RunID.String()
 	fields[scheduledForField] = time.Unix(rlb.RunScheduledFor, 0).UTC().Format(time.RFC3339)
 	if rlb.RequestedAt != 0 {
 		fields[requestedAtField] = time.Unix(rlb.RequestedAt, 0).UTC().Format(time.RFC3339)
 	}
+	if rlb.Task.Version != 0 {
+		fields[taskVersionField] = int64(rlb.Task.Version)
+	}
 
 	pt, err := models.NewPoint("records", tags, fields, when)
 	if err != nil {
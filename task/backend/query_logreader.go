@@ -270,6 +270,10 @@ func (re *runExtractor) extractRecord(cr flux.ColReader) error {
 				r.RequestedAt = cr.Strings(j)[i]
 			case scheduledForField:
 				r.ScheduledFor = cr.Strings(j)[i]
+			case taskVersionField:
+				if col.Type == flux.TInt {
+					r.TaskVersion = int(cr.Ints(j)[i])
+				}
 			case "status":
 				r.Status = cr.Strings(j)[i]
 			case "runID":
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/task/options"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -34,11 +35,18 @@ type DesiredState interface {
 	// and according to what's in progress and what's been finished.
 	//
 	// If a Run is requested and the cron schedule says the schedule isn't ready, a RunNotYetDueError is returned.
-	CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (RunCreation, error)
+	//
+	// If owner is valid, the run is only created while owner holds the lease of the task;
+	// otherwise ErrTaskLeaseNotHeld is returned.
+	CreateNextRun(ctx context.Context, taskID, owner platform.ID, now int64) (RunCreation, error)
 
 	// FinishRun indicates that the given run is no longer intended to be executed.
 	// This may be called after a successful or failed execution, or upon cancellation.
 	FinishRun(ctx context.Context, taskID, runID platform.ID) error
+
+	// IncrementRunTry indicates that the given run is about to be executed again, after a retryable failure.
+	// It returns the number of the try about to be executed, starting at 1 for the first execution.
+	IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error)
 }
 
 // Executor handles execution of a run.
@@ -61,6 +69,9 @@ type QueuedRun struct {
 	// The Unix timestamp (seconds since January 1, 1970 UTC) that will be set
 	// as the "now" option when executing the task.
 	Now int64
+
+	// The number of the attempt at executing the run, starting at 1.
+	Try uint32
 }
 
 // RunPromise represents an in-progress run whose result is not yet known.
@@ -90,6 +101,24 @@ type RunResult interface {
 	// TODO(mr): add more detail here like number of points written, execution time, etc.
 }
 
+// RetryableError wraps err, an error of an Executor failing to execute a run, to mark
+// it as non-terminal, so that the run is eligible for retry.
+func RetryableError(err error) error {
+	return retryableError{err}
+}
+
+type retryableError struct {
+	error
+}
+
+func (retryableError) IsRetryable() bool { return true }
+
+// IsRetryable returns true if err is an error of an Executor marked with RetryableError.
+func IsRetryable(err error) bool {
+	r, ok := err.(interface{ IsRetryable() bool })
+	return ok && r.IsRetryable()
+}
+
 // Scheduler accepts tasks and handles their scheduling.
 //
 // TODO(mr): right now the methods on Scheduler are synchronous.
@@ -117,6 +146,14 @@ type Scheduler interface {
 	CancelRun(ctx context.Context, taskID, runID platform.ID) error
 }
 
+// RunNotifier is notified of the finished runs of tasks, for example to alert on failures.
+type RunNotifier interface {
+	// RunFinished is called when the run rlb finishes with status RunSuccess or RunFail.
+	// runErr is the error the run failed with, if known.
+	// RunFinished is called on the run's goroutine, so it must not block.
+	RunFinished(rlb RunLogBase, status RunStatus, runErr error)
+}
+
 // TickSchedulerOption is a option you can use to modify the schedulers behavior.
 type TickSchedulerOption func(*TickScheduler)
 
@@ -146,6 +183,41 @@ func WithLogger(logger *zap.Logger) TickSchedulerOption {
 	}
 }
 
+// WithMaxConcurrency limits the number of runs of a single task executing at
+// once to n, regardless of the concurrency of the task. 0 means no limit.
+func WithMaxConcurrency(n int) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.maxConcurrency = n
+	}
+}
+
+// WithRetryBackoff sets how long the first retry of a run waits after a retryable failure.
+// Each following retry of the run waits twice as long as the previous one.
+func WithRetryBackoff(d time.Duration) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.retryBackoff = d
+	}
+}
+
+// WithRunNotifier sets the notifier of the runs that finish successfully or fail.
+func WithRunNotifier(n RunNotifier) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.runNotifier = n
+	}
+}
+
+// WithLeaseOwner makes the scheduler create runs as owner, so that runs are only created
+// while owner holds the lease of their task. It must be the owner the leases of the tasks
+// claimed by the scheduler are held by.
+func WithLeaseOwner(owner platform.ID) TickSchedulerOption {
+	return func(s *TickScheduler) {
+		s.leaseOwner = owner
+	}
+}
+
+// defaultRetryBackoff is the delay before the first retry of a run, when not set with WithRetryBackoff.
+const defaultRetryBackoff = time.Second
+
 // NewScheduler returns a new scheduler with the given desired state and the given now UTC timestamp.
 func NewScheduler(desiredState DesiredState, executor Executor, lw LogWriter, now int64, opts ...TickSchedulerOption) *TickScheduler {
 	o := &TickScheduler{
@@ -155,6 +227,7 @@ func NewScheduler(desiredState DesiredState, executor Executor, lw LogWriter, no
 		now:            now,
 		taskSchedulers: make(map[platform.ID]*taskScheduler),
 		logger:         zap.NewNop(),
+		retryBackoff:   defaultRetryBackoff,
 		wg:             &sync.WaitGroup{},
 		metrics:        newSchedulerMetrics(),
 	}
@@ -174,6 +247,18 @@ type TickScheduler struct {
 	now    int64
 	logger *zap.Logger
 
+	// Maximum number of concurrent runs of a task; 0 means no limit.
+	maxConcurrency int
+
+	// Delay before the first retry of a run; doubled for each following retry.
+	retryBackoff time.Duration
+
+	// Notified of finished runs, if set.
+	runNotifier RunNotifier
+
+	// Owner of the leases of the claimed tasks, if they are leased.
+	leaseOwner platform.ID
+
 	metrics *schedulerMetrics
 
 	ctx    context.Context
@@ -376,6 +461,21 @@ type taskScheduler struct {
 
 	metrics *schedulerMetrics
 
+	// Maximum number of tries of a run, from the task's retry option, and delay before its first retry.
+	maxTries     int
+	retryBackoff time.Duration
+
+	runNotifier RunNotifier
+
+	// Owner runs are created as.
+	leaseOwner platform.ID
+
+	// Context of the runners, done once the task is released.
+	ctx context.Context
+
+	retriesMu sync.Mutex    // Protects retries.
+	retries   []queuedRetry // Runs waiting for their backoff before they are retried.
+
 	nextDueMu     sync.RWMutex // Protects following fields.
 	nextDue       int64        // Unix timestamp of next due.
 	nextDueSource int64        // Run time that produced nextDue.
@@ -395,16 +495,40 @@ func newTaskScheduler(
 		return nil, err
 	}
 
+	concurrency := int(meta.MaxConcurrency)
+	if s.maxConcurrency > 0 && concurrency > s.maxConcurrency {
+		// Runs already in progress still need a runner to resume them.
+		concurrency = s.maxConcurrency
+		if n := len(meta.CurrentlyRunning); concurrency < n {
+			concurrency = n
+		}
+	}
+
+	logger := s.logger.With(zap.String("task_id", task.ID.String()))
+
+	// Runs are tried once, unless the task's options say otherwise.
+	maxTries := 1
+	if opts, err := options.FromScript(task.Script); err != nil {
+		logger.Info("Failed to parse task options; runs will not be retried", zap.Error(err))
+	} else {
+		maxTries = int(opts.Retry)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	ts := &taskScheduler{
 		now:           &s.now,
 		task:          task,
 		cancel:        cancel,
 		wg:            wg,
-		runners:       make([]*runner, meta.MaxConcurrency),
-		running:       make(map[platform.ID]runCtx, meta.MaxConcurrency),
-		logger:        s.logger.With(zap.String("task_id", task.ID.String())),
+		runners:       make([]*runner, concurrency),
+		running:       make(map[platform.ID]runCtx, concurrency),
+		logger:        logger,
 		metrics:       s.metrics,
+		maxTries:      maxTries,
+		retryBackoff:  s.retryBackoff,
+		runNotifier:   s.runNotifier,
+		leaseOwner:    s.leaseOwner,
+		ctx:           ctx,
 		nextDue:       firstDue,
 		nextDueSource: math.MinInt64,
 		hasQueue:      len(meta.ManualRuns) > 0,
@@ -434,7 +558,7 @@ func (ts *taskScheduler) WorkCurrentlyRunning(meta *StoreTaskMeta) error {
 	for _, cr := range meta.CurrentlyRunning {
 		foundWorker := false
 		for _, r := range ts.runners {
-			qr := QueuedRun{TaskID: ts.task.ID, RunID: platform.ID(cr.RunID), Now: cr.Now}
+			qr := QueuedRun{TaskID: ts.task.ID, RunID: platform.ID(cr.RunID), Now: cr.Now, Try: cr.Try}
 			if r.RestartRun(qr) {
 				foundWorker = true
 				break
@@ -449,6 +573,50 @@ func (ts *taskScheduler) WorkCurrentlyRunning(meta *StoreTaskMeta) error {
 	return nil
 }
 
+// queuedRetry is a run to retry once its backoff has passed.
+type queuedRetry struct {
+	qr        QueuedRun
+	notBefore time.Time
+}
+
+// QueueRetry queues qr to be executed again by the next runner to start work once backoff has passed.
+// The run does not hold a runner while it waits.
+func (ts *taskScheduler) QueueRetry(qr QueuedRun, backoff time.Duration) {
+	ts.retriesMu.Lock()
+	ts.retries = append(ts.retries, queuedRetry{qr: qr, notBefore: time.Now().Add(backoff)})
+	ts.retriesMu.Unlock()
+
+	// Start the retry once it is due, rather than at the next run of the task.
+	time.AfterFunc(backoff, func() {
+		select {
+		case <-ts.ctx.Done():
+			// The run is left in progress, to be resumed by the next claim of the task.
+		default:
+			ts.Work()
+		}
+	})
+}
+
+// NextRetry removes the earliest queued run whose backoff has passed by now, and returns it.
+// It returns false if no queued run is due.
+func (ts *taskScheduler) NextRetry(now time.Time) (QueuedRun, bool) {
+	ts.retriesMu.Lock()
+	defer ts.retriesMu.Unlock()
+
+	next := -1
+	for i, r := range ts.retries {
+		if !r.notBefore.After(now) && (next < 0 || r.notBefore.Before(ts.retries[next].notBefore)) {
+			next = i
+		}
+	}
+	if next < 0 {
+		return QueuedRun{}, false
+	}
+	qr := ts.retries[next].qr
+	ts.retries = append(ts.retries[:next], ts.retries[next+1:]...)
+	return qr, true
+}
+
 // Cancel interrupts this taskScheduler and its runners.
 func (ts *taskScheduler) Cancel() {
 	ts.cancel()
@@ -567,16 +735,22 @@ func (r *runner) RestartRun(qr QueuedRun) bool {
 	return true
 }
 
-// startFromWorking attempts to create a run if one is due, and then begins execution on a separate goroutine.
+// startFromWorking attempts to retry a run whose backoff has passed, or else to create a run if one is due,
+// and then begins execution on a separate goroutine.
 // r.state must be runnerWorking when this is called.
 func (r *runner) startFromWorking(now int64) {
+	if qr, ok := r.ts.NextRetry(time.Now()); ok {
+		r.startRetry(qr, now)
+		return
+	}
+
 	if nextDue, hasQueue := r.ts.NextDue(); now < nextDue && !hasQueue {
 		// Not ready for a new run. Go idle again.
 		atomic.StoreUint32(r.state, runnerIdle)
 		return
 	}
 	ctx, cancel := context.WithCancel(r.ctx)
-	rc, err := r.desiredState.CreateNextRun(ctx, r.task.ID, now)
+	rc, err := r.desiredState.CreateNextRun(ctx, r.task.ID, r.ts.leaseOwner, now)
 	if err != nil {
 		r.logger.Info("Failed to create run", zap.Error(err))
 		atomic.StoreUint32(r.state, runnerIdle)
@@ -601,10 +775,36 @@ func (r *runner) startFromWorking(now int64) {
 	r.updateRunState(qr, RunStarted, runLogger)
 }
 
+// startRetry begins executing the queued run qr again on a separate goroutine,
+// unless it was canceled while it waited for its retry.
+// r.state must be runnerWorking when this is called.
+func (r *runner) startRetry(qr QueuedRun, now int64) {
+	runLogger := r.logger.With(zap.String("run_id", qr.RunID.String()), zap.Int64("now", qr.Now))
+
+	r.ts.runningMu.Lock()
+	rCtx, ok := r.ts.running[qr.RunID]
+	r.ts.runningMu.Unlock()
+	if !ok || rCtx.Context.Err() != nil {
+		r.clearRunning(qr.RunID)
+		_ = r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID)
+		r.updateRunState(qr, RunCanceled, runLogger)
+
+		// Move on to the next execution, for a canceled run.
+		r.startFromWorking(now)
+		return
+	}
+
+	runLogger.Info("Retrying run", zap.Uint32("try", qr.Try))
+	r.wg.Add(1)
+	go r.executeAndWait(rCtx.Context, qr, runLogger)
+}
+
 func (r *runner) clearRunning(id platform.ID) {
 	r.ts.runningMu.Lock()
-	r.ts.running[id].CancelFunc() // cleanup
-	delete(r.ts.running, id)
+	if rc, ok := r.ts.running[id]; ok {
+		rc.CancelFunc() // cleanup
+		delete(r.ts.running, id)
+	}
 	r.ts.runningMu.Unlock()
 }
 
@@ -617,9 +817,13 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 	rp, err := r.executor.Execute(spCtx, qr)
 
 	if err != nil {
-		// TODO(mr): retry? and log error.
+		if IsRetryable(err) && int(qr.Try) < r.ts.maxTries {
+			r.retryRun(qr, err, runLogger)
+			return
+		}
+		runLogger.Info("Failed to execute run", zap.Error(err))
 		atomic.StoreUint32(r.state, runnerIdle)
-		r.updateRunState(qr, RunFail, runLogger)
+		r.finishRunState(qr, RunFail, err, runLogger)
 		return
 	}
 
@@ -636,13 +840,17 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 			rp.Cancel()
 		// Wait finished.
 		case <-ready:
-			r.clearRunning(qr.RunID)
 		}
 	}()
 
-	// TODO(mr): handle res.IsRetryable().
-	_, err = rp.Wait()
+	res, err := rp.Wait()
 	close(ready)
+	if err == nil && res != nil && res.Err() != nil && res.IsRetryable() && int(qr.Try) < r.ts.maxTries {
+		r.retryRun(qr, res.Err(), runLogger)
+		return
+	}
+	r.clearRunning(qr.RunID)
+
 	if err != nil {
 		if err == ErrRunCanceled {
 			_ = r.desiredState.FinishRun(r.ctx, qr.TaskID, qr.RunID)
@@ -655,7 +863,7 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 
 		runLogger.Info("Failed to wait for execution result", zap.Error(err))
 		// TODO(mr): retry?
-		r.updateRunState(qr, RunFail, runLogger)
+		r.finishRunState(qr, RunFail, err, runLogger)
 		atomic.StoreUint32(r.state, runnerIdle)
 		return
 	}
@@ -665,23 +873,71 @@ func (r *runner) executeAndWait(ctx context.Context, qr QueuedRun, runLogger *za
 		// TODO(mr): retry?
 		// Need to think about what it means if there was an error finishing a run.
 		atomic.StoreUint32(r.state, runnerIdle)
-		r.updateRunState(qr, RunFail, runLogger)
+		r.finishRunState(qr, RunFail, err, runLogger)
 		return
 	}
-	r.updateRunState(qr, RunSuccess, runLogger)
-	runLogger.Info("Execution succeeded")
+	if res != nil && res.Err() != nil {
+		runLogger.Info("Execution failed", zap.Uint32("try", qr.Try), zap.Error(res.Err()))
+		r.logWriter.AddRunLog(r.ctx, r.runLogBase(qr), time.Now(), fmt.Sprintf("Error: %v", res.Err()))
+		r.finishRunState(qr, RunFail, res.Err(), runLogger)
+	} else {
+		r.finishRunState(qr, RunSuccess, nil, runLogger)
+		runLogger.Info("Execution succeeded")
+	}
 
 	// Check again if there is a new run available, without returning to idle state.
 	r.startFromWorking(atomic.LoadInt64(r.ts.now))
 }
 
-func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
-	rlb := RunLogBase{
+// retryRun increments the try of qr after a retryable failure with runErr, and queues qr to be executed again
+// after the backoff of the try, doubling for each try. The runner moves on to other runs in the meantime.
+func (r *runner) retryRun(qr QueuedRun, runErr error, runLogger *zap.Logger) {
+	try, err := r.desiredState.IncrementRunTry(r.ctx, qr.TaskID, qr.RunID)
+	if err != nil {
+		runLogger.Info("Failed to retry run", zap.Error(err))
+		r.clearRunning(qr.RunID)
+		atomic.StoreUint32(r.state, runnerIdle)
+		r.finishRunState(qr, RunFail, runErr, runLogger)
+		return
+	}
+	qr.Try = try
+
+	// Runs queued before their tries were counted start at try 0, so their first retry is try 1.
+	var shift uint32
+	if try > 2 {
+		shift = try - 2
+	}
+	backoff := r.ts.retryBackoff << shift
+	r.ts.metrics.RetryRun(r.task.ID.String())
+	r.logWriter.AddRunLog(r.ctx, r.runLogBase(qr), time.Now(), fmt.Sprintf("Retrying in %s (try %d of %d) after error: %v", backoff, try, r.ts.maxTries, runErr))
+	runLogger.Info("Queued run for retry", zap.Uint32("try", try), zap.Duration("backoff", backoff), zap.Error(runErr))
+
+	r.ts.QueueRetry(qr, backoff)
+
+	// Move on to the next execution while the run waits for its retry.
+	r.startFromWorking(atomic.LoadInt64(r.ts.now))
+}
+
+func (r *runner) runLogBase(qr QueuedRun) RunLogBase {
+	return RunLogBase{
 		Task:            r.task,
 		RunID:           qr.RunID,
 		RunScheduledFor: qr.Now,
 		RequestedAt:     qr.RequestedAt,
 	}
+}
+
+// finishRunState updates the state of the run qr that finished with status s RunSuccess or RunFail,
+// and notifies the scheduler's RunNotifier of it.
+func (r *runner) finishRunState(qr QueuedRun, s RunStatus, runErr error, runLogger *zap.Logger) {
+	r.updateRunState(qr, s, runLogger)
+	if r.ts.runNotifier != nil {
+		r.ts.runNotifier.RunFinished(r.runLogBase(qr), s, runErr)
+	}
+}
+
+func (r *runner) updateRunState(qr QueuedRun, s RunStatus, runLogger *zap.Logger) {
+	rlb := r.runLogBase(qr)
 
 	switch s {
 	case RunStarted:
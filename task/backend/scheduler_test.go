@@ -213,6 +213,45 @@ func TestScheduler_CreateNextRunOnTick(t *testing.T) {
 	}
 }
 
+func TestScheduler_MaxConcurrency(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	o := backend.NewScheduler(d, e, backend.NopLogWriter{}, 5, backend.WithMaxConcurrency(1))
+	o.Start(context.Background())
+	defer o.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  2,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := o.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	o.Tick(6)
+	if _, err := e.PollForNumberRunning(task.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	o.Tick(7) // Can't exceed the scheduler's concurrency of 1.
+	if x, err := d.PollForNumberCreated(task.ID, 1); err != nil {
+		t.Fatalf("expected 1 run queued, but got %d", len(x))
+	}
+	running, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if running[0].Run().Now != 6 {
+		t.Fatalf("unexpected now for run 6: %d", running[0].Run().Now)
+	}
+}
+
 func TestScheduler_Release(t *testing.T) {
 	d := mock.NewDesiredState()
 	e := mock.NewExecutor()
@@ -633,3 +672,273 @@ func TestScheduler_Metrics(t *testing.T) {
 		t.Fatalf("expected 0 claims active, got %v", got)
 	}
 }
+
+func TestScheduler_Retry(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Millisecond))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	reg := prom.NewRegistry()
+	reg.MustRegister(s.PrometheusCollectors()...)
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+		Script: `option task = {name: "a task", every: 1s, retry: 3}
+from(bucket: "b") |> range(start: -1h)`,
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qr := promises[0].Run()
+
+	// A retryable failure executes the same run again, up to the retry option of the task.
+	for try := uint32(2); try <= 3; try++ {
+		promises[0].Finish(mock.NewRunResult(errors.New("retryable failure"), true), nil)
+		promises = pollForRunTry(t, e, task.ID, try)
+		if got := promises[0].Run(); got.RunID != qr.RunID || got.Now != qr.Now {
+			t.Fatalf("expected retry of run %v, got %v", qr, got)
+		}
+		pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunStarted.String())
+	}
+
+	promises[0].Finish(mock.NewRunResult(errors.New("retryable failure"), true), nil)
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	pollForRunStatus(t, rl, task.ID, 1, 0, backend.RunFail.String())
+
+	logs, err := rl.ListLogs(context.Background(), platform.LogFilter{Run: &qr.RunID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(logs[0]), "Retrying in"); got != 2 {
+		t.Fatalf("expected 2 retries in run log, got %d: %s", got, logs[0])
+	}
+
+	mfs := promtest.MustGather(t, reg)
+	m := promtest.MustFindMetric(t, mfs, "task_scheduler_runs_retried", map[string]string{"task_id": task.ID.String()})
+	if got := *m.Counter.Value; got != 2 {
+		t.Fatalf("expected 2 runs retried for task ID %s, got %v", task.ID.String(), got)
+	}
+
+	// A failure that is not retryable fails the run at once.
+	s.Tick(7)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	promises[0].Finish(mock.NewRunResult(errors.New("terminal failure"), false), nil)
+	pollForRunStatus(t, rl, task.ID, 2, 1, backend.RunFail.String())
+
+	// A run that fails to execute with a retryable error is retried too.
+	e.FailNextCallToExecute(backend.RetryableError(errors.New("executor unavailable")))
+	s.Tick(8)
+	promises = pollForRunTry(t, e, task.ID, 2)
+	promises[0].Finish(mock.NewRunResult(nil, false), nil)
+	pollForRunStatus(t, rl, task.ID, 3, 2, backend.RunSuccess.String())
+
+	// A run that fails to execute with any other error fails at once.
+	e.FailNextCallToExecute(errors.New("invalid script"))
+	s.Tick(9)
+	pollForRunStatus(t, rl, task.ID, 4, 3, backend.RunFail.String())
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScheduler_RetryDoesNotHoldRunner(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	// A single runner, so that the next run can only start if the retried run does not hold it.
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Hour), backend.WithMaxConcurrency(1))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+		Script: `option task = {name: "a task", every: 1s, retry: 3}
+from(bucket: "b") |> range(start: -1h)`,
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  2,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Tick(6)
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	promises[0].Finish(mock.NewRunResult(errors.New("retryable failure"), true), nil)
+	if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// The run waiting for its retry leaves its runner free for the next run.
+	s.Tick(7)
+	promises, err = e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := promises[0].Run().Now; got != 7 {
+		t.Fatalf("expected the run scheduled for 7 to execute, got the run scheduled for %d", got)
+	}
+}
+
+func TestScheduler_RetryRunWithoutTries(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRetryBackoff(time.Hour))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+		Script: `option task = {name: "a task", every: 1s, retry: 3}
+from(bucket: "b") |> range(start: -1h)`,
+	}
+	// A run left running before tries were counted has try 0.
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:   1,
+		EffectiveCron:    "@every 1s",
+		LatestCompleted:  5,
+		CurrentlyRunning: []*backend.StoreTaskMetaRun{{Now: 6, RunID: 10}},
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	promises, err := e.PollForNumberRunning(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runID := promises[0].Run().RunID
+	promises[0].Finish(mock.NewRunResult(errors.New("retryable failure"), true), nil)
+
+	// The retry still waits for the backoff, instead of the shift of its try wrapping around.
+	want := fmt.Sprintf("Retrying in %s (try 1 of 3)", time.Hour)
+	for i := 0; ; i++ {
+		logs, err := rl.ListLogs(context.Background(), platform.LogFilter{Run: &runID})
+		if err == nil && len(logs) > 0 && strings.Contains(string(logs[0]), want) {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("expected %q in run log, got %v (%v)", want, logs, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := e.RunningFor(task.ID); len(got) != 0 {
+		t.Fatalf("expected the run to wait for its retry, got %d running", len(got))
+	}
+}
+
+type runNotification struct {
+	rlb    backend.RunLogBase
+	status backend.RunStatus
+	err    error
+}
+
+type chanRunNotifier chan runNotification
+
+func (n chanRunNotifier) RunFinished(rlb backend.RunLogBase, status backend.RunStatus, runErr error) {
+	n <- runNotification{rlb: rlb, status: status, err: runErr}
+}
+
+func TestScheduler_RunNotifier(t *testing.T) {
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	rl := backend.NewInMemRunReaderWriter()
+	n := make(chanRunNotifier, 2)
+	s := backend.NewScheduler(d, e, rl, 5, backend.WithLogger(zaptest.NewLogger(t)), backend.WithRunNotifier(n))
+	s.Start(context.Background())
+	defer s.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "@every 1s",
+		LatestCompleted: 5,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := s.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	runErr := errors.New("failure")
+	for i, exp := range []runNotification{
+		{status: backend.RunFail, err: runErr},
+		{status: backend.RunSuccess},
+	} {
+		s.Tick(int64(6 + i))
+		promises, err := e.PollForNumberRunning(task.ID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		qr := promises[0].Run()
+		promises[0].Finish(mock.NewRunResult(exp.err, false), nil)
+		if _, err := e.PollForNumberRunning(task.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case got := <-n:
+			if got.rlb.Task.ID != task.ID || got.rlb.RunID != qr.RunID || got.rlb.RunScheduledFor != qr.Now {
+				t.Fatalf("expected notification of run %v, got %v", qr, got.rlb)
+			}
+			if got.status != exp.status || got.err != exp.err {
+				t.Fatalf("expected run to finish with status %v and error %v, got %v and %v", exp.status, exp.err, got.status, got.err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected notification of run %v", qr)
+		}
+	}
+}
+
+// pollForRunTry blocks for a small amount of time waiting for a single active run of the given task ID,
+// executing for the given try.
+func pollForRunTry(t *testing.T, e *mock.Executor, taskID platform.ID, try uint32) []*mock.RunPromise {
+	t.Helper()
+
+	const maxAttempts = 50
+	for i := 0; i < maxAttempts; i++ {
+		if i != 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if promises := e.RunningFor(taskID); len(promises) == 1 && promises[0].Run().Try == try {
+			return promises
+		}
+	}
+
+	t.Fatalf("did not see a run of task ID %s executing for try %d in time", taskID.String(), try)
+	return nil
+}
@@ -33,6 +33,21 @@ var (
 
 	// ErrRunNotFinished is returned when a retry is invalid due to the run not being finished yet.
 	ErrRunNotFinished = errors.New("run is still in progress")
+
+	// ErrManualRunNotFound is returned when canceling a manual run request that is not queued.
+	ErrManualRunNotFound = errors.New("manual run not found")
+
+	// ErrTaskVersionNotFound is returned when searching for a version of a task's script that doesn't exist.
+	ErrTaskVersionNotFound = errors.New("task version not found")
+
+	// ErrNotificationRuleNotFound is returned when deleting a notification rule that doesn't exist.
+	ErrNotificationRuleNotFound = errors.New("notification rule not found")
+
+	// ErrTaskLeaseHeld is returned when claiming the lease of a task that another owner holds and that has not expired.
+	ErrTaskLeaseHeld = errors.New("task lease held by another owner")
+
+	// ErrTaskLeaseNotHeld is returned when creating a run of a task as an owner that does not hold an unexpired lease on it.
+	ErrTaskLeaseNotHeld = errors.New("task lease not held by owner")
 )
 
 type TaskStatus string
@@ -155,6 +170,10 @@ type CreateTaskRequest struct {
 	// The initial task status.
 	// If empty, will be treated as DefaultTaskStatus.
 	Status TaskStatus
+
+	// ID of the user creating the task, recorded as the author of the first version of its script.
+	// May be invalid if the author is unknown.
+	Author platform.ID
 }
 
 // UpdateTaskRequest encapsulates requested changes to a task.
@@ -169,6 +188,10 @@ type UpdateTaskRequest struct {
 	// The new desired task status.
 	// If empty, do not modify the existing status.
 	Status TaskStatus
+
+	// ID of the user updating the task, recorded as the author of the new version of its script.
+	// May be invalid if the author is unknown.
+	Author platform.ID
 }
 
 // UpdateTaskResult describes the result of modifying a single task.
@@ -214,16 +237,58 @@ type Store interface {
 
 	// CreateNextRun creates the earliest needed run scheduled no later than the given Unix timestamp now.
 	// Internally, the Store should rely on the underlying task's StoreTaskMeta to create the next run.
-	CreateNextRun(ctx context.Context, taskID platform.ID, now int64) (RunCreation, error)
+	// If owner is valid, the run is only created if owner holds the lease of the task and it has not expired by now;
+	// otherwise ErrTaskLeaseNotHeld is returned. Runs are created regardless of leases if owner is invalid.
+	CreateNextRun(ctx context.Context, taskID, owner platform.ID, now int64) (RunCreation, error)
 
 	// FinishRun removes runID from the list of running tasks and if its `now` is later then last completed update it.
 	FinishRun(ctx context.Context, taskID, runID platform.ID) error
 
+	// IncrementRunTry increments the try of the in-progress run runID, and returns the new try.
+	IncrementRunTry(ctx context.Context, taskID, runID platform.ID) (uint32, error)
+
+	// FindTaskVersions returns the versions of the task's script, oldest first.
+	FindTaskVersions(ctx context.Context, taskID platform.ID) ([]StoreTaskVersion, error)
+
+	// FindTaskVersion returns the given version of the task's script.
+	// If the task has no such version, ErrTaskVersionNotFound is returned.
+	FindTaskVersion(ctx context.Context, taskID platform.ID, version uint32) (*StoreTaskVersion, error)
+
+	// CreateNotificationRule adds the notification rule to its task, and sets its ID.
+	CreateNotificationRule(ctx context.Context, r *platform.TaskNotificationRule) error
+
+	// FindNotificationRules returns the notification rules of the task, in the order they were created.
+	FindNotificationRules(ctx context.Context, taskID platform.ID) ([]*platform.TaskNotificationRule, error)
+
+	// DeleteNotificationRule removes the notification rule from the task.
+	// If the task has no such rule, ErrNotificationRuleNotFound is returned.
+	DeleteNotificationRule(ctx context.Context, taskID, ruleID platform.ID) error
+
 	// ManuallyRunTimeRange enqueues a request to run the task with the given ID for all schedules no earlier than start and no later than end (Unix timestamps).
 	// requestedAt is the Unix timestamp when the request was initiated.
 	// ManuallyRunTimeRange must delegate to an underlying StoreTaskMeta's ManuallyRunTimeRange method.
 	ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) error
 
+	// CancelManualRun removes the queued request to run the task with the given ID for the schedules from start to end (Unix timestamps),
+	// so that no more of its runs are created. Runs of the request already created are not affected.
+	// CancelManualRun must delegate to an underlying StoreTaskMeta's CancelManualRun method.
+	CancelManualRun(ctx context.Context, taskID platform.ID, start, end int64) error
+
+	// ClaimTaskLease acquires or renews the lease of owner on the task, until the Unix timestamp expiresAt.
+	// The lease is acquired if the task is not leased, if owner already holds its lease,
+	// or if its lease expired before the Unix timestamp now. Otherwise ErrTaskLeaseHeld is returned.
+	// Schedulers sharing a store only run the tasks they hold the lease of.
+	ClaimTaskLease(ctx context.Context, taskID, owner platform.ID, now, expiresAt int64) error
+
+	// ClaimTaskLeases claims the lease of owner on each of the tasks, as ClaimTaskLease does, in a single transaction.
+	// It returns the IDs of the tasks whose lease owner holds until expiresAt;
+	// the tasks leased by other owners, and the tasks that do not exist, are left out.
+	ClaimTaskLeases(ctx context.Context, taskIDs []platform.ID, owner platform.ID, now, expiresAt int64) ([]platform.ID, error)
+
+	// ReleaseTaskLease releases the lease of owner on the task, so that other owners can claim it at once.
+	// Releasing a lease that owner does not hold does nothing.
+	ReleaseTaskLease(ctx context.Context, taskID, owner platform.ID) error
+
 	// DeleteOrg deletes the org.
 	DeleteOrg(ctx context.Context, orgID platform.ID) error
 
@@ -328,6 +393,27 @@ type StoreTask struct {
 
 	// The script content of the task.
 	Script string
+
+	// The version of the script content, incremented each time the script changes.
+	// Zero if the store has no record of the task's versions.
+	Version uint32
+}
+
+// StoreTaskVersion is an immutable record of a version of a task's script, and so of the options it sets.
+type StoreTaskVersion struct {
+	TaskID platform.ID
+
+	// The version number, starting at 1.
+	Version uint32
+
+	// The script content of the task at this version.
+	Script string
+
+	// ID of the user that created the version. May be invalid if the author is unknown.
+	Author platform.ID
+
+	// Unix timestamp of when the version was created.
+	CreatedAt int64
 }
 
 // StoreTaskWithMeta is a single struct with a StoreTask and a StoreTaskMeta.
@@ -32,7 +32,12 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 			"DeleteTask",
 			"CreateNextRun",
 			"FinishRun",
+			"IncrementRunTry",
 			"ManuallyRunTimeRange",
+			"CancelManualRun",
+			"TaskVersions",
+			"NotificationRules",
+			"TaskLeases",
 		}
 	}
 	availableFuncs := map[string]TestFunc{
@@ -45,7 +50,12 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 		"DeleteTask":           testStoreDelete,
 		"CreateNextRun":        testStoreCreateNextRun,
 		"FinishRun":            testStoreFinishRun,
+		"IncrementRunTry":      testStoreIncrementRunTry,
 		"ManuallyRunTimeRange": testStoreManuallyRunTimeRange,
+		"CancelManualRun":      testStoreCancelManualRun,
+		"TaskVersions":         testStoreTaskVersions,
+		"NotificationRules":    testStoreNotificationRules,
+		"TaskLeases":           testStoreTaskLeases,
 		"DeleteOrg":            testStoreDeleteOrg,
 		"DeleteUser":           testStoreDeleteUser,
 	}
@@ -552,12 +562,12 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatalf("expected nil meta when finding nonexistent ID, got %#v", meta)
 		}
 
-		rc, err := s.CreateNextRun(context.Background(), id, 6065)
+		rc, err := s.CreateNextRun(context.Background(), id, platform.InvalidID(), 6065)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		_, err = s.CreateNextRun(context.Background(), id, 6125)
+		_, err = s.CreateNextRun(context.Background(), id, platform.InvalidID(), 6125)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -748,18 +758,18 @@ from(bucket:"test") |> range(start:-1h)`
 
 		badID := uint64(taskID)
 		badID++
-		if _, err := s.CreateNextRun(context.Background(), platform.ID(badID), 999); err == nil {
+		if _, err := s.CreateNextRun(context.Background(), platform.ID(badID), platform.InvalidID(), 999); err == nil {
 			t.Fatal("expected error for CreateNextRun with bad ID, got none")
 		}
 
-		_, err = s.CreateNextRun(context.Background(), taskID, 64)
+		_, err = s.CreateNextRun(context.Background(), taskID, platform.InvalidID(), 64)
 		if e, ok := err.(backend.RunNotYetDueError); !ok {
 			t.Fatalf("expected RunNotYetDueError, got %v (%T)", err, err)
 		} else if e.DueAt != 65 {
 			t.Fatalf("expected run due at 65, got %d", e.DueAt)
 		}
 
-		rc, err := s.CreateNextRun(context.Background(), taskID, 65)
+		rc, err := s.CreateNextRun(context.Background(), taskID, platform.InvalidID(), 65)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -773,7 +783,7 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatalf("unexpected next due time: %d", rc.NextDue)
 		}
 
-		rc, err = s.CreateNextRun(context.Background(), taskID, 125)
+		rc, err = s.CreateNextRun(context.Background(), taskID, platform.InvalidID(), 125)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -813,7 +823,7 @@ from(bucket:"test") |> range(start:-1h)`
 			t.Fatal(err)
 		}
 
-		rc, err := s.CreateNextRun(context.Background(), taskID, 3005)
+		rc, err := s.CreateNextRun(context.Background(), taskID, platform.InvalidID(), 3005)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -829,7 +839,7 @@ from(bucket:"test") |> range(start:-1h)`
 		}
 
 		// Queue: 0
-		rc, err = s.CreateNextRun(context.Background(), taskID, 3005)
+		rc, err = s.CreateNextRun(context.Background(), taskID, platform.InvalidID(), 3005)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -845,7 +855,7 @@ from(bucket:"test") |> range(start:-1h)`
 		}
 
 		// Queue: 60
-		rc, err = s.CreateNextRun(context.Background(), taskID, 3005)
+		rc, err = s.CreateNextRun(context.Background(), taskID, platform.InvalidID(), 3005)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -861,7 +871,7 @@ from(bucket:"test") |> range(start:-1h)`
 		}
 
 		// Queue: 180
-		rc, err = s.CreateNextRun(context.Background(), taskID, 3005)
+		rc, err = s.CreateNextRun(context.Background(), taskID, platform.InvalidID(), 3005)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -893,7 +903,7 @@ from(bucket:"test") |> range(start:-1h)`
 		t.Fatal(err)
 	}
 
-	rc, err := s.CreateNextRun(context.Background(), task, 60)
+	rc, err := s.CreateNextRun(context.Background(), task, platform.InvalidID(), 60)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -907,6 +917,54 @@ from(bucket:"test") |> range(start:-1h)`
 	}
 }
 
+func testStoreIncrementRunTry(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+		retry: 3,
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	task, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := s.CreateNextRun(context.Background(), task, platform.InvalidID(), 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Try != 1 {
+		t.Fatalf("expected created run to be on try 1, got %d", rc.Created.Try)
+	}
+
+	try, err := s.IncrementRunTry(context.Background(), task, rc.Created.RunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if try != 2 {
+		t.Fatalf("expected run to be on try 2, got %d", try)
+	}
+
+	meta, err := s.FindTaskMetaByID(context.Background(), task)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := meta.CurrentlyRunning[0].Try; got != 2 {
+		t.Fatalf("expected try 2 of run to be stored, got %d", got)
+	}
+
+	if err := s.FinishRun(context.Background(), task, rc.Created.RunID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.IncrementRunTry(context.Background(), task, rc.Created.RunID); err == nil {
+		t.Fatal("expected failure when retrying run that doesnt exist")
+	}
+}
+
 func testStoreManuallyRunTimeRange(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	const script = `option task = {
 		name: "a task",
@@ -934,7 +992,7 @@ from(bucket:"test") |> range(start:-1h)`
 		t.Fatalf("expected 1 manual run to be created, got %d", len(meta.ManualRuns))
 	}
 
-	rc, err := s.CreateNextRun(context.Background(), taskID, 9999)
+	rc, err := s.CreateNextRun(context.Background(), taskID, platform.InvalidID(), 9999)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -944,6 +1002,295 @@ from(bucket:"test") |> range(start:-1h)`
 	}
 }
 
+func testStoreCancelManualRun(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	taskID, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ManuallyRunTimeRange(context.Background(), taskID, 60, 600, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ManuallyRunTimeRange(context.Background(), taskID, 1200, 1200, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.CancelManualRun(context.Background(), taskID, 60, 600); err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := s.FindTaskMetaByID(context.Background(), taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.ManualRuns) != 1 || meta.ManualRuns[0].Start != 1200 {
+		t.Fatalf("expected only the manual run for 1200 to remain queued, got %v", meta.ManualRuns)
+	}
+
+	if err := s.CancelManualRun(context.Background(), taskID, 60, 600); err != backend.ErrManualRunNotFound {
+		t.Fatalf("expected %v when canceling a manual run that is not queued, got %v", backend.ErrManualRunNotFound, err)
+	}
+}
+
+func testStoreTaskVersions(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const scriptFmt = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test%d") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	taskID, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: fmt.Sprintf(scriptFmt, 1), Author: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: taskID, Script: fmt.Sprintf(scriptFmt, 2), Author: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NewTask.Version != 2 {
+		t.Fatalf("expected updated task to be at version 2, got %d", res.NewTask.Version)
+	}
+
+	// Neither a status update nor an unchanged script creates a version.
+	if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: taskID, Status: backend.TaskInactive}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateTask(context.Background(), backend.UpdateTaskRequest{ID: taskID, Script: fmt.Sprintf(scriptFmt, 2), Author: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := s.FindTaskByID(context.Background(), taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Version != 2 {
+		t.Fatalf("expected task to be at version 2, got %d", task.Version)
+	}
+
+	versions, err := s.FindTaskVersions(context.Background(), taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d: %v", len(versions), versions)
+	}
+	for i, v := range versions {
+		if v.TaskID != taskID || v.Version != uint32(i+1) {
+			t.Fatalf("unexpected version at index %d: got task %s version %d", i, v.TaskID, v.Version)
+		}
+		if exp := fmt.Sprintf(scriptFmt, i+1); v.Script != exp {
+			t.Fatalf("unexpected script for version %d: got %q, expected %q", v.Version, v.Script, exp)
+		}
+		if exp := platform.ID(i + 3); v.Author != exp {
+			t.Fatalf("unexpected author for version %d: got %s, expected %s", v.Version, v.Author, exp)
+		}
+		if v.CreatedAt == 0 {
+			t.Fatalf("expected version %d to record its creation time", v.Version)
+		}
+	}
+
+	v, err := s.FindTaskVersion(context.Background(), taskID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Script != fmt.Sprintf(scriptFmt, 1) {
+		t.Fatalf("unexpected script for version 1: %q", v.Script)
+	}
+	if _, err := s.FindTaskVersion(context.Background(), taskID, 3); err != backend.ErrTaskVersionNotFound {
+		t.Fatalf("expected %v finding a missing version, got %v", backend.ErrTaskVersionNotFound, err)
+	}
+
+	// Versions are deleted along with their task.
+	if _, err := s.DeleteTask(context.Background(), taskID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.FindTaskVersions(context.Background(), taskID); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected %v finding versions of a deleted task, got %v", backend.ErrTaskNotFound, err)
+	}
+}
+
+func testStoreNotificationRules(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	taskID, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := []*platform.TaskNotificationRule{
+		{TaskID: taskID, On: platform.TaskNotifyOnFailure, URL: "http://example.com/failure", SecretKey: "hook"},
+		{TaskID: taskID, On: platform.TaskNotifyOnConsecutiveFailures, Threshold: 3, URL: "http://example.com/failures"},
+	}
+	for _, r := range rules {
+		if err := s.CreateNotificationRule(context.Background(), r); err != nil {
+			t.Fatal(err)
+		}
+		if !r.ID.Valid() {
+			t.Fatalf("expected created rule to have a valid ID, got %s", r.ID)
+		}
+	}
+	if rules[0].ID == rules[1].ID {
+		t.Fatalf("expected rules to have distinct IDs, got %s twice", rules[0].ID)
+	}
+
+	found, err := s.FindNotificationRules(context.Background(), taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != len(rules) {
+		t.Fatalf("expected %d rules, got %d: %v", len(rules), len(found), found)
+	}
+	for i := range rules {
+		if *found[i] != *rules[i] {
+			t.Fatalf("unexpected rule at index %d: got %#v, expected %#v", i, *found[i], *rules[i])
+		}
+	}
+
+	if err := s.DeleteNotificationRule(context.Background(), taskID, rules[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteNotificationRule(context.Background(), taskID, rules[0].ID); err != backend.ErrNotificationRuleNotFound {
+		t.Fatalf("expected %v deleting a missing rule, got %v", backend.ErrNotificationRuleNotFound, err)
+	}
+	found, err = s.FindNotificationRules(context.Background(), taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ID != rules[1].ID {
+		t.Fatalf("expected only rule %s to remain, got %v", rules[1].ID, found)
+	}
+
+	// Rules are deleted along with their task.
+	if _, err := s.DeleteTask(context.Background(), taskID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.FindNotificationRules(context.Background(), taskID); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected %v finding rules of a deleted task, got %v", backend.ErrTaskNotFound, err)
+	}
+	if err := s.CreateNotificationRule(context.Background(), &platform.TaskNotificationRule{TaskID: taskID, On: platform.TaskNotifyOnFailure, URL: "http://example.com"}); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected %v creating a rule of a deleted task, got %v", backend.ErrTaskNotFound, err)
+	}
+}
+
+func testStoreTaskLeases(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	taskID, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const owner1, owner2 = platform.ID(10), platform.ID(20)
+
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner1, 100, 130); err != nil {
+		t.Fatal(err)
+	}
+	// The owner of the lease renews it; other owners cannot claim it until it expires.
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner1, 110, 140); err != nil {
+		t.Fatalf("expected owner to renew its lease, got %v", err)
+	}
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner2, 135, 165); err != backend.ErrTaskLeaseHeld {
+		t.Fatalf("expected %v claiming a lease held by another owner, got %v", backend.ErrTaskLeaseHeld, err)
+	}
+
+	// An expired lease is taken over.
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner2, 140, 170); err != nil {
+		t.Fatalf("expected expired lease to be taken over, got %v", err)
+	}
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner1, 145, 175); err != backend.ErrTaskLeaseHeld {
+		t.Fatalf("expected %v renewing a lease taken over, got %v", backend.ErrTaskLeaseHeld, err)
+	}
+
+	// Only the owner of the lease releases it.
+	if err := s.ReleaseTaskLease(context.Background(), taskID, owner1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner1, 150, 180); err != backend.ErrTaskLeaseHeld {
+		t.Fatalf("expected %v after another owner released, got %v", backend.ErrTaskLeaseHeld, err)
+	}
+	if err := s.ReleaseTaskLease(context.Background(), taskID, owner2); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner1, 150, 180); err != nil {
+		t.Fatalf("expected released lease to be claimed, got %v", err)
+	}
+
+	// Runs are only created as the owner of an unexpired lease.
+	if _, err := s.CreateNextRun(context.Background(), taskID, owner2, 160); err != backend.ErrTaskLeaseNotHeld {
+		t.Fatalf("expected %v creating a run as another owner, got %v", backend.ErrTaskLeaseNotHeld, err)
+	}
+	if _, err := s.CreateNextRun(context.Background(), taskID, owner1, 180); err != backend.ErrTaskLeaseNotHeld {
+		t.Fatalf("expected %v creating a run once the lease expired, got %v", backend.ErrTaskLeaseNotHeld, err)
+	}
+	rc, err := s.CreateNextRun(context.Background(), taskID, owner1, 160)
+	if err != nil {
+		t.Fatalf("expected owner of the lease to create a run, got %v", err)
+	}
+	if err := s.FinishRun(context.Background(), taskID, rc.Created.RunID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Leases of several tasks are claimed at once, leaving out the tasks leased by other owners and unknown tasks.
+	otherID, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, User: 2, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ClaimTaskLease(context.Background(), otherID, owner2, 150, 180); err != nil {
+		t.Fatal(err)
+	}
+	claimed, err := s.ClaimTaskLeases(context.Background(), []platform.ID{taskID, otherID, platform.ID(9999)}, owner1, 170, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 || claimed[0] != taskID {
+		t.Fatalf("expected to claim the lease of %v only, got %v", taskID, claimed)
+	}
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner2, 190, 220); err != backend.ErrTaskLeaseHeld {
+		t.Fatalf("expected %v claiming a lease renewed by another owner, got %v", backend.ErrTaskLeaseHeld, err)
+	}
+	claimed, err = s.ClaimTaskLeases(context.Background(), []platform.ID{taskID, otherID}, owner1, 190, 220)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 2 {
+		t.Fatalf("expected to claim the expired lease of %v too, got %v", otherID, claimed)
+	}
+
+	// Leases are deleted along with their task.
+	if _, err := s.DeleteTask(context.Background(), taskID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ClaimTaskLease(context.Background(), taskID, owner1, 150, 180); err != backend.ErrTaskNotFound {
+		t.Fatalf("expected %v claiming the lease of a deleted task, got %v", backend.ErrTaskNotFound, err)
+	}
+}
+
 func testStoreDeleteUser(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	s := create(t)
 	defer destroy(t, s)
@@ -134,6 +134,17 @@ func (s *Scheduler) TaskFor(id platform.ID) *Task {
 	return s.claims[id.String()]
 }
 
+// TaskMetaFor returns the meta the task with the given ID was last claimed or updated with, or nil if it is not claimed.
+func (s *Scheduler) TaskMetaFor(id platform.ID) *backend.StoreTaskMeta {
+	s.Lock()
+	defer s.Unlock()
+	meta, ok := s.meta[id.String()]
+	if !ok {
+		return nil
+	}
+	return &meta
+}
+
 func (s *Scheduler) TaskCreateChan() <-chan *Task {
 	s.createChan = make(chan *Task, 10)
 	return s.createChan
@@ -195,7 +206,7 @@ func (d *DesiredState) SetTaskMeta(taskID platform.ID, meta backend.StoreTaskMet
 
 // CreateNextRun creates the next run for the given task.
 // Refer to the documentation for SetTaskPeriod to understand how the times are determined.
-func (d *DesiredState) CreateNextRun(_ context.Context, taskID platform.ID, now int64) (backend.RunCreation, error) {
+func (d *DesiredState) CreateNextRun(_ context.Context, taskID, _ platform.ID, now int64) (backend.RunCreation, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	if !taskID.Valid() {
@@ -243,6 +254,20 @@ func (d *DesiredState) FinishRun(_ context.Context, taskID, runID platform.ID) e
 	return nil
 }
 
+func (d *DesiredState) IncrementRunTry(_ context.Context, taskID, runID platform.ID) (uint32, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tid := taskID.String()
+	m := d.meta[tid]
+	try, ok := m.IncrementRunTry(runID)
+	if !ok {
+		return 0, fmt.Errorf("unknown run ID %s", runID.String())
+	}
+	d.meta[tid] = m
+	return try, nil
+}
+
 func (d *DesiredState) CreatedFor(taskID platform.ID) []backend.QueuedRun {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -280,6 +305,9 @@ type Executor struct {
 	mu         sync.Mutex
 	hangingFor time.Duration
 
+	// Error returned by the next call to Execute, if set.
+	nextExecuteErr error
+
 	// Map of stringified, concatenated task and run ID, to runs that have begun execution but have not finished.
 	running map[string]*RunPromise
 
@@ -297,6 +325,14 @@ func NewExecutor() *Executor {
 }
 
 func (e *Executor) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
+	e.mu.Lock()
+	if err := e.nextExecuteErr; err != nil {
+		e.nextExecuteErr = nil
+		e.mu.Unlock()
+		return nil, err
+	}
+	e.mu.Unlock()
+
 	rp := NewRunPromise(run)
 	rp.WithHanging(ctx, e.hangingFor)
 	id := run.TaskID.String() + run.RunID.String()
@@ -306,7 +342,10 @@ func (e *Executor) Execute(ctx context.Context, run backend.QueuedRun) (backend.
 	go func() {
 		res, _ := rp.Wait()
 		e.mu.Lock()
-		delete(e.running, id)
+		// A retried run executes again with the same ID, possibly before this runs.
+		if e.running[id] == rp {
+			delete(e.running, id)
+		}
 		e.finished[id] = res
 		e.mu.Unlock()
 	}()
@@ -315,6 +354,13 @@ func (e *Executor) Execute(ctx context.Context, run backend.QueuedRun) (backend.
 
 func (e *Executor) WithLogger(l *zap.Logger) {}
 
+// FailNextCallToExecute causes the next call to e.Execute to return err.
+func (e *Executor) FailNextCallToExecute(err error) {
+	e.mu.Lock()
+	e.nextExecuteErr = err
+	e.mu.Unlock()
+}
+
 func (e *Executor) WithHanging(dt time.Duration) {
 	e.hangingFor = dt
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/semantic"
+	qoptions "github.com/influxdata/platform/query/options"
 	cron "gopkg.in/robfig/cron.v2"
 )
 
@@ -62,6 +63,11 @@ func FromScript(script string) (Options, error) {
 	opt := Options{Retry: 1, Concurrency: 1}
 
 	inter := flux.NewInterpreter()
+	// Secrets are only loaded when the task runs, so any secret is an
+	// empty string while the options are extracted.
+	inter.SetOption(qoptions.SecretsOption, qoptions.SecretsObject(func(string) (string, error) {
+		return "", nil
+	}))
 	if err := flux.Eval(inter, script); err != nil {
 		return opt, err
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/influxdata/platform"
+	pctx "github.com/influxdata/platform/context"
 	"github.com/influxdata/platform/task/backend"
 	"github.com/influxdata/platform/task/options"
 )
@@ -80,7 +81,7 @@ func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
 
 	// TODO(mr): decide whether we allow user to configure scheduleAfter. https://github.com/influxdata/platform/issues/595
 	scheduleAfter := time.Now().Unix()
-	req := backend.CreateTaskRequest{Org: t.Organization, User: t.Owner.ID, Script: t.Flux, ScheduleAfter: scheduleAfter}
+	req := backend.CreateTaskRequest{Org: t.Organization, User: t.Owner.ID, Script: t.Flux, ScheduleAfter: scheduleAfter, Author: authorID(ctx)}
 	id, err := p.s.CreateTask(ctx, req)
 	if err != nil {
 		return err
@@ -88,6 +89,7 @@ func (p pAdapter) CreateTask(ctx context.Context, t *platform.Task) error {
 	t.ID = id
 	t.Every = opts.Every.String()
 	t.Cron = opts.Cron
+	t.Version = 1
 
 	return nil
 }
@@ -97,7 +99,7 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 		return nil, errors.New("cannot update task without content")
 	}
 
-	req := backend.UpdateTaskRequest{ID: id}
+	req := backend.UpdateTaskRequest{ID: id, Author: authorID(ctx)}
 	if upd.Flux != nil {
 		req.Script = *upd.Flux
 	}
@@ -115,13 +117,14 @@ func (p pAdapter) UpdateTask(ctx context.Context, id platform.ID, upd platform.T
 	}
 
 	task := &platform.Task{
-		ID:     id,
-		Name:   opts.Name,
-		Status: res.NewMeta.Status,
-		Owner:  platform.User{},
-		Flux:   res.NewTask.Script,
-		Every:  opts.Every.String(),
-		Cron:   opts.Cron,
+		ID:      id,
+		Name:    opts.Name,
+		Status:  res.NewMeta.Status,
+		Owner:   platform.User{},
+		Flux:    res.NewTask.Script,
+		Every:   opts.Every.String(),
+		Cron:    opts.Cron,
+		Version: int(res.NewTask.Version),
 	}
 
 	t, err := p.s.FindTaskByID(ctx, id)
@@ -189,6 +192,100 @@ func (p pAdapter) CancelRun(ctx context.Context, taskID, runID platform.ID) erro
 	return p.rc.CancelRun(ctx, taskID, runID)
 }
 
+func (p pAdapter) ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*platform.ManualRun, error) {
+	if start > end {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "start of manual run must not be after its end",
+		}
+	}
+	if err := p.s.ManuallyRunTimeRange(ctx, taskID, start, end, requestedAt); err != nil {
+		return nil, err
+	}
+
+	return toPlatformManualRun(taskID, &backend.StoreTaskMetaManualRun{
+		Start:           start,
+		End:             end,
+		LatestCompleted: start - 1,
+		RequestedAt:     requestedAt,
+	}), nil
+}
+
+func (p pAdapter) FindManualRuns(ctx context.Context, taskID platform.ID) ([]*platform.ManualRun, error) {
+	m, err := p.s.FindTaskMetaByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	mrs := make([]*platform.ManualRun, 0, len(m.ManualRuns))
+	for _, mr := range m.ManualRuns {
+		mrs = append(mrs, toPlatformManualRun(taskID, mr))
+	}
+	return mrs, nil
+}
+
+func (p pAdapter) CancelManualRun(ctx context.Context, taskID platform.ID, start, end int64) error {
+	return p.s.CancelManualRun(ctx, taskID, start, end)
+}
+
+func (p pAdapter) FindTaskVersions(ctx context.Context, taskID platform.ID) ([]*platform.TaskVersion, error) {
+	vs, err := p.s.FindTaskVersions(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	tvs := make([]*platform.TaskVersion, len(vs))
+	for i := range vs {
+		tvs[i] = toPlatformTaskVersion(vs[i])
+	}
+	return tvs, nil
+}
+
+func (p pAdapter) RollbackTask(ctx context.Context, taskID platform.ID, version int) (*platform.Task, error) {
+	if version <= 0 {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "task version must be positive",
+		}
+	}
+
+	v, err := p.s.FindTaskVersion(ctx, taskID, uint32(version))
+	if err != nil {
+		return nil, err
+	}
+
+	// The script of the version is recorded as a new version, so the history is kept intact.
+	return p.UpdateTask(ctx, taskID, platform.TaskUpdate{Flux: &v.Script})
+}
+
+func (p pAdapter) FindTaskNotificationRules(ctx context.Context, taskID platform.ID) ([]*platform.TaskNotificationRule, error) {
+	return p.s.FindNotificationRules(ctx, taskID)
+}
+
+func (p pAdapter) CreateTaskNotificationRule(ctx context.Context, r *platform.TaskNotificationRule) error {
+	if err := r.Valid(); err != nil {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	return p.s.CreateNotificationRule(ctx, r)
+}
+
+func (p pAdapter) DeleteTaskNotificationRule(ctx context.Context, taskID, ruleID platform.ID) error {
+	return p.s.DeleteNotificationRule(ctx, taskID, ruleID)
+}
+
+// authorID returns the ID of the user authorized in ctx, or an invalid ID if ctx has no authorizer.
+func authorID(ctx context.Context) platform.ID {
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		return platform.InvalidID()
+	}
+	return auth.GetUserID()
+}
+
 func toPlatformTask(t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Task, error) {
 	opts, err := options.FromScript(t.Script)
 	if err != nil {
@@ -203,8 +300,9 @@ func toPlatformTask(t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Ta
 			ID:   t.User,
 			Name: "", // TODO(mr): how to get owner name?
 		},
-		Flux: t.Script,
-		Cron: opts.Cron,
+		Flux:    t.Script,
+		Cron:    opts.Cron,
+		Version: int(t.Version),
 	}
 	if opts.Every != 0 {
 		pt.Every = opts.Every.String()
@@ -214,3 +312,34 @@ func toPlatformTask(t backend.StoreTask, m *backend.StoreTaskMeta) (*platform.Ta
 	}
 	return pt, nil
 }
+
+func toPlatformManualRun(taskID platform.ID, mr *backend.StoreTaskMetaManualRun) *platform.ManualRun {
+	pmr := &platform.ManualRun{
+		TaskID: taskID,
+		Start:  time.Unix(mr.Start, 0).UTC().Format(time.RFC3339),
+		End:    time.Unix(mr.End, 0).UTC().Format(time.RFC3339),
+	}
+	// LatestCompleted starts just before the range, until a run of the range completes.
+	if mr.LatestCompleted >= mr.Start {
+		pmr.LatestCompleted = time.Unix(mr.LatestCompleted, 0).UTC().Format(time.RFC3339)
+	}
+	if mr.RequestedAt != 0 {
+		pmr.RequestedAt = time.Unix(mr.RequestedAt, 0).UTC().Format(time.RFC3339)
+	}
+	return pmr
+}
+
+func toPlatformTaskVersion(v backend.StoreTaskVersion) *platform.TaskVersion {
+	tv := &platform.TaskVersion{
+		TaskID:  v.TaskID,
+		Version: int(v.Version),
+		Flux:    v.Script,
+	}
+	if v.Author.Valid() {
+		tv.Author = v.Author
+	}
+	if v.CreatedAt != 0 {
+		tv.CreatedAt = time.Unix(v.CreatedAt, 0).UTC().Format(time.RFC3339)
+	}
+	return tv
+}
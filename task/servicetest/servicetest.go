@@ -208,6 +208,73 @@ func testTaskCRUD(t *testing.T, sys *System) {
 		t.Fatalf("expected task status to be inactive, got %q", f.Status)
 	}
 
+	// Each update of the script should have been recorded as a version; the status update should not.
+	if f.Version != 3 {
+		t.Fatalf("expected task to be at version 3, got %d", f.Version)
+	}
+	versions, err := sys.ts.FindTaskVersions(sys.Ctx, origID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("expected 3 versions, got %d: %#v", len(versions), versions)
+	}
+	for i, exp := range []int{0, 99, 98} {
+		v := versions[i]
+		if v.TaskID != origID || v.Version != i+1 {
+			t.Fatalf("unexpected version %d: got task %s version %d", i+1, v.TaskID, v.Version)
+		}
+		if want := fmt.Sprintf(scriptFmt, exp); v.Flux != want {
+			t.Fatalf("wrong flux for version %d; want %q, got %q", i+1, want, v.Flux)
+		}
+		if v.CreatedAt == "" {
+			t.Fatalf("expected version %d to record its creation time", i+1)
+		}
+	}
+
+	// Roll back to the first version: its script should be recorded as a new version.
+	f, err = sys.ts.RollbackTask(sys.Ctx, origID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf(scriptFmt, 0); f.Flux != want {
+		t.Fatalf("wrong flux from rollback; want %q, got %q", want, f.Flux)
+	}
+	if f.Name != "task #0" {
+		t.Fatalf(`wrong name from rollback; want "task #0", got %q`, f.Name)
+	}
+	if f.Version != 4 {
+		t.Fatalf("expected rollback to create version 4, got %d", f.Version)
+	}
+	if _, err := sys.ts.RollbackTask(sys.Ctx, origID, 99); err != backend.ErrTaskVersionNotFound {
+		t.Fatalf("expected %v rolling back to a missing version, got %v", backend.ErrTaskVersionNotFound, err)
+	}
+
+	// Notification rules.
+	rule := &platform.TaskNotificationRule{TaskID: origID, On: platform.TaskNotifyOnConsecutiveFailures, Threshold: 2, URL: "http://example.com/hook"}
+	if err := sys.ts.CreateTaskNotificationRule(sys.Ctx, rule); err != nil {
+		t.Fatal(err)
+	}
+	if !rule.ID.Valid() {
+		t.Fatalf("expected created notification rule to have a valid ID, got %s", rule.ID)
+	}
+	if err := sys.ts.CreateTaskNotificationRule(sys.Ctx, &platform.TaskNotificationRule{TaskID: origID, On: platform.TaskNotifyOnConsecutiveFailures, URL: "http://example.com/hook"}); err == nil {
+		t.Fatal("expected error creating a notification rule without a threshold")
+	}
+	rules, err := sys.ts.FindTaskNotificationRules(sys.Ctx, origID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || *rules[0] != *rule {
+		t.Fatalf("expected notification rule %#v, got %v", *rule, rules)
+	}
+	if err := sys.ts.DeleteTaskNotificationRule(sys.Ctx, origID, rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := sys.ts.DeleteTaskNotificationRule(sys.Ctx, origID, rule.ID); err != backend.ErrNotificationRuleNotFound {
+		t.Fatalf("expected %v deleting a missing notification rule, got %v", backend.ErrNotificationRuleNotFound, err)
+	}
+
 	// Delete task.
 	if err := sys.ts.DeleteTask(sys.Ctx, origID); err != nil {
 		t.Fatal(err)
@@ -238,7 +305,7 @@ func testTaskRuns(t *testing.T, sys *System) {
 
 		requestedAtUnix := time.Now().Add(5 * time.Minute).UTC().Unix() // This should guarantee we can make two runs.
 
-		rc0, err := sys.S.CreateNextRun(sys.Ctx, task.ID, requestedAtUnix)
+		rc0, err := sys.S.CreateNextRun(sys.Ctx, task.ID, platform.InvalidID(), requestedAtUnix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -259,7 +326,7 @@ func testTaskRuns(t *testing.T, sys *System) {
 			t.Fatal(err)
 		}
 
-		rc1, err := sys.S.CreateNextRun(sys.Ctx, task.ID, requestedAtUnix)
+		rc1, err := sys.S.CreateNextRun(sys.Ctx, task.ID, platform.InvalidID(), requestedAtUnix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -304,6 +371,9 @@ func testTaskRuns(t *testing.T, sys *System) {
 		if runs[0].FinishedAt != "" {
 			t.Fatalf("expected empty FinishedAt, got %q", runs[0].FinishedAt)
 		}
+		if runs[0].TaskVersion != 1 {
+			t.Fatalf("expected run of task version 1, got %d", runs[0].TaskVersion)
+		}
 
 		if runs[1].ID != rc1.Created.RunID {
 			t.Fatalf("retrieved wrong run ID; want %s, got %s", rc1.Created.RunID, runs[1].ID)
@@ -356,7 +426,7 @@ func testTaskRuns(t *testing.T, sys *System) {
 
 		requestedAtUnix := time.Now().Add(5 * time.Minute).UTC().Unix() // This should guarantee we can make a run.
 
-		rc, err := sys.S.CreateNextRun(sys.Ctx, task.ID, requestedAtUnix)
+		rc, err := sys.S.CreateNextRun(sys.Ctx, task.ID, platform.InvalidID(), requestedAtUnix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -410,6 +480,81 @@ func testTaskRuns(t *testing.T, sys *System) {
 		}
 	})
 
+	t.Run("ManualRuns", func(t *testing.T) {
+		t.Parallel()
+
+		task := &platform.Task{Organization: orgID, Owner: platform.User{ID: userID}, Flux: fmt.Sprintf(scriptFmt, 0)}
+		if err := sys.ts.CreateTask(sys.Ctx, task); err != nil {
+			t.Fatal(err)
+		}
+
+		start := time.Date(2018, 11, 1, 0, 0, 0, 0, time.UTC)
+		end := start.Add(time.Hour)
+		mr, err := sys.ts.ManuallyRunTimeRange(sys.Ctx, task.ID, start.Unix(), end.Unix(), 3000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mr.TaskID != task.ID || mr.Start != start.Format(time.RFC3339) || mr.End != end.Format(time.RFC3339) || mr.LatestCompleted != "" {
+			t.Fatalf("unexpected manual run: %#v", mr)
+		}
+
+		// A single schedule is a range with the same start and end.
+		if _, err := sys.ts.ManuallyRunTimeRange(sys.Ctx, task.ID, end.Unix()+60, end.Unix()+60, 3000); err != nil {
+			t.Fatal(err)
+		}
+
+		// Requesting the same range again should be rejected while it is queued.
+		if exp, err := (backend.RetryAlreadyQueuedError{Start: start.Unix(), End: end.Unix()}), func() error {
+			_, err := sys.ts.ManuallyRunTimeRange(sys.Ctx, task.ID, start.Unix(), end.Unix(), 3000)
+			return err
+		}(); err != exp {
+			t.Fatalf("queuing the same range should have been rejected with %v; got %v", exp, err)
+		}
+
+		// Progress is reported once runs of the range complete.
+		// Creating a run before the next regular run is due creates it from the queue.
+		meta, err := sys.S.FindTaskMetaByID(sys.Ctx, task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rc, err := sys.S.CreateNextRun(sys.Ctx, task.ID, platform.InvalidID(), meta.LatestCompleted)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rc.Created.Now != start.Unix() {
+			t.Fatalf("expected run of the manual run to be scheduled for %d, got %d", start.Unix(), rc.Created.Now)
+		}
+		if err := sys.S.FinishRun(sys.Ctx, task.ID, rc.Created.RunID); err != nil {
+			t.Fatal(err)
+		}
+
+		mrs, err := sys.ts.FindManualRuns(sys.Ctx, task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mrs) != 2 {
+			t.Fatalf("expected 2 manual runs, got %d", len(mrs))
+		}
+		if exp := time.Unix(rc.Created.Now, 0).UTC().Format(time.RFC3339); mrs[0].LatestCompleted != exp {
+			t.Fatalf("expected manual run to have completed %s, got %q", exp, mrs[0].LatestCompleted)
+		}
+
+		if err := sys.ts.CancelManualRun(sys.Ctx, task.ID, start.Unix(), end.Unix()); err != nil {
+			t.Fatal(err)
+		}
+		mrs, err = sys.ts.FindManualRuns(sys.Ctx, task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mrs) != 1 || mrs[0].Start != mrs[0].End {
+			t.Fatalf("expected only the single schedule to remain queued, got %v", mrs)
+		}
+
+		if err := sys.ts.CancelManualRun(sys.Ctx, task.ID, start.Unix(), end.Unix()); err != backend.ErrManualRunNotFound {
+			t.Fatalf("expected %v canceling a manual run that is not queued, got %v", backend.ErrManualRunNotFound, err)
+		}
+	})
+
 	t.Run("FindLogs", func(t *testing.T) {
 		t.Parallel()
 
@@ -425,7 +570,7 @@ func testTaskRuns(t *testing.T, sys *System) {
 		requestedAtUnix := time.Now().Add(5 * time.Minute).UTC().Unix() // This should guarantee we can make a run.
 
 		// Create two runs.
-		rc1, err := sys.S.CreateNextRun(sys.Ctx, task.ID, requestedAtUnix)
+		rc1, err := sys.S.CreateNextRun(sys.Ctx, task.ID, platform.InvalidID(), requestedAtUnix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -439,7 +584,7 @@ func testTaskRuns(t *testing.T, sys *System) {
 			t.Fatal(err)
 		}
 
-		rc2, err := sys.S.CreateNextRun(sys.Ctx, task.ID, requestedAtUnix)
+		rc2, err := sys.S.CreateNextRun(sys.Ctx, task.ID, platform.InvalidID(), requestedAtUnix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -629,7 +774,7 @@ func testTaskConcurrency(t *testing.T, sys *System) {
 			// Create a run for the last task we found.
 			// The script should run every minute, so use max now.
 			tid := tasks[len(tasks)-1].ID
-			if _, err := sys.S.CreateNextRun(sys.Ctx, tid, math.MaxInt64); err != nil {
+			if _, err := sys.S.CreateNextRun(sys.Ctx, tid, platform.InvalidID(), math.MaxInt64); err != nil {
 				// This may have errored due to the task being deleted. Check if the task still exists.
 				if _, err2 := sys.S.FindTaskByID(sys.Ctx, tid); err2 == backend.ErrTaskNotFound {
 					// It was deleted. Just continue.
@@ -25,31 +25,219 @@ type taskServiceValidator struct {
 	platform.TaskService
 }
 
+// NewValidator wraps ts so that every call checks the permissions of the
+// authorizer on the context. A task may be accessed with a permission on the
+// task, on the tasks of its organization, or on every task.
 func NewValidator(ts platform.TaskService) platform.TaskService {
 	return &taskServiceValidator{
 		TaskService: ts,
 	}
 }
 
+func (ts *taskServiceValidator) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
+	t, err := ts.TaskService.FindTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePermission(ctx, platform.ReadAction, t.Organization, t.ID); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (ts *taskServiceValidator) FindTasks(ctx context.Context, filter platform.TaskFilter) ([]*platform.Task, int, error) {
+	if filter.Organization != nil {
+		if err := validatePermission(ctx, platform.ReadAction, *filter.Organization, platform.InvalidID()); err != nil {
+			return nil, 0, err
+		}
+		return ts.TaskService.FindTasks(ctx, filter)
+	}
+
+	tasks, _, err := ts.TaskService.FindTasks(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ts2 := tasks[:0]
+	for _, t := range tasks {
+		if validatePermission(ctx, platform.ReadAction, t.Organization, t.ID) == nil {
+			ts2 = append(ts2, t)
+		}
+	}
+
+	return ts2, len(ts2), nil
+}
+
 func (ts *taskServiceValidator) CreateTask(ctx context.Context, t *platform.Task) error {
-	if err := validatePermission(ctx, platform.Permission{Action: platform.CreateAction, Resource: platform.TaskResource(t.Organization)}); err != nil {
+	if err := validatePermission(ctx, platform.CreateAction, t.Organization, platform.InvalidID()); err != nil {
 		return err
 	}
 
 	return ts.TaskService.CreateTask(ctx, t)
 }
 
-// TODO(lh): add permission checking for the all the platform.TaskService functions.
+func (ts *taskServiceValidator) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+	if err := ts.validateTask(ctx, platform.WriteAction, id); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.UpdateTask(ctx, id, upd)
+}
+
+func (ts *taskServiceValidator) DeleteTask(ctx context.Context, id platform.ID) error {
+	if err := ts.validateTask(ctx, platform.DeleteAction, id); err != nil {
+		return err
+	}
+
+	return ts.TaskService.DeleteTask(ctx, id)
+}
+
+func (ts *taskServiceValidator) FindLogs(ctx context.Context, filter platform.LogFilter) ([]*platform.Log, int, error) {
+	if err := ts.validateFilter(ctx, platform.ReadAction, filter.Org, filter.Task); err != nil {
+		return nil, 0, err
+	}
+
+	return ts.TaskService.FindLogs(ctx, filter)
+}
+
+func (ts *taskServiceValidator) FindRuns(ctx context.Context, filter platform.RunFilter) ([]*platform.Run, int, error) {
+	if err := ts.validateFilter(ctx, platform.ReadAction, filter.Org, filter.Task); err != nil {
+		return nil, 0, err
+	}
+
+	return ts.TaskService.FindRuns(ctx, filter)
+}
+
+func (ts *taskServiceValidator) FindRunByID(ctx context.Context, taskID, runID platform.ID) (*platform.Run, error) {
+	if err := ts.validateTask(ctx, platform.ReadAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindRunByID(ctx, taskID, runID)
+}
+
+func (ts *taskServiceValidator) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
+	if err := ts.validateTask(ctx, platform.WriteAction, taskID); err != nil {
+		return err
+	}
+
+	return ts.TaskService.CancelRun(ctx, taskID, runID)
+}
+
+func (ts *taskServiceValidator) RetryRun(ctx context.Context, taskID, runID platform.ID, requestedAt int64) error {
+	if err := ts.validateTask(ctx, platform.WriteAction, taskID); err != nil {
+		return err
+	}
+
+	return ts.TaskService.RetryRun(ctx, taskID, runID, requestedAt)
+}
+
+func (ts *taskServiceValidator) ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*platform.ManualRun, error) {
+	if err := ts.validateTask(ctx, platform.WriteAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.ManuallyRunTimeRange(ctx, taskID, start, end, requestedAt)
+}
+
+func (ts *taskServiceValidator) FindManualRuns(ctx context.Context, taskID platform.ID) ([]*platform.ManualRun, error) {
+	if err := ts.validateTask(ctx, platform.ReadAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindManualRuns(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) CancelManualRun(ctx context.Context, taskID platform.ID, start, end int64) error {
+	if err := ts.validateTask(ctx, platform.WriteAction, taskID); err != nil {
+		return err
+	}
+
+	return ts.TaskService.CancelManualRun(ctx, taskID, start, end)
+}
+
+func (ts *taskServiceValidator) FindTaskVersions(ctx context.Context, taskID platform.ID) ([]*platform.TaskVersion, error) {
+	if err := ts.validateTask(ctx, platform.ReadAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindTaskVersions(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) RollbackTask(ctx context.Context, taskID platform.ID, version int) (*platform.Task, error) {
+	if err := ts.validateTask(ctx, platform.WriteAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.RollbackTask(ctx, taskID, version)
+}
+
+func (ts *taskServiceValidator) FindTaskNotificationRules(ctx context.Context, taskID platform.ID) ([]*platform.TaskNotificationRule, error) {
+	if err := ts.validateTask(ctx, platform.ReadAction, taskID); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.FindTaskNotificationRules(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) CreateTaskNotificationRule(ctx context.Context, r *platform.TaskNotificationRule) error {
+	if err := ts.validateTask(ctx, platform.WriteAction, r.TaskID); err != nil {
+		return err
+	}
+
+	return ts.TaskService.CreateTaskNotificationRule(ctx, r)
+}
+
+func (ts *taskServiceValidator) DeleteTaskNotificationRule(ctx context.Context, taskID, ruleID platform.ID) error {
+	if err := ts.validateTask(ctx, platform.WriteAction, taskID); err != nil {
+		return err
+	}
+
+	return ts.TaskService.DeleteTaskNotificationRule(ctx, taskID, ruleID)
+}
 
-func validatePermission(ctx context.Context, perm platform.Permission) error {
+// validateTask looks up the organization of the task id and checks the
+// authorizer on ctx is allowed action a on the task.
+func (ts *taskServiceValidator) validateTask(ctx context.Context, a platform.Action, id platform.ID) error {
+	t, err := ts.TaskService.FindTaskByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return validatePermission(ctx, a, t.Organization, t.ID)
+}
+
+// validateFilter checks the authorizer on ctx is allowed action a on the
+// tasks of the organization, or on the task, of a run or log filter.
+func (ts *taskServiceValidator) validateFilter(ctx context.Context, a platform.Action, orgID, taskID *platform.ID) error {
+	if orgID != nil {
+		return validatePermission(ctx, a, *orgID, platform.InvalidID())
+	}
+	if taskID != nil {
+		return ts.validateTask(ctx, a, *taskID)
+	}
+	return validatePermission(ctx, a, platform.InvalidID(), platform.InvalidID())
+}
+
+// validatePermission checks the authorizer on ctx is allowed action a on the
+// task id of the organization orgID. An invalid id stands for every task of
+// the organization, and an invalid orgID for every task.
+func validatePermission(ctx context.Context, a platform.Action, orgID, id platform.ID) error {
 	auth, err := platcontext.GetAuthorizer(ctx)
 	if err != nil {
 		return err
 	}
 
-	if !auth.Allowed(perm) {
-		return authError{error: ErrFailedPermission, perm: perm, auth: auth}
+	perm := platform.NewPermission(a, platform.TaskResourceType, orgID, id)
+	if auth.Allowed(perm) {
+		return nil
 	}
 
-	return nil
+	return &platform.Error{
+		Code: platform.EForbidden,
+		Msg:  ErrFailedPermission.Error(),
+		Err:  &authError{error: ErrFailedPermission, perm: perm, auth: auth},
+	}
 }
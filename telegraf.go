@@ -41,11 +41,12 @@ type TelegrafConfigStore interface {
 
 // TelegrafConfig stores telegraf config for one telegraf instance.
 type TelegrafConfig struct {
-	ID        ID
-	Name      string
-	Created   time.Time
-	LastMod   time.Time
-	LastModBy ID
+	ID             ID
+	OrganizationID ID
+	Name           string
+	Created        time.Time
+	LastMod        time.Time
+	LastModBy      ID
 
 	Agent   TelegrafAgentConfig
 	Plugins []TelegrafPlugin
@@ -117,11 +118,12 @@ func (tc TelegrafConfig) TOML() string {
 
 // telegrafConfigEncode is the helper struct for json encoding.
 type telegrafConfigEncode struct {
-	ID        ID        `json:"id"`
-	Name      string    `json:"name"`
-	Created   time.Time `json:"created"`
-	LastMod   time.Time `json:"lastModified"`
-	LastModBy ID        `json:"lastModifiedBy"`
+	ID             ID        `json:"id"`
+	OrganizationID ID        `json:"orgID,omitempty"`
+	Name           string    `json:"name"`
+	Created        time.Time `json:"created"`
+	LastMod        time.Time `json:"lastModified"`
+	LastModBy      ID        `json:"lastModifiedBy"`
 
 	Agent TelegrafAgentConfig `json:"agent"`
 
@@ -139,11 +141,12 @@ type telegrafPluginEncode struct {
 
 // telegrafConfigDecode is the helper struct for json decoding.
 type telegrafConfigDecode struct {
-	ID        ID        `json:"id"`
-	Name      string    `json:"name"`
-	Created   time.Time `json:"created"`
-	LastMod   time.Time `json:"lastModified"`
-	LastModBy ID        `json:"lastModifiedBy"`
+	ID             ID        `json:"id"`
+	OrganizationID ID        `json:"orgID,omitempty"`
+	Name           string    `json:"name"`
+	Created        time.Time `json:"created"`
+	LastMod        time.Time `json:"lastModified"`
+	LastModBy      ID        `json:"lastModifiedBy"`
 
 	Agent TelegrafAgentConfig `json:"agent"`
 
@@ -195,13 +198,14 @@ const (
 func (tc *TelegrafConfig) MarshalJSON() ([]byte, error) {
 	tce := new(telegrafConfigEncode)
 	*tce = telegrafConfigEncode{
-		ID:        tc.ID,
-		Name:      tc.Name,
-		Agent:     tc.Agent,
-		Created:   tc.Created,
-		LastMod:   tc.LastMod,
-		LastModBy: tc.LastModBy,
-		Plugins:   make([]telegrafPluginEncode, len(tc.Plugins)),
+		ID:             tc.ID,
+		OrganizationID: tc.OrganizationID,
+		Name:           tc.Name,
+		Agent:          tc.Agent,
+		Created:        tc.Created,
+		LastMod:        tc.LastMod,
+		LastModBy:      tc.LastModBy,
+		Plugins:        make([]telegrafPluginEncode, len(tc.Plugins)),
 	}
 	for k, p := range tc.Plugins {
 		tce.Plugins[k] = telegrafPluginEncode{
@@ -300,13 +304,14 @@ func (tc *TelegrafConfig) UnmarshalJSON(b []byte) error {
 		return err
 	}
 	*tc = TelegrafConfig{
-		ID:        tcd.ID,
-		Name:      tcd.Name,
-		Created:   tcd.Created,
-		LastMod:   tcd.LastMod,
-		LastModBy: tcd.LastModBy,
-		Agent:     tcd.Agent,
-		Plugins:   make([]TelegrafPlugin, len(tcd.Plugins)),
+		ID:             tcd.ID,
+		OrganizationID: tcd.OrganizationID,
+		Name:           tcd.Name,
+		Created:        tcd.Created,
+		LastMod:        tcd.LastMod,
+		LastModBy:      tcd.LastModBy,
+		Agent:          tcd.Agent,
+		Plugins:        make([]TelegrafPlugin, len(tcd.Plugins)),
 	}
 	return decodePluginRaw(tcd, tc)
 }
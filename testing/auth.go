@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/mock"
 )
@@ -29,6 +30,7 @@ var authorizationCmpOptions = cmp.Options{
 		})
 		return out
 	}),
+	cmpopts.IgnoreFields(platform.Authorization{}, "CreatedAt"),
 }
 
 // AuthorizationFields will include the IDGenerator, and authorizations
@@ -127,7 +129,6 @@ func CreateAuthorization(
 					{
 						ID:     MustIDBase16(authOneID),
 						UserID: MustIDBase16(userOneID),
-						Token:  "rand",
 						Status: platform.Active,
 						User:   "cooluser",
 						Permissions: []platform.Permission{
@@ -185,7 +186,6 @@ func CreateAuthorization(
 						UserID: MustIDBase16(userOneID),
 						User:   "cooluser",
 						Status: platform.Active,
-						Token:  "supersecret",
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
 							platform.DeleteUserPermission,
@@ -195,7 +195,6 @@ func CreateAuthorization(
 						ID:     MustIDBase16(authTwoID),
 						UserID: MustIDBase16(userTwoID),
 						User:   "regularuser",
-						Token:  "rand",
 						Status: platform.Active,
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
@@ -250,7 +249,6 @@ func CreateAuthorization(
 						UserID: MustIDBase16(userOneID),
 						User:   "cooluser",
 						Status: platform.Active,
-						Token:  "supersecret",
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
 							platform.DeleteUserPermission,
@@ -260,7 +258,6 @@ func CreateAuthorization(
 						ID:     MustIDBase16(authTwoID),
 						UserID: MustIDBase16(userTwoID),
 						User:   "regularuser",
-						Token:  "rand",
 						Status: platform.Active,
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
@@ -292,6 +289,15 @@ func CreateAuthorization(
 			defer s.DeleteAuthorization(ctx, tt.args.authorization.ID)
 			// }
 
+			if err == nil {
+				if tt.args.authorization.Token != "rand" {
+					t.Errorf("expected the created token to be returned, got %q", tt.args.authorization.Token)
+				}
+				if tt.args.authorization.CreatedAt == nil {
+					t.Errorf("expected the creation time to be set")
+				}
+			}
+
 			authorizations, _, err := s.FindAuthorizations(ctx, platform.AuthorizationFilter{})
 			if err != nil {
 				t.Fatalf("failed to retrieve authorizations: %v", err)
@@ -364,7 +370,6 @@ func FindAuthorizationByID(
 					UserID: MustIDBase16(userTwoID),
 					User:   "regularuser",
 					Status: platform.Active,
-					Token:  "rand2",
 					Permissions: []platform.Permission{
 						platform.CreateUserPermission,
 					},
@@ -469,7 +474,6 @@ func FindAuthorizationByToken(
 					UserID: MustIDBase16(userOneID),
 					Status: platform.Inactive,
 					User:   "cooluser",
-					Token:  "rand1",
 					Permissions: []platform.Permission{
 						platform.CreateUserPermission,
 						platform.DeleteUserPermission,
@@ -556,7 +560,6 @@ func FindAuthorizations(
 						ID:     MustIDBase16(authOneID),
 						UserID: MustIDBase16(userOneID),
 						User:   "cooluser",
-						Token:  "rand1",
 						Status: platform.Active,
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
@@ -567,7 +570,6 @@ func FindAuthorizations(
 						ID:     MustIDBase16(authTwoID),
 						UserID: MustIDBase16(userTwoID),
 						User:   "regularuser",
-						Token:  "rand2",
 						Status: platform.Active,
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
@@ -628,7 +630,6 @@ func FindAuthorizations(
 						UserID: MustIDBase16(userOneID),
 						User:   "cooluser",
 						Status: platform.Active,
-						Token:  "rand1",
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
 							platform.DeleteUserPermission,
@@ -639,7 +640,6 @@ func FindAuthorizations(
 						UserID: MustIDBase16(userOneID),
 						User:   "cooluser",
 						Status: platform.Active,
-						Token:  "rand3",
 						Permissions: []platform.Permission{
 							platform.DeleteUserPermission,
 						},
@@ -705,7 +705,6 @@ func FindAuthorizations(
 						ID:     MustIDBase16(authTwoID),
 						UserID: MustIDBase16(userTwoID),
 						User:   "regularuser",
-						Token:  "rand2",
 						Status: platform.Active,
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
@@ -804,7 +803,6 @@ func DeleteAuthorization(
 						UserID: MustIDBase16(userTwoID),
 						User:   "regularuser",
 						Status: platform.Active,
-						Token:  "rand2",
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
 						},
@@ -859,7 +857,6 @@ func DeleteAuthorization(
 						ID:     MustIDBase16(authOneID),
 						UserID: MustIDBase16(userOneID),
 						User:   "cooluser",
-						Token:  "rand1",
 						Status: platform.Active,
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
@@ -870,7 +867,6 @@ func DeleteAuthorization(
 						ID:     MustIDBase16(authTwoID),
 						UserID: MustIDBase16(userTwoID),
 						User:   "regularuser",
-						Token:  "rand2",
 						Status: platform.Active,
 						Permissions: []platform.Permission{
 							platform.CreateUserPermission,
@@ -0,0 +1,182 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/platform"
+)
+
+// OAuth2IdentityFields will include the users and identities to populate.
+type OAuth2IdentityFields struct {
+	Users      []*platform.User
+	Identities []*platform.OAuth2Identity
+}
+
+// OAuth2IdentityService tests all the service functions.
+func OAuth2IdentityService(
+	init func(OAuth2IdentityFields, *testing.T) (platform.OAuth2IdentityService, func()),
+	t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(OAuth2IdentityFields, *testing.T) (platform.OAuth2IdentityService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "FindOAuth2Identity",
+			fn:   FindOAuth2Identity,
+		},
+		{
+			name: "CreateOAuth2Identity",
+			fn:   CreateOAuth2Identity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+// FindOAuth2Identity testing
+func FindOAuth2Identity(
+	init func(OAuth2IdentityFields, *testing.T) (platform.OAuth2IdentityService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		issuer  string
+		subject string
+	}
+	type wants struct {
+		identity *platform.OAuth2Identity
+		code     string
+	}
+
+	fields := OAuth2IdentityFields{
+		Users: []*platform.User{
+			{ID: MustIDBase16(oneID), Name: "user1"},
+		},
+		Identities: []*platform.OAuth2Identity{
+			{Issuer: "github", Subject: "user1", UserID: MustIDBase16(oneID)},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields OAuth2IdentityFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "find identity of the issuer",
+			fields: fields,
+			args: args{
+				issuer:  "github",
+				subject: "user1",
+			},
+			wants: wants{
+				identity: &platform.OAuth2Identity{Issuer: "github", Subject: "user1", UserID: MustIDBase16(oneID)},
+			},
+		},
+		{
+			name:   "subject of another issuer",
+			fields: fields,
+			args: args{
+				issuer:  "google",
+				subject: "user1",
+			},
+			wants: wants{
+				code: platform.ENotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			i, err := s.FindOAuth2Identity(ctx, tt.args.issuer, tt.args.subject)
+			if code := platform.ErrorCode(err); code != tt.wants.code {
+				t.Fatalf("expected error code %q got %q: %v", tt.wants.code, code, err)
+			}
+			if diff := cmp.Diff(i, tt.wants.identity); diff != "" {
+				t.Errorf("identities are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// CreateOAuth2Identity testing
+func CreateOAuth2Identity(
+	init func(OAuth2IdentityFields, *testing.T) (platform.OAuth2IdentityService, func()),
+	t *testing.T,
+) {
+	fields := OAuth2IdentityFields{
+		Users: []*platform.User{
+			{ID: MustIDBase16(oneID), Name: "user1"},
+			{ID: MustIDBase16(twoID), Name: "user2"},
+		},
+		Identities: []*platform.OAuth2Identity{
+			{Issuer: "github", Subject: "user1", UserID: MustIDBase16(oneID)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fields   OAuth2IdentityFields
+		identity *platform.OAuth2Identity
+		code     string
+	}{
+		{
+			name:     "link the identity of another issuer",
+			fields:   fields,
+			identity: &platform.OAuth2Identity{Issuer: "google", Subject: "user1", UserID: MustIDBase16(twoID)},
+		},
+		{
+			name:     "identity linked to another user",
+			fields:   fields,
+			identity: &platform.OAuth2Identity{Issuer: "github", Subject: "user1", UserID: MustIDBase16(twoID)},
+			code:     platform.EConflict,
+		},
+		{
+			name:     "unknown user",
+			fields:   fields,
+			identity: &platform.OAuth2Identity{Issuer: "github", Subject: "user3", UserID: MustIDBase16(threeID)},
+			code:     platform.ENotFound,
+		},
+		{
+			name:     "identity without subject",
+			fields:   fields,
+			identity: &platform.OAuth2Identity{Issuer: "github", UserID: MustIDBase16(twoID)},
+			code:     platform.EInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CreateOAuth2Identity(ctx, tt.identity)
+			if code := platform.ErrorCode(err); code != tt.code {
+				t.Fatalf("expected error code %q got %q: %v", tt.code, code, err)
+			}
+			if err != nil {
+				return
+			}
+
+			i, err := s.FindOAuth2Identity(ctx, tt.identity.Issuer, tt.identity.Subject)
+			if err != nil {
+				t.Fatalf("failed to find the created identity: %v", err)
+			}
+			if diff := cmp.Diff(i, tt.identity); diff != "" {
+				t.Errorf("identities are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
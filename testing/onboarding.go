@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/influxdata/platform"
 	"github.com/influxdata/platform/mock"
 )
@@ -164,20 +165,12 @@ func Generate(
 						RetentionPeriod: time.Hour * 24 * 7,
 					},
 					Auth: &platform.Authorization{
-						ID:     MustIDBase16(fourID),
-						Token:  oneToken,
-						Status: platform.Active,
-						User:   "admin",
-						UserID: MustIDBase16(oneID),
-						Permissions: []platform.Permission{
-							platform.CreateUserPermission,
-							platform.DeleteUserPermission,
-							{
-								Resource: platform.OrganizationResource,
-								Action:   platform.WriteAction,
-							},
-							platform.WriteBucketPermission(MustIDBase16(threeID)),
-						},
+						ID:          MustIDBase16(fourID),
+						Token:       oneToken,
+						Status:      platform.Active,
+						User:        "admin",
+						UserID:      MustIDBase16(oneID),
+						Permissions: platform.OperPermissions(),
 					},
 				},
 			},
@@ -197,7 +190,7 @@ func Generate(
 					t.Fatalf("expected error code to match '%s' got '%v'", tt.wants.errCode, code)
 				}
 			}
-			if diff := cmp.Diff(results, tt.wants.results); diff != "" {
+			if diff := cmp.Diff(results, tt.wants.results, cmpopts.IgnoreFields(platform.Authorization{}, "CreatedAt")); diff != "" {
 				t.Errorf("onboarding results are different -got/+want\ndiff %s", diff)
 			}
 			if results != nil {
@@ -44,6 +44,10 @@ func SecretService(
 			name: "GetSecretKeys",
 			fn:   GetSecretKeys,
 		},
+		{
+			name: "DeleteSecret",
+			fn:   DeleteSecret,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -223,6 +227,25 @@ func GetSecretKeys(
 				keys: []string{"api_key"},
 			},
 		},
+		{
+			name: "get secret keys for org without secrets",
+			fields: SecretServiceFields{
+				Secrets: []Secret{
+					{
+						OrganizationID: platform.ID(2),
+						Env: map[string]string{
+							"api_key": "zyx321cba",
+						},
+					},
+				},
+			},
+			args: args{
+				orgID: platform.ID(1),
+			},
+			wants: wants{
+				keys: []string{},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -248,3 +271,86 @@ func GetSecretKeys(
 		})
 	}
 }
+
+// DeleteSecret tests the DeleteSecret method for the SecretService interface.
+func DeleteSecret(
+	init func(f SecretServiceFields, t *testing.T) (platform.SecretService, func()),
+	t *testing.T,
+) {
+	type args struct {
+		orgID platform.ID
+		keys  []string
+	}
+	type wants struct {
+		keys []string
+		err  error
+	}
+
+	tests := []struct {
+		name   string
+		fields SecretServiceFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "delete secrets of one org",
+			fields: SecretServiceFields{
+				Secrets: []Secret{
+					{
+						OrganizationID: platform.ID(1),
+						Env: map[string]string{
+							"api_key":  "abc123xyz",
+							"password": "hunter2",
+							"user":     "admin",
+						},
+					},
+					{
+						OrganizationID: platform.ID(2),
+						Env: map[string]string{
+							"api_key": "zyx321cba",
+						},
+					},
+				},
+			},
+			args: args{
+				orgID: platform.ID(1),
+				keys:  []string{"api_key", "password", "missing"},
+			},
+			wants: wants{
+				keys: []string{"user"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteSecret(ctx, tt.args.orgID, tt.args.keys...)
+			if (err != nil) != (tt.wants.err != nil) {
+				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
+			}
+
+			if err != nil && tt.wants.err != nil {
+				if err.Error() != tt.wants.err.Error() {
+					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
+				}
+			}
+
+			keys, err := s.GetSecretKeys(ctx, tt.args.orgID)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if diff := cmp.Diff(keys, tt.wants.keys); diff != "" {
+				t.Errorf("keys are different -got/+want\ndiff %s", diff)
+			}
+
+			if _, err := s.LoadSecret(ctx, platform.ID(2), "api_key"); err != nil {
+				t.Errorf("expected secrets of other organizations to be kept, got %v", err)
+			}
+		})
+	}
+}
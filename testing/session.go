@@ -14,8 +14,10 @@ import (
 )
 
 const (
-	sessionOneID = "020f755c3c082000"
-	sessionTwoID = "020f755c3c082001"
+	sessionOneID   = "020f755c3c082000"
+	sessionTwoID   = "020f755c3c082001"
+	sessionThreeID = "020f755c3c082002"
+	sessionFourID  = "020f755c3c082003"
 )
 
 var sessionCmpOptions = cmp.Options{
@@ -35,10 +37,12 @@ var sessionCmpOptions = cmp.Options{
 
 // SessionFields will include the IDGenerator, TokenGenerator, Sessions, and Users
 type SessionFields struct {
-	IDGenerator    platform.IDGenerator
-	TokenGenerator platform.TokenGenerator
-	Sessions       []*platform.Session
-	Users          []*platform.User
+	IDGenerator          platform.IDGenerator
+	TokenGenerator       platform.TokenGenerator
+	Sessions             []*platform.Session
+	Users                []*platform.User
+	Authorizations       []*platform.Authorization
+	UserResourceMappings []*platform.UserResourceMapping
 }
 
 // SessionService tests all the service functions.
@@ -112,6 +116,64 @@ func CreateSession(
 				},
 			},
 		},
+		{
+			name: "create session with the permissions of the user",
+			fields: SessionFields{
+				IDGenerator:    mock.NewIDGenerator(sessionTwoID, t),
+				TokenGenerator: mock.NewTokenGenerator("abc123xyz", nil),
+				Users: []*platform.User{
+					{
+						ID:   MustIDBase16(sessionOneID),
+						Name: "user1",
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:     MustIDBase16(sessionThreeID),
+						UserID: MustIDBase16(sessionOneID),
+						Token:  "active",
+						Status: platform.Active,
+						Permissions: []platform.Permission{
+							platform.WriteBucketPermission(MustIDBase16(sessionThreeID)),
+						},
+					},
+					{
+						ID:     MustIDBase16(sessionFourID),
+						UserID: MustIDBase16(sessionOneID),
+						Token:  "inactive",
+						Status: platform.Inactive,
+						Permissions: []platform.Permission{
+							platform.CreateUserPermission,
+						},
+					},
+				},
+				UserResourceMappings: []*platform.UserResourceMapping{
+					{
+						ResourceID:   MustIDBase16(sessionFourID),
+						ResourceType: platform.DashboardResourceType,
+						UserID:       MustIDBase16(sessionOneID),
+						UserType:     platform.Member,
+					},
+				},
+			},
+			args: args{
+				user: "user1",
+			},
+			wants: wants{
+				session: &platform.Session{
+					ID:     MustIDBase16(sessionTwoID),
+					UserID: MustIDBase16(sessionOneID),
+					Key:    "abc123xyz",
+					Permissions: []platform.Permission{
+						platform.WriteBucketPermission(MustIDBase16(sessionThreeID)),
+						{
+							Action:   platform.ReadAction,
+							Resource: platform.ResourceWithID(platform.DashboardResourceType, MustIDBase16(sessionFourID)),
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
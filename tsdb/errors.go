@@ -3,6 +3,9 @@ package tsdb
 import (
 	"errors"
 	"fmt"
+
+	"github.com/influxdata/platform"
+	"github.com/influxdata/platform/models"
 )
 
 var (
@@ -50,3 +53,66 @@ type PartialWriteError struct {
 func (e PartialWriteError) Error() string {
 	return fmt.Sprintf("partial write: %s dropped=%d", e.Reason, e.Dropped)
 }
+
+// FieldTypeConflict describes a series written with a different field type
+// than the one it was created with.
+type FieldTypeConflict struct {
+	Measurement string
+	Field       string
+	Existing    models.FieldType
+	Got         models.FieldType
+}
+
+// FieldTypeConflictError is a PartialWriteError returned when some of the
+// dropped series were written with conflicting field types.
+type FieldTypeConflictError struct {
+	PartialWriteError
+
+	// The conflicting series, in the order they were written.
+	Conflicts []FieldTypeConflict
+}
+
+func (e FieldTypeConflictError) Error() string {
+	c := e.Conflicts[0]
+	return fmt.Sprintf("partial write: field type conflict: input field %q on measurement %q is type %s, already exists as type %s dropped=%d",
+		c.Field, c.Measurement, c.Got, c.Existing, e.Dropped)
+}
+
+// Names of the limits on the series of a bucket.
+const (
+	MaxSeriesPerBucketLimit = "max-series-per-bucket"
+	MaxValuesPerTagLimit    = "max-values-per-tag"
+)
+
+// SeriesLimit describes a limit of a bucket that new series were dropped by.
+type SeriesLimit struct {
+	OrgID    platform.ID
+	BucketID platform.ID
+
+	// Limit is MaxSeriesPerBucketLimit or MaxValuesPerTagLimit.
+	Limit string
+	Max   int
+
+	// TagKey is the tag key that reached the max-values-per-tag limit.
+	TagKey string
+}
+
+func (l SeriesLimit) String() string {
+	if l.Limit == MaxValuesPerTagLimit {
+		return fmt.Sprintf("tag %q of bucket %s reached the %s limit of %d", l.TagKey, l.BucketID, l.Limit, l.Max)
+	}
+	return fmt.Sprintf("bucket %s reached the %s limit of %d", l.BucketID, l.Limit, l.Max)
+}
+
+// SeriesLimitError is a PartialWriteError returned when some of the dropped
+// series were new series of a bucket that reached one of its series limits.
+type SeriesLimitError struct {
+	PartialWriteError
+
+	// The limits reached, in the order they were reached.
+	Limits []SeriesLimit
+}
+
+func (e SeriesLimitError) Error() string {
+	return fmt.Sprintf("partial write: %s dropped=%d", e.Limits[0], e.Dropped)
+}
@@ -1,6 +1,7 @@
 package tsdb
 
 import (
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"unsafe"
@@ -24,6 +25,8 @@ type SeriesCollection struct {
 	Dropped     uint64
 	DroppedKeys [][]byte
 	Reason      string
+	Conflicts   []FieldTypeConflict
+	Limits      []SeriesLimit
 
 	// Used by the concurrent iterators to stage drops. Inefficient, but should be
 	// very infrequently used.
@@ -32,9 +35,10 @@ type SeriesCollection struct {
 
 // seriesCollectionState keeps track of concurrent iterator state.
 type seriesCollectionState struct {
-	mu     sync.Mutex
-	reason string
-	index  map[int]struct{}
+	mu        sync.Mutex
+	reason    string
+	index     map[int]struct{}
+	conflicts map[int]FieldTypeConflict
 }
 
 // NewSeriesCollection builds a SeriesCollection from a slice of points. It does some filtering
@@ -221,6 +225,10 @@ func (s *SeriesCollection) ApplyConcurrentDrops() {
 		if _, ok := state.index[i]; ok {
 			s.Dropped++
 
+			if c, ok := state.conflicts[i]; ok {
+				s.Conflicts = append(s.Conflicts, c)
+			}
+
 			if i < len(s.Keys) {
 				s.DroppedKeys = append(s.DroppedKeys, s.Keys[i])
 			}
@@ -275,18 +283,55 @@ func (s *SeriesCollection) invalidIndex(index int, reason string) {
 	state.mu.Unlock()
 }
 
+// invalidIndexType stages the index as invalid because its type conflicts with the type
+// of the existing series. It will be removed when ApplyConcurrentDrops is called.
+func (s *SeriesCollection) invalidIndexType(index int, existing models.FieldType) {
+	c := FieldTypeConflict{
+		Existing: existing,
+		Got:      s.Types[index],
+	}
+	if index < len(s.Tags) {
+		c.Measurement = string(s.Tags[index].Get(MeasurementTagKeyBytes))
+		c.Field = string(s.Tags[index].Get(FieldKeyTagKeyBytes))
+	}
+	s.invalidIndex(index, fmt.Sprintf("series type mismatch: already %s but got %s", c.Existing, c.Got))
+
+	state := s.getState(true)
+	state.mu.Lock()
+	if state.conflicts == nil {
+		state.conflicts = make(map[int]FieldTypeConflict)
+	}
+	state.conflicts[index] = c
+	state.mu.Unlock()
+}
+
 // PartialWriteError returns a PartialWriteError if any entries have been marked as invalid. It
 // returns an error to avoid `return collection.PartialWriteError()` always being non-nil.
+// If any entries were invalid because of a field type conflict, a FieldTypeConflictError
+// is returned, or a SeriesLimitError if any were invalid because of a series limit.
 func (s *SeriesCollection) PartialWriteError() error {
 	if s.Dropped == 0 {
 		return nil
 	}
 	droppedKeys := bytesutil.SortDedup(s.DroppedKeys)
-	return PartialWriteError{
+	err := PartialWriteError{
 		Reason:      s.Reason,
 		Dropped:     len(droppedKeys),
 		DroppedKeys: droppedKeys,
 	}
+	if len(s.Conflicts) > 0 {
+		return FieldTypeConflictError{
+			PartialWriteError: err,
+			Conflicts:         s.Conflicts,
+		}
+	}
+	if len(s.Limits) > 0 {
+		return SeriesLimitError{
+			PartialWriteError: err,
+			Limits:            s.Limits,
+		}
+	}
+	return err
 }
 
 // Iterator returns a new iterator over the entries in the collection. Multiple iterators
@@ -331,3 +376,10 @@ func (i SeriesCollectionIterator) SeriesID() SeriesID     { return i.s.SeriesIDs
 func (i *SeriesCollectionIterator) Invalid(reason string) {
 	i.s.invalidIndex(i.index, reason)
 }
+
+// InvalidType flags the current entry as invalid because its type conflicts with the
+// existing type of its series, recording the conflict. This is safe for concurrent callers,
+// but ApplyConcurrentDrops must be called after all iterators are finished.
+func (i *SeriesCollectionIterator) InvalidType(existing models.FieldType) {
+	i.s.invalidIndexType(i.index, existing)
+}
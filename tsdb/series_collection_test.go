@@ -146,4 +146,34 @@ func TestSeriesCollection(t *testing.T) {
 			DroppedKeys: bs("ka", "kc"),
 		})
 	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		collection := &SeriesCollection{
+			Keys: bs("ka", "kb"),
+			Tags: []models.Tags{
+				models.NewTags(map[string]string{MeasurementTagKey: "cpu", FieldKeyTagKey: "usage"}),
+				models.NewTags(map[string]string{MeasurementTagKey: "cpu", FieldKeyTagKey: "idle"}),
+			},
+			Types: []models.FieldType{models.Integer, models.Float},
+		}
+
+		for iter := collection.Iterator(); iter.Next(); {
+			if iter.Index() == 0 {
+				iter.InvalidType(models.Float)
+			}
+		}
+
+		collection.ApplyConcurrentDrops()
+		assertEqual(t, "length", collection.Length(), 1)
+		assertEqual(t, "error", collection.PartialWriteError(), FieldTypeConflictError{
+			PartialWriteError: PartialWriteError{
+				Reason:      "series type mismatch: already Float but got Integer",
+				Dropped:     1,
+				DroppedKeys: bs("ka"),
+			},
+			Conflicts: []FieldTypeConflict{
+				{Measurement: "cpu", Field: "usage", Existing: models.Float, Got: models.Integer},
+			},
+		})
+	})
 }
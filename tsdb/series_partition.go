@@ -197,9 +197,7 @@ func (p *SeriesPartition) CreateSeriesListIfNotExists(collection *SeriesCollecti
 			continue
 		}
 		if id.HasType() && id.Type() != iter.Type() {
-			iter.Invalid(fmt.Sprintf(
-				"series type mismatch: already %s but got %s",
-				id.Type(), iter.Type()))
+			iter.InvalidType(id.Type())
 			continue
 		}
 		collection.SeriesIDs[index] = id.SeriesID()
@@ -251,9 +249,7 @@ func (p *SeriesPartition) CreateSeriesListIfNotExists(collection *SeriesCollecti
 		// if the type matches.
 		if !id.IsZero() {
 			if id.HasType() && id.Type() != typ {
-				iter.Invalid(fmt.Sprintf(
-					"series type mismatch: already %s but got %s",
-					id.Type(), iter.Type()))
+				iter.InvalidType(id.Type())
 				continue
 			}
 			collection.SeriesIDs[index] = id.SeriesID()
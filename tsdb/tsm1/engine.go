@@ -1166,6 +1166,16 @@ func (e *Engine) WriteSnapshot() error {
 	return e.writeSnapshotAndCommit(log, closedFiles, snapshot)
 }
 
+// CreateSnapshot writes the cache to a new TSM file and creates hard links to
+// all TSM and tombstone files in a new directory, whose path is returned.
+// The caller must remove the directory once it's done with it.
+func (e *Engine) CreateSnapshot() (string, error) {
+	if err := e.WriteSnapshot(); err != nil {
+		return "", err
+	}
+	return e.FileStore.CreateSnapshot()
+}
+
 // writeSnapshotAndCommit will write the passed cache to a new TSM file and remove the closed WAL segments.
 func (e *Engine) writeSnapshotAndCommit(log *zap.Logger, closedFiles []string, snapshot *Cache) (err error) {
 	defer func() {
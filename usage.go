@@ -38,6 +38,15 @@ type UsageService interface {
 	GetUsage(ctx context.Context, filter UsageFilter) (map[UsageMetric]*Usage, error)
 }
 
+// UsageRecorder records the usage of organizations.
+type UsageRecorder interface {
+	// RecordUsage adds value to the usage metric m of the bucket bucketID of
+	// the organization orgID at t. The bucket is invalid for usage of the
+	// organization as a whole, such as queries. The usage may be batched and
+	// only stored some time later.
+	RecordUsage(ctx context.Context, orgID, bucketID ID, m UsageMetric, value float64, t time.Time)
+}
+
 // UsageFilter is used to filter usage.
 type UsageFilter struct {
 	OrgID    *ID
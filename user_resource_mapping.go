@@ -18,6 +18,13 @@ const (
 	OrgResourceType       ResourceType = "org"
 	ViewResourceType      ResourceType = "view"
 	TelegrafResourceType  ResourceType = "telegraf"
+	ScraperResourceType   ResourceType = "scraper"
+
+	// resource types that are only used by permissions.
+	UserResourceType          ResourceType = "user"
+	MacroResourceType         ResourceType = "macro"
+	AuthorizationResourceType ResourceType = "authorization"
+	SecretResourceType        ResourceType = "secret"
 )
 
 // UserResourceMappingService maps the relationships between users and resources
@@ -52,7 +59,7 @@ func (m UserResourceMapping) Validate() error {
 		return errors.New("a valid user type is required")
 	}
 	switch m.ResourceType {
-	case DashboardResourceType, BucketResourceType, TaskResourceType, OrgResourceType, ViewResourceType, TelegrafResourceType:
+	case DashboardResourceType, BucketResourceType, TaskResourceType, OrgResourceType, ViewResourceType, TelegrafResourceType, ScraperResourceType:
 	default:
 		return errors.New("a valid resource type is required")
 	}
@@ -75,13 +75,23 @@ func TestOwnerMappingValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "scraper is a valid resourcetype",
+			fields: fields{
+				ResourceID:   platformtesting.MustIDBase16("020f755c3c082000"),
+				UserID:       platformtesting.MustIDBase16("debac1e0deadbeef"),
+				UserType:     platform.Owner,
+				ResourceType: platform.ScraperResourceType,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := platform.UserResourceMapping{
-				ResourceID: tt.fields.ResourceID,
-				UserID:     tt.fields.UserID,
-				UserType:   tt.fields.UserType,
+				ResourceID:   tt.fields.ResourceID,
+				ResourceType: tt.fields.ResourceType,
+				UserID:       tt.fields.UserID,
+				UserType:     tt.fields.UserType,
 			}
 			if err := m.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("OwnerMapping.Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -63,8 +63,9 @@ type View struct {
 
 // ViewContents is the id and name of a specific view.
 type ViewContents struct {
-	ID   ID     `json:"id,omitempty"`
-	Name string `json:"name"`
+	ID             ID     `json:"id,omitempty"`
+	OrganizationID ID     `json:"orgID,omitempty"`
+	Name           string `json:"name"`
 }
 
 // ViewProperties is used to mark other structures as conforming to a View.
@@ -2,6 +2,7 @@ package platform
 
 import (
 	"context"
+	"fmt"
 	"io"
 )
 
@@ -9,3 +10,37 @@ import (
 type WriteService interface {
 	Write(ctx context.Context, org, bucket ID, r io.Reader) error
 }
+
+// Codes describing why a line of line protocol was rejected by a partial write.
+const (
+	WriteCodeParseError        = "parse error"
+	WriteCodeBadTimestamp      = "bad timestamp"
+	WriteCodeFieldTypeConflict = "field type conflict"
+	WriteCodeSeriesLimit       = "series limit"
+	WriteCodeWriteError        = "write error"
+)
+
+// WriteReport is the result of a partial write. Valid lines are written
+// and lines that could not be written are reported as rejected.
+type WriteReport struct {
+	Accepted int            `json:"accepted"`
+	Rejected []RejectedLine `json:"rejected"`
+}
+
+// RejectedLine is a line of line protocol that was not written.
+type RejectedLine struct {
+	Line   int    `json:"line"`
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// PartialWriteError is returned by a WriteService when some lines
+// of a write were rejected.
+type PartialWriteError struct {
+	Report WriteReport
+}
+
+// Error implements the error interface.
+func (e *PartialWriteError) Error() string {
+	return fmt.Sprintf("partial write: %d lines accepted, %d lines rejected", e.Report.Accepted, len(e.Report.Rejected))
+}
@@ -104,6 +104,36 @@ func (b *Batcher) write(ctx context.Context, org, bucket platform.ID, lines <-ch
 	buf := make([]byte, 0, maxBytes)
 	r := bytes.NewReader(buf)
 
+	// report collects the rejected lines of partial writes, numbered
+	// from the start of the input rather than the start of each batch.
+	var (
+		report    platform.WriteReport
+		partial   bool
+		offset    int
+		bufLines  int
+		bufPoints int
+	)
+	flush := func() error {
+		r.Reset(buf)
+		timer.Reset(flushInterval)
+		err := b.Service.Write(ctx, org, bucket, r)
+		if perr, ok := err.(*platform.PartialWriteError); ok {
+			partial = true
+			report.Accepted += perr.Report.Accepted
+			for _, l := range perr.Report.Rejected {
+				l.Line += offset
+				report.Rejected = append(report.Rejected, l)
+			}
+			err = nil
+		} else if err == nil {
+			report.Accepted += bufPoints
+		}
+		offset += bufLines
+		bufLines, bufPoints = 0, 0
+		buf = buf[:0]
+		return err
+	}
+
 	var line []byte
 	var more = true
 	// if read closes the channel normally, exit the loop
@@ -112,26 +142,24 @@ func (b *Batcher) write(ctx context.Context, org, bucket platform.ID, lines <-ch
 		case line, more = <-lines:
 			if more {
 				buf = append(buf, line...)
+				bufLines++
+				if isPointLine(line) {
+					bufPoints++
+				}
 			}
 			// write if we exceed the max lines OR read routine has finished
 			if len(buf) >= maxBytes || (!more && len(buf) > 0) {
-				r.Reset(buf)
-				timer.Reset(flushInterval)
-				if err := b.Service.Write(ctx, org, bucket, r); err != nil {
+				if err := flush(); err != nil {
 					errC <- err
 					return
 				}
-				buf = buf[:0]
 			}
 		case <-timer.C:
 			if len(buf) > 0 {
-				r.Reset(buf)
-				timer.Reset(flushInterval)
-				if err := b.Service.Write(ctx, org, bucket, r); err != nil {
+				if err := flush(); err != nil {
 					errC <- err
 					return
 				}
-				buf = buf[:0]
 			}
 		case <-ctx.Done():
 			errC <- ctx.Err()
@@ -139,9 +167,20 @@ func (b *Batcher) write(ctx context.Context, org, bucket platform.ID, lines <-ch
 		}
 	}
 
+	if partial {
+		errC <- &platform.PartialWriteError{Report: report}
+		return
+	}
 	errC <- nil
 }
 
+// isPointLine reports whether line holds a point rather than
+// being blank or a comment.
+func isPointLine(line []byte) bool {
+	line = bytes.TrimSpace(line)
+	return len(line) > 0 && line[0] != '#'
+}
+
 // ScanLines is used in bufio.Scanner.Split to split lines of line protocol.
 func ScanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	if atEOF && len(data) == 0 {
@@ -427,3 +427,48 @@ func TestBatcher_WriteTimeout(t *testing.T) {
 		t.Errorf(" Batcher.Write() with timeout got %s", got)
 	}
 }
+
+func TestBatcher_WritePartial(t *testing.T) {
+	// the service rejects the second line, which is the first line of
+	// the second batch.
+	svc := &mock.WriteService{
+		WriteF: func(ctx context.Context, org, bucket platform.ID, r io.Reader) error {
+			b, err := ioutil.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			if !strings.HasPrefix(string(b), "m2") {
+				return nil
+			}
+			return &platform.PartialWriteError{
+				Report: platform.WriteReport{
+					Rejected: []platform.RejectedLine{
+						{Line: 1, Code: platform.WriteCodeParseError, Reason: "bad"},
+					},
+				},
+			}
+		},
+	}
+
+	b := &Batcher{
+		MaxFlushBytes: len([]byte("m1,t1=v1 f1=1\n")),
+		Service:       svc,
+	}
+
+	r := strings.NewReader("m1,t1=v1 f1=1\nm2,t2=v2 f2=2\nm3,t3=v3 f3=3")
+	err := b.Write(context.Background(), platform.ID(1), platform.ID(2), r)
+	perr, ok := err.(*platform.PartialWriteError)
+	if !ok {
+		t.Fatalf("Batcher.Write() error = %v, want partial write error", err)
+	}
+
+	want := platform.WriteReport{
+		Accepted: 2,
+		Rejected: []platform.RejectedLine{
+			{Line: 2, Code: platform.WriteCodeParseError, Reason: "bad"},
+		},
+	}
+	if !cmp.Equal(perr.Report, want) {
+		t.Errorf("Batcher.Write() report = -got/+want %s", cmp.Diff(perr.Report, want))
+	}
+}